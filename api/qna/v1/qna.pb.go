@@ -0,0 +1,1030 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/qna/v1/qna.proto
+
+// Typed API for internal services. It mirrors the REST endpoints under
+// /api/questions and /api/answers.
+
+package qnav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AnswerEvent_Type int32
+
+const (
+	AnswerEvent_TYPE_UNSPECIFIED AnswerEvent_Type = 0
+	AnswerEvent_TYPE_CREATED     AnswerEvent_Type = 1
+	AnswerEvent_TYPE_DELETED     AnswerEvent_Type = 2
+)
+
+// Enum value maps for AnswerEvent_Type.
+var (
+	AnswerEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_DELETED",
+	}
+	AnswerEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_DELETED":     2,
+	}
+)
+
+func (x AnswerEvent_Type) Enum() *AnswerEvent_Type {
+	p := new(AnswerEvent_Type)
+	*p = x
+	return p
+}
+
+func (x AnswerEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnswerEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_qna_v1_qna_proto_enumTypes[0].Descriptor()
+}
+
+func (AnswerEvent_Type) Type() protoreflect.EnumType {
+	return &file_api_qna_v1_qna_proto_enumTypes[0]
+}
+
+func (x AnswerEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnswerEvent_Type.Descriptor instead.
+func (AnswerEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type Question struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text      string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// user_id is the asker; empty for questions created before askers were
+	// recorded.
+	UserId      string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AnswerCount int32  `protobuf:"varint,5,opt,name=answer_count,json=answerCount,proto3" json:"answer_count,omitempty"`
+	// last_activity_at is when the newest answer was added, or created_at
+	// while the question has none.
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Question) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Question) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Question) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Question) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Question) GetAnswerCount() int32 {
+	if x != nil {
+		return x.AnswerCount
+	}
+	return 0
+}
+
+func (x *Question) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+type Answer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuestionId    int64                  `protobuf:"varint,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Answer) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Answer) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *Answer) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Answer) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Answer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Lists are ordered by id. page_size defaults to 50 and cannot exceed 100;
+// page_token is the next_page_token of the previous page.
+type ListQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsRequest) Reset() {
+	*x = ListQuestionsRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsRequest) ProtoMessage() {}
+
+func (x *ListQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ListQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListQuestionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListQuestionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListQuestionsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Questions []*Question            `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuestionsResponse) Reset() {
+	*x = ListQuestionsResponse{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuestionsResponse) ProtoMessage() {}
+
+func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ListQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListQuestionsResponse) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *ListQuestionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionRequest) Reset() {
+	*x = GetQuestionRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionRequest) ProtoMessage() {}
+
+func (x *GetQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetQuestionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateQuestionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// user_id is optional.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateQuestionRequest) Reset() {
+	*x = CreateQuestionRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQuestionRequest) ProtoMessage() {}
+
+func (x *CreateQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*CreateQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateQuestionRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteQuestionRequest) Reset() {
+	*x = DeleteQuestionRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteQuestionRequest) ProtoMessage() {}
+
+func (x *DeleteQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteQuestionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteQuestionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListAnswersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnswersRequest) Reset() {
+	*x = ListAnswersRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersRequest) ProtoMessage() {}
+
+func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAnswersRequest) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *ListAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAnswersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAnswersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answers       []*Answer              `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnswersResponse) Reset() {
+	*x = ListAnswersResponse{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersResponse) ProtoMessage() {}
+
+func (x *ListAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ListAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAnswersResponse) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *ListAnswersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAnswerRequest) Reset() {
+	*x = GetAnswerRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerRequest) ProtoMessage() {}
+
+func (x *GetAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerRequest.ProtoReflect.Descriptor instead.
+func (*GetAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAnswerRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnswerRequest) Reset() {
+	*x = CreateAnswerRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnswerRequest) ProtoMessage() {}
+
+func (x *CreateAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnswerRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateAnswerRequest) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *CreateAnswerRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateAnswerRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type DeleteAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAnswerRequest) Reset() {
+	*x = DeleteAnswerRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAnswerRequest) ProtoMessage() {}
+
+func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAnswerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteAnswerRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchAnswersRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	// last_event_id resumes after a reconnect; events still in the replay
+	// buffer are sent first.
+	LastEventId   uint64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAnswersRequest) Reset() {
+	*x = WatchAnswersRequest{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAnswersRequest) ProtoMessage() {}
+
+func (x *WatchAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAnswersRequest.ProtoReflect.Descriptor instead.
+func (*WatchAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchAnswersRequest) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *WatchAnswersRequest) GetLastEventId() uint64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type AnswerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          AnswerEvent_Type       `protobuf:"varint,2,opt,name=type,proto3,enum=qna.v1.AnswerEvent_Type" json:"type,omitempty"`
+	Answer        *Answer                `protobuf:"bytes,3,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerEvent) Reset() {
+	*x = AnswerEvent{}
+	mi := &file_api_qna_v1_qna_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerEvent) ProtoMessage() {}
+
+func (x *AnswerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_qna_v1_qna_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerEvent.ProtoReflect.Descriptor instead.
+func (*AnswerEvent) Descriptor() ([]byte, []int) {
+	return file_api_qna_v1_qna_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AnswerEvent) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AnswerEvent) GetType() AnswerEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return AnswerEvent_TYPE_UNSPECIFIED
+}
+
+func (x *AnswerEvent) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+var File_api_qna_v1_qna_proto protoreflect.FileDescriptor
+
+const file_api_qna_v1_qna_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/qna/v1/qna.proto\x12\x06qna.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xeb\x01\n" +
+	"\bQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12!\n" +
+	"\fanswer_count\x18\x05 \x01(\x05R\vanswerCount\x12D\n" +
+	"\x10last_activity_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\"\xa1\x01\n" +
+	"\x06Answer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\x03R\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"R\n" +
+	"\x14ListQuestionsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"o\n" +
+	"\x15ListQuestionsResponse\x12.\n" +
+	"\tquestions\x18\x01 \x03(\v2\x10.qna.v1.QuestionR\tquestions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"$\n" +
+	"\x12GetQuestionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"D\n" +
+	"\x15CreateQuestionRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"'\n" +
+	"\x15DeleteQuestionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"q\n" +
+	"\x12ListAnswersRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x03R\n" +
+	"questionId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"g\n" +
+	"\x13ListAnswersResponse\x12(\n" +
+	"\aanswers\x18\x01 \x03(\v2\x0e.qna.v1.AnswerR\aanswers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\"\n" +
+	"\x10GetAnswerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"c\n" +
+	"\x13CreateAnswerRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x03R\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"%\n" +
+	"\x13DeleteAnswerRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"Z\n" +
+	"\x13WatchAnswersRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x03R\n" +
+	"questionId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x04R\vlastEventId\"\xb5\x01\n" +
+	"\vAnswerEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12,\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.qna.v1.AnswerEvent.TypeR\x04type\x12&\n" +
+	"\x06answer\x18\x03 \x01(\v2\x0e.qna.v1.AnswerR\x06answer\"@\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fTYPE_CREATED\x10\x01\x12\x10\n" +
+	"\fTYPE_DELETED\x10\x022\xa8\x02\n" +
+	"\x0fQuestionService\x12L\n" +
+	"\rListQuestions\x12\x1c.qna.v1.ListQuestionsRequest\x1a\x1d.qna.v1.ListQuestionsResponse\x12;\n" +
+	"\vGetQuestion\x12\x1a.qna.v1.GetQuestionRequest\x1a\x10.qna.v1.Question\x12A\n" +
+	"\x0eCreateQuestion\x12\x1d.qna.v1.CreateQuestionRequest\x1a\x10.qna.v1.Question\x12G\n" +
+	"\x0eDeleteQuestion\x12\x1d.qna.v1.DeleteQuestionRequest\x1a\x16.google.protobuf.Empty2\xd4\x02\n" +
+	"\rAnswerService\x12F\n" +
+	"\vListAnswers\x12\x1a.qna.v1.ListAnswersRequest\x1a\x1b.qna.v1.ListAnswersResponse\x125\n" +
+	"\tGetAnswer\x12\x18.qna.v1.GetAnswerRequest\x1a\x0e.qna.v1.Answer\x12;\n" +
+	"\fCreateAnswer\x12\x1b.qna.v1.CreateAnswerRequest\x1a\x0e.qna.v1.Answer\x12C\n" +
+	"\fDeleteAnswer\x12\x1b.qna.v1.DeleteAnswerRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\fWatchAnswers\x12\x1b.qna.v1.WatchAnswersRequest\x1a\x13.qna.v1.AnswerEvent0\x01B4Z2api_service_questions_and_answers/api/qna/v1;qnav1b\x06proto3"
+
+var (
+	file_api_qna_v1_qna_proto_rawDescOnce sync.Once
+	file_api_qna_v1_qna_proto_rawDescData []byte
+)
+
+func file_api_qna_v1_qna_proto_rawDescGZIP() []byte {
+	file_api_qna_v1_qna_proto_rawDescOnce.Do(func() {
+		file_api_qna_v1_qna_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_qna_v1_qna_proto_rawDesc), len(file_api_qna_v1_qna_proto_rawDesc)))
+	})
+	return file_api_qna_v1_qna_proto_rawDescData
+}
+
+var file_api_qna_v1_qna_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_qna_v1_qna_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_api_qna_v1_qna_proto_goTypes = []any{
+	(AnswerEvent_Type)(0),         // 0: qna.v1.AnswerEvent.Type
+	(*Question)(nil),              // 1: qna.v1.Question
+	(*Answer)(nil),                // 2: qna.v1.Answer
+	(*ListQuestionsRequest)(nil),  // 3: qna.v1.ListQuestionsRequest
+	(*ListQuestionsResponse)(nil), // 4: qna.v1.ListQuestionsResponse
+	(*GetQuestionRequest)(nil),    // 5: qna.v1.GetQuestionRequest
+	(*CreateQuestionRequest)(nil), // 6: qna.v1.CreateQuestionRequest
+	(*DeleteQuestionRequest)(nil), // 7: qna.v1.DeleteQuestionRequest
+	(*ListAnswersRequest)(nil),    // 8: qna.v1.ListAnswersRequest
+	(*ListAnswersResponse)(nil),   // 9: qna.v1.ListAnswersResponse
+	(*GetAnswerRequest)(nil),      // 10: qna.v1.GetAnswerRequest
+	(*CreateAnswerRequest)(nil),   // 11: qna.v1.CreateAnswerRequest
+	(*DeleteAnswerRequest)(nil),   // 12: qna.v1.DeleteAnswerRequest
+	(*WatchAnswersRequest)(nil),   // 13: qna.v1.WatchAnswersRequest
+	(*AnswerEvent)(nil),           // 14: qna.v1.AnswerEvent
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 16: google.protobuf.Empty
+}
+var file_api_qna_v1_qna_proto_depIdxs = []int32{
+	15, // 0: qna.v1.Question.created_at:type_name -> google.protobuf.Timestamp
+	15, // 1: qna.v1.Question.last_activity_at:type_name -> google.protobuf.Timestamp
+	15, // 2: qna.v1.Answer.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 3: qna.v1.ListQuestionsResponse.questions:type_name -> qna.v1.Question
+	2,  // 4: qna.v1.ListAnswersResponse.answers:type_name -> qna.v1.Answer
+	0,  // 5: qna.v1.AnswerEvent.type:type_name -> qna.v1.AnswerEvent.Type
+	2,  // 6: qna.v1.AnswerEvent.answer:type_name -> qna.v1.Answer
+	3,  // 7: qna.v1.QuestionService.ListQuestions:input_type -> qna.v1.ListQuestionsRequest
+	5,  // 8: qna.v1.QuestionService.GetQuestion:input_type -> qna.v1.GetQuestionRequest
+	6,  // 9: qna.v1.QuestionService.CreateQuestion:input_type -> qna.v1.CreateQuestionRequest
+	7,  // 10: qna.v1.QuestionService.DeleteQuestion:input_type -> qna.v1.DeleteQuestionRequest
+	8,  // 11: qna.v1.AnswerService.ListAnswers:input_type -> qna.v1.ListAnswersRequest
+	10, // 12: qna.v1.AnswerService.GetAnswer:input_type -> qna.v1.GetAnswerRequest
+	11, // 13: qna.v1.AnswerService.CreateAnswer:input_type -> qna.v1.CreateAnswerRequest
+	12, // 14: qna.v1.AnswerService.DeleteAnswer:input_type -> qna.v1.DeleteAnswerRequest
+	13, // 15: qna.v1.AnswerService.WatchAnswers:input_type -> qna.v1.WatchAnswersRequest
+	4,  // 16: qna.v1.QuestionService.ListQuestions:output_type -> qna.v1.ListQuestionsResponse
+	1,  // 17: qna.v1.QuestionService.GetQuestion:output_type -> qna.v1.Question
+	1,  // 18: qna.v1.QuestionService.CreateQuestion:output_type -> qna.v1.Question
+	16, // 19: qna.v1.QuestionService.DeleteQuestion:output_type -> google.protobuf.Empty
+	9,  // 20: qna.v1.AnswerService.ListAnswers:output_type -> qna.v1.ListAnswersResponse
+	2,  // 21: qna.v1.AnswerService.GetAnswer:output_type -> qna.v1.Answer
+	2,  // 22: qna.v1.AnswerService.CreateAnswer:output_type -> qna.v1.Answer
+	16, // 23: qna.v1.AnswerService.DeleteAnswer:output_type -> google.protobuf.Empty
+	14, // 24: qna.v1.AnswerService.WatchAnswers:output_type -> qna.v1.AnswerEvent
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_api_qna_v1_qna_proto_init() }
+func file_api_qna_v1_qna_proto_init() {
+	if File_api_qna_v1_qna_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_qna_v1_qna_proto_rawDesc), len(file_api_qna_v1_qna_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_api_qna_v1_qna_proto_goTypes,
+		DependencyIndexes: file_api_qna_v1_qna_proto_depIdxs,
+		EnumInfos:         file_api_qna_v1_qna_proto_enumTypes,
+		MessageInfos:      file_api_qna_v1_qna_proto_msgTypes,
+	}.Build()
+	File_api_qna_v1_qna_proto = out.File
+	file_api_qna_v1_qna_proto_goTypes = nil
+	file_api_qna_v1_qna_proto_depIdxs = nil
+}
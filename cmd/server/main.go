@@ -7,48 +7,177 @@ package main
 // @host localhost:8080
 // @BasePath /api
 // @query.collection.format multi
+
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin API token as "Bearer <token>"
 import (
+	"api_service_questions_and_answers/internal/cache"
 	"api_service_questions_and_answers/internal/config"
-	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/graph"
+	"api_service_questions_and_answers/internal/grpcapi"
 	"api_service_questions_and_answers/internal/handlers"
-	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/outbox"
+	"api_service_questions_and_answers/internal/repositories/cached"
 	"api_service_questions_and_answers/internal/route"
 	"api_service_questions_and_answers/internal/services"
+	"api_service_questions_and_answers/internal/webhooks"
+	"api_service_questions_and_answers/internal/ws"
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
 
 	_ "api_service_questions_and_answers/docs"
 
 	"github.com/swaggo/http-swagger"
+	"golang.org/x/text/language"
 )
 
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the effective config with secrets masked and exit")
+	flag.Parse()
+
+	if flag.Arg(0) == "migrate" {
+		runMigrate(flag.Args()[1:])
+		return
+	}
+	if flag.Arg(0) == "repair-stats" {
+		runRepairStats()
+		return
+	}
+	if flag.Arg(0) == "rebuild-reputation" {
+		runRebuildReputation()
+		return
+	}
+	if flag.Arg(0) == "render-markdown" {
+		runRenderMarkdown()
+		return
+	}
+
 	cfg := config.LoadConfig()
 
-	db, err := database.NewDatabase(cfg.DB)
+	if *printConfig {
+		out, err := cfg.Masked().YAML()
+		if err != nil {
+			log.Fatal("Failed to render config:", err)
+		}
+		fmt.Print(string(out))
+		return
+	}
+
+	store := newStorage(cfg)
+
+	broadcaster := events.NewBroadcaster(cfg.Events.ReplayBuffer)
+	hub := ws.NewHub(cfg.WebSocket.MaxConnections, cfg.WebSocket.SendBuffer, cfg.WebSocket.PingInterval)
+
+	worker := webhooks.NewWorker(store.webhooks, cfg.Webhooks)
+	go worker.Run(context.Background())
+	webhookService := services.NewWebhookService(store.webhooks, worker.Wake)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+	notificationService := services.NewNotificationService(store.questions, store.follows, store.notifications)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+
+	publisher := events.Fanout(broadcaster, hub, notificationService)
+	recorders := []outbox.Recorder{webhooks.NewRecorder(worker.Wake)}
+	relay := outbox.NewRelay(store.outbox, outbox.Sinks(cfg.Outbox, publisher), recorders, cfg.Outbox)
+	go relay.Run(context.Background())
+
+	questionRepo, answerRepo := store.questions, store.answers
+	transactor := store.transactor(relay.Wake)
+
+	var repoCache cache.Cache
+	if cfg.Cache.Enabled {
+		repoCache = cache.NewLRU(cfg.Cache.MaxEntries, cfg.Cache.TTL)
+		questionRepo = cached.NewQuestionRepository(questionRepo, repoCache)
+		answerRepo = cached.NewAnswerRepository(answerRepo, repoCache)
+		transactor = cached.NewTransactor(transactor, repoCache)
+	}
+
+	filters, err := filter.New(cfg.ContentFilters)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Fatal("Failed to set up content filters:", err)
 	}
 
-	questionRepo := repositories.NewQuestionRepository(db.DB)
-	questionService := services.NewQuestionService(questionRepo)
+	blobs, err := newBlobStore(cfg.Attachments)
+	if err != nil {
+		log.Fatal("Failed to set up the attachment store:", err)
+	}
+	attachmentService := services.NewAttachmentService(questionRepo, answerRepo, store.attachments, blobs,
+		cfg.Attachments.MaxSize, cfg.Attachments.AllowedTypes)
+
+	questionService := services.NewQuestionService(questionRepo, store.users, transactor, filters.Questions)
+	questionService = services.DeleteQuestionAttachments(questionService, store.attachments, blobs)
 	questionHandler := handlers.NewQuestionHandler(questionService)
 
-	answerRepo := repositories.NewAnswerRepository(db.DB)
-	answerService := services.NewAnswerService(questionRepo, answerRepo)
+	eventHandler := handlers.NewEventHandler(questionService, broadcaster, cfg.Events.Heartbeat)
+	webSocketHandler := handlers.NewWebSocketHandler(hub, cfg.CORS.AllowedOrigins)
+
+	userService := services.NewUserService(store.users)
+	userHandler := handlers.NewUserHandler(userService)
+
+	answerService := services.NewAnswerService(questionRepo, answerRepo, store.users, transactor, cfg.Reputation.Rules(),
+		filters.Answers)
+	answerService = services.DeleteAnswerAttachments(answerService, store.attachments, blobs)
 	answerHandler := handlers.NewAnswerHandler(answerService)
 
-	apiRoute := route.SetupQuestionRoutes(questionHandler, answerHandler)
+	moderationService := services.NewModerationService(questionRepo, answerRepo, store.users, store.moderation, transactor,
+		questionService, answerService, cfg.Reputation.Rules(), cfg.Moderation.FlagThreshold)
+
+	apiRoute := route.SetupQuestionRoutes(route.Handlers{
+		Question:     questionHandler,
+		Answer:       answerHandler,
+		Event:        eventHandler,
+		WebSocket:    webSocketHandler,
+		Webhook:      webhookHandler,
+		Notification: notificationHandler,
+		User:         userHandler,
+		Reputation:   handlers.NewReputationHandler(services.NewReputationService(store.users, store.reputation)),
+		Moderation:   handlers.NewModerationHandler(moderationService),
+		Activity:     handlers.NewActivityHandler(services.NewActivityService(questionRepo, answerRepo)),
+		Attachment:   handlers.NewAttachmentHandler(attachmentService, cfg.Attachments.MaxSize),
+		GraphQL:      graph.NewHandler(questionService, answerService),
+		QuestionV2:   handlers.NewQuestionV2Handler(questionService),
+		AnswerV2:     handlers.NewAnswerV2Handler(answerService),
+	}, cfg.Admin.Token, cfg.API.V1)
+
+	if cfg.GRPC.Enabled {
+		grpcAddr := cfg.Server.Address + ":" + cfg.GRPC.Port
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatal("Failed to listen for gRPC:", err)
+		}
+		grpcServer := grpcapi.NewServer(questionService, answerService, broadcaster, cfg.GRPC.Token, language.Make(cfg.I18n.DefaultLocale))
+		log.Printf("gRPC server starting on %s", grpcAddr)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatal("gRPC server failed:", err)
+			}
+		}()
+	}
 
 	mux := http.NewServeMux()
-	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	if cfg.Swagger.Enabled {
+		mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	}
+	if repoCache != nil {
+		mux.Handle("/metrics/cache", route.CacheStats(repoCache))
+	}
 	mux.Handle("/", apiRoute)
 
 	serverAddr := cfg.Server.Address + ":" + cfg.Server.Port
 	log.Printf("Server starting on %s", serverAddr)
-	log.Printf("Swagger documentation available at http://localhost:8080/swagger/index.html")
+	if cfg.Swagger.Enabled {
+		log.Printf("Swagger documentation available at http://%s/swagger/index.html", serverAddr)
+	}
 
-	if err := http.ListenAndServe(serverAddr, mux); err != nil {
+	if err := http.ListenAndServe(serverAddr, route.CORS(cfg.CORS)(route.Locale(cfg.I18n)(mux))); err != nil {
 		log.Fatal("Server failed to start:", err)
 	}
 }
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"api_service_questions_and_answers/database/migrations"
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"context"
+	"fmt"
+	"log"
+	"os"
+)
+
+const migrateUsage = `usage: server migrate <command> [args]
+
+commands:
+  up             apply all pending migrations
+  down           roll back the latest migration
+  redo           roll back the latest migration and apply it again
+  status         list migrations and when they were applied
+  version        print the current database version
+  create <name>  create a new SQL migration in ` + migrations.Dir + `
+`
+
+// runMigrate handles "server migrate ..." and exits the process on failure.
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	if args[0] == "create" {
+		if len(args) < 2 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			os.Exit(2)
+		}
+		if err := database.CreateMigration(migrations.Dir, args[1], os.Stdout); err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		return
+	}
+
+	cfg := config.LoadConfig()
+	cfg.DB.AutoMigrate = false
+
+	db, err := database.NewDatabase(cfg.DB)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := db.Migrate(context.Background(), args[0], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/markdown"
+	"api_service_questions_and_answers/internal/repositories"
+	"fmt"
+	"log"
+)
+
+// runRenderMarkdown handles "server render-markdown": it renders the text
+// of every question and answer again, for rows stored by an older renderer,
+// and exits the process on failure. Rows stored before text_html existed
+// are rendered by the migrations.
+func runRenderMarkdown() {
+	cfg := config.LoadConfig()
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Fatal("render-markdown needs a database: the memory storage starts empty")
+	}
+
+	db, err := database.NewDatabase(cfg.DB)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	questions, err := repositories.NewQuestionRepository(db.DB).RenderText(markdown.Render)
+	if err != nil {
+		log.Fatal("Failed to render questions:", err)
+	}
+	answers, err := repositories.NewAnswerRepository(db.DB).RenderText(markdown.Render)
+	if err != nil {
+		log.Fatal("Failed to render answers:", err)
+	}
+	fmt.Printf("Rendered %d questions and %d answers\n", questions, answers)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/repositories"
+	"fmt"
+	"log"
+)
+
+// runRepairStats handles "server repair-stats": it recomputes the answer
+// count and last activity of every question from the answers and exits the
+// process on failure.
+func runRepairStats() {
+	cfg := config.LoadConfig()
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Fatal("repair-stats needs a database: the memory storage starts empty")
+	}
+
+	db, err := database.NewDatabase(cfg.DB)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	repaired, err := repositories.NewQuestionRepository(db.DB).RepairAnswerStats()
+	if err != nil {
+		log.Fatal("Failed to repair answer stats:", err)
+	}
+	fmt.Printf("Repaired answer stats of %d questions\n", repaired)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"fmt"
+	"log"
+)
+
+// runRebuildReputation handles "server rebuild-reputation": it awards the
+// answer_posted points for answers that have no ledger entry yet, then
+// replays the ledger into the reputation of every user and exits the
+// process on failure.
+func runRebuildReputation() {
+	cfg := config.LoadConfig()
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Fatal("rebuild-reputation needs a database: the memory storage starts empty")
+	}
+
+	db, err := database.NewDatabase(cfg.DB)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	reputation := repositories.NewReputationRepository(db.DB)
+
+	if points := cfg.Reputation.Rules()[models.ReputationAnswerPosted]; points != 0 {
+		awarded, err := reputation.AwardMissingAnswers(points)
+		if err != nil {
+			log.Fatal("Failed to award missing answers:", err)
+		}
+		fmt.Printf("Awarded %d answers without a ledger entry\n", awarded)
+	}
+
+	rebuilt, err := reputation.Rebuild()
+	if err != nil {
+		log.Fatal("Failed to rebuild reputation:", err)
+	}
+	fmt.Printf("Rebuilt the reputation of %d users\n", rebuilt)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"api_service_questions_and_answers/internal/blob"
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"fmt"
+	"log"
+)
+
+type storage struct {
+	questions     repositories.QuestionRepository
+	answers       repositories.AnswerRepository
+	webhooks      repositories.WebhookRepository
+	outbox        repositories.OutboxRepository
+	follows       repositories.FollowRepository
+	notifications repositories.NotificationRepository
+	users         repositories.UserRepository
+	reputation    repositories.ReputationRepository
+	moderation    repositories.ModerationRepository
+	attachments   repositories.AttachmentRepository
+	// transactor is a constructor because the commit hook, the outbox
+	// relay, is built after the repositories.
+	transactor func(onCommit func()) repositories.Transactor
+}
+
+// newStorage builds the repositories for the configured storage driver.
+func newStorage(cfg *config.Config) storage {
+	if cfg.Storage.Driver == config.StorageMemory {
+		log.Println("Using in-memory storage, data will be lost on restart")
+		store := memory.NewStore()
+		return storage{
+			questions:     memory.NewQuestionRepository(store),
+			answers:       memory.NewAnswerRepository(store),
+			webhooks:      memory.NewWebhookRepository(store),
+			outbox:        memory.NewOutboxRepository(store),
+			follows:       memory.NewFollowRepository(store),
+			notifications: memory.NewNotificationRepository(store),
+			users:         memory.NewUserRepository(store),
+			reputation:    memory.NewReputationRepository(store),
+			moderation:    memory.NewModerationRepository(store),
+			attachments:   memory.NewAttachmentRepository(store),
+			transactor: func(onCommit func()) repositories.Transactor {
+				return memory.NewTransactor(store, onCommit)
+			},
+		}
+	}
+
+	db, err := database.NewDatabase(cfg.DB)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	return storage{
+		questions:     repositories.NewQuestionRepository(db.DB),
+		answers:       repositories.NewAnswerRepository(db.DB),
+		webhooks:      repositories.NewWebhookRepository(db.DB),
+		outbox:        repositories.NewOutboxRepository(db.DB),
+		follows:       repositories.NewFollowRepository(db.DB),
+		notifications: repositories.NewNotificationRepository(db.DB),
+		users:         repositories.NewUserRepository(db.DB),
+		reputation:    repositories.NewReputationRepository(db.DB),
+		moderation:    repositories.NewModerationRepository(db.DB),
+		attachments:   repositories.NewAttachmentRepository(db.DB),
+		transactor: func(onCommit func()) repositories.Transactor {
+			return repositories.NewTransactor(db.DB, onCommit)
+		},
+	}
+}
+
+// newBlobStore builds the configured store for attachment files.
+func newBlobStore(cfg config.AttachmentsConfig) (blob.Store, error) {
+	switch cfg.Store {
+	case config.AttachmentStoreFilesystem:
+		return blob.NewFileStore(cfg.Dir)
+	}
+	return nil, fmt.Errorf("unknown attachment store %q", cfg.Store)
+}
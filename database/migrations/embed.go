@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+// files holds the SQL migrations compiled into the binary, so the server does
+// not depend on the working directory it is started from. Every dialect has
+// its own directory; a migration uses the same version in all of them.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Dir is where new migrations are created, relative to the repository root.
+const Dir = "database/migrations"
+
+// Dialects lists the migration directories, one per supported database.
+var Dialects = []string{"postgres", "sqlite"}
+
+// FS returns the migrations for a dialect.
+func FS(dialect string) (fs.FS, error) {
+	for _, d := range Dialects {
+		if d == dialect {
+			return fs.Sub(files, dialect)
+		}
+	}
+	return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+}
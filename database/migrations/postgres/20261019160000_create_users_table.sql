@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    email TEXT UNIQUE,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- Questions and answers were written before users were registered: every
+-- author gets a placeholder user without an email.
+-- +goose StatementBegin
+INSERT INTO users (id, display_name, created_at)
+SELECT user_id, 'user-' || left(user_id::text, 8), MIN(created_at)
+FROM (
+    SELECT user_id, created_at FROM answers
+    UNION ALL
+    SELECT user_id, created_at FROM questions WHERE user_id IS NOT NULL
+) authors
+GROUP BY user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers ADD CONSTRAINT fk_answers_user FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions ADD CONSTRAINT fk_questions_user FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS fk_questions_user;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers DROP CONSTRAINT IF EXISTS fk_answers_user;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE users;
+-- +goose StatementEnd
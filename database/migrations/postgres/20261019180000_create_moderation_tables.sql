@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS moderation_cases (
+    id SERIAL PRIMARY KEY,
+    content_type TEXT NOT NULL CHECK (content_type IN ('question', 'answer')),
+    content_id INTEGER NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('open', 'approved', 'removed', 'dismissed')),
+    flag_count INTEGER NOT NULL DEFAULT 0,
+    moderator_id UUID REFERENCES users(id),
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_cases_open ON moderation_cases (content_type, content_id) WHERE status = 'open';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_content ON moderation_cases (content_type, content_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS flags (
+    id SERIAL PRIMARY KEY,
+    case_id INTEGER NOT NULL REFERENCES moderation_cases(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL CHECK (reason IN ('spam', 'abusive', 'off_topic', 'other')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (case_id, user_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE flags;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE moderation_cases;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers DROP COLUMN hidden;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN hidden;
+-- +goose StatementEnd
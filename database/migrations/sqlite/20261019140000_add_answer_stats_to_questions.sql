@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN answer_count INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions ADD COLUMN last_activity_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE questions SET
+    answer_count = (SELECT COUNT(*) FROM answers WHERE answers.question_id = questions.id),
+    last_activity_at = COALESCE((SELECT MAX(answers.created_at) FROM answers WHERE answers.question_id = questions.id), questions.created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_answer_count ON questions (answer_count);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_last_activity_at ON questions (last_activity_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_questions_last_activity_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_questions_answer_count;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN last_activity_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions DROP COLUMN answer_count;
+-- +goose StatementEnd
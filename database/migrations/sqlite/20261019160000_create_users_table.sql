@@ -0,0 +1,238 @@
+-- SQLite cannot add a foreign key to an existing table, so answers and
+-- questions are rebuilt. Foreign keys are switched off meanwhile because dropping the old
+-- table would otherwise cascade to notifications, and PRAGMA foreign_keys
+-- has no effect inside a transaction, hence NO TRANSACTION and the explicit
+-- BEGIN/COMMIT.
+-- +goose NO TRANSACTION
+
+-- +goose Up
+-- +goose StatementBegin
+PRAGMA foreign_keys = OFF;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+BEGIN;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    email TEXT UNIQUE,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended')),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- Questions and answers were written before users were registered: every
+-- author gets a placeholder user without an email.
+-- +goose StatementBegin
+INSERT INTO users (id, display_name, created_at)
+SELECT user_id, 'user-' || substr(user_id, 1, 8), MIN(created_at)
+FROM (
+    SELECT user_id, created_at FROM answers
+    UNION ALL
+    SELECT user_id, created_at FROM questions WHERE user_id IS NOT NULL
+)
+GROUP BY user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE answers_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question_id INTEGER NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id),
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO answers_new (id, question_id, user_id, text, created_at)
+SELECT id, question_id, user_id, text, created_at FROM answers;
+-- +goose StatementEnd
+
+-- Keep ids of deleted answers from being handed out again.
+-- +goose StatementBegin
+DELETE FROM sqlite_sequence WHERE name = 'answers_new';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO sqlite_sequence (name, seq)
+SELECT 'answers_new', seq FROM sqlite_sequence WHERE name = 'answers';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE answers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers_new RENAME TO answers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_answers_user_id ON answers (user_id, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE questions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT REFERENCES users(id),
+    answer_count INTEGER NOT NULL DEFAULT 0,
+    last_activity_at DATETIME
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO questions_new (id, text, created_at, user_id, answer_count, last_activity_at)
+SELECT id, text, created_at, user_id, answer_count, last_activity_at FROM questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM sqlite_sequence WHERE name = 'questions_new';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO sqlite_sequence (name, seq)
+SELECT 'questions_new', seq FROM sqlite_sequence WHERE name = 'questions';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions_new RENAME TO questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_user_id ON questions (user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_created_at ON questions (created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_answer_count ON questions (answer_count);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_last_activity_at ON questions (last_activity_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+COMMIT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+PRAGMA foreign_keys = OFF;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+BEGIN;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE answers_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    question_id INTEGER NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO answers_old (id, question_id, user_id, text, created_at)
+SELECT id, question_id, user_id, text, created_at FROM answers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM sqlite_sequence WHERE name = 'answers_old';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO sqlite_sequence (name, seq)
+SELECT 'answers_old', seq FROM sqlite_sequence WHERE name = 'answers';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE answers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE answers_old RENAME TO answers;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_answers_user_id ON answers (user_id, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE questions_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT,
+    answer_count INTEGER NOT NULL DEFAULT 0,
+    last_activity_at DATETIME
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO questions_old (id, text, created_at, user_id, answer_count, last_activity_at)
+SELECT id, text, created_at, user_id, answer_count, last_activity_at FROM questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM sqlite_sequence WHERE name = 'questions_old';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO sqlite_sequence (name, seq)
+SELECT 'questions_old', seq FROM sqlite_sequence WHERE name = 'questions';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE questions_old RENAME TO questions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_user_id ON questions (user_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_created_at ON questions (created_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_answer_count ON questions (answer_count);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_questions_last_activity_at ON questions (last_activity_at);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE users;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+COMMIT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+PRAGMA foreign_keys = ON;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE questions SET
+    answer_count = (SELECT COUNT(*) FROM answers WHERE answers.question_id = questions.id AND NOT answers.hidden),
+    last_activity_at = COALESCE((SELECT MAX(answers.created_at) FROM answers WHERE answers.question_id = questions.id AND NOT answers.hidden), questions.created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE questions SET
+    answer_count = (SELECT COUNT(*) FROM answers WHERE answers.question_id = questions.id),
+    last_activity_at = COALESCE((SELECT MAX(answers.created_at) FROM answers WHERE answers.question_id = questions.id), questions.created_at);
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+// Package blob stores the files uploaded as attachments.
+package blob
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when opening a key that holds no file.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps files under keys chosen by the caller.
+type Store interface {
+	// Put writes r under key and returns the number of bytes written. A
+	// failed Put leaves nothing under key.
+	Put(key string, r io.Reader) (int64, error)
+	// Open returns the file under key or ErrNotFound. The caller must close
+	// it.
+	Open(key string) (io.ReadSeekCloser, error)
+	// Delete removes the file under key. Deleting a missing file succeeds.
+	Delete(key string) error
+}
@@ -0,0 +1,86 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore keeps files in dir, one file per key, creating dir if it
+// does not exist.
+func NewFileStore(dir string) (Store, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &fileStore{
+		dir,
+	}, nil
+}
+
+func (f fileStore) Put(key string, r io.Reader) (int64, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	// Writing to a temporary file and renaming it keeps readers from ever
+	// seeing a partial file under key.
+	file, err := os.CreateTemp(f.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return 0, err
+	}
+	return size, nil
+}
+
+func (f fileStore) Open(key string) (io.ReadSeekCloser, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (f fileStore) Delete(key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path returns the file of key, refusing keys that would leave the
+// directory or collide with temporary files.
+func (f fileStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(f.dir, key), nil
+}
@@ -0,0 +1,68 @@
+package blob
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	size, err := store.Put("key", strings.NewReader("content"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), size)
+
+	file, err := store.Open("key")
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, "content", string(content))
+
+	require.NoError(t, store.Delete("key"))
+	require.NoError(t, store.Delete("key"))
+	_, err = store.Open("key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_FailedPutLeavesNothing(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	_, err = store.Put("key", io.MultiReader(strings.NewReader("partial"), failingReader{}))
+	require.Error(t, err)
+
+	_, err = store.Open("key")
+	assert.ErrorIs(t, err, ErrNotFound)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFileStore_InvalidKeys(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "a/b", `a\b`, ".upload-1"} {
+		_, err := store.Put(key, strings.NewReader("content"))
+		assert.Error(t, err, key)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
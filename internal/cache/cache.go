@@ -0,0 +1,19 @@
+// Package cache provides the cache used in front of the repositories.
+package cache
+
+// Cache stores encoded values by key. Implementations must be safe for
+// concurrent use; the in-process LRU can be swapped for a shared cache.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+	Delete(keys ...string)
+	Stats() Stats
+}
+
+// Stats counts cache activity since the cache was created.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+
+	_, ok := c.Get("question:1")
+	assert.False(t, ok)
+
+	c.Set("question:1", []byte("value"))
+	value, ok := c.Get("question:1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU(2, time.Minute)
+
+	c.Set("a", []byte("a"))
+	c.Set("b", []byte("b"))
+	c.Get("a")
+	c.Set("c", []byte("c"))
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+}
+
+func TestLRU_ExpiresAfterTTL(t *testing.T) {
+	c := NewLRU(10, time.Minute).(*lru)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("a"))
+	now = now.Add(2 * time.Minute)
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Stats().Size)
+}
+
+func TestLRU_Delete(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+
+	c.Set("a", []byte("a"))
+	c.Set("b", []byte("b"))
+	c.Delete("a", "b", "missing")
+
+	assert.Equal(t, 0, c.Stats().Size)
+}
@@ -1,49 +1,674 @@
 package config
 
 import (
+	"api_service_questions_and_answers/internal/i18n"
+	"errors"
+	"fmt"
 	"log"
+	"maps"
+	"mime"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"gopkg.in/yaml.v3"
 )
 
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+var environments = []string{EnvDevelopment, EnvTest, EnvProduction}
+
+const (
+	StorageMemory   = "memory"
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+)
+
+var storageDrivers = []string{StorageMemory, StoragePostgres, StorageSQLite}
+
 type Config struct {
-	ENV    string         `yaml:"env" env-default:"development"`
-	DB     DatabaseConfig `yaml:"database"`
-	Server HttpServer     `yaml:"http_server"`
+	ENV            string               `yaml:"env" env:"ENV" env-default:"development"`
+	Storage        StorageConfig        `yaml:"storage"`
+	DB             DatabaseConfig       `yaml:"database"`
+	Server         HttpServer           `yaml:"http_server"`
+	Swagger        SwaggerConfig        `yaml:"swagger"`
+	CORS           CORSConfig           `yaml:"cors"`
+	Cache          CacheConfig          `yaml:"cache"`
+	Events         EventsConfig         `yaml:"events"`
+	WebSocket      WebSocketConfig      `yaml:"websocket"`
+	Webhooks       WebhooksConfig       `yaml:"webhooks"`
+	Outbox         OutboxConfig         `yaml:"outbox"`
+	Admin          AdminConfig          `yaml:"admin"`
+	GRPC           GRPCConfig           `yaml:"grpc"`
+	API            APIConfig            `yaml:"api"`
+	Reputation     ReputationConfig     `yaml:"reputation"`
+	Moderation     ModerationConfig     `yaml:"moderation"`
+	ContentFilters ContentFiltersConfig `yaml:"content_filters"`
+	Attachments    AttachmentsConfig    `yaml:"attachments"`
+	I18n           I18nConfig           `yaml:"i18n"`
 }
 
 type HttpServer struct {
-	Address string `yaml:"address" default:"localhost"`
-	Port    string `yaml:"port" default:"8080"`
+	Address string `yaml:"address" env:"HTTP_SERVER_ADDRESS" env-default:"localhost"`
+	Port    string `yaml:"port" env:"HTTP_SERVER_PORT" env-default:"8080"`
+}
+
+type StorageConfig struct {
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER" env-default:"postgres"`
 }
 
 type DatabaseConfig struct {
-	Host     string `yaml:"host" default:"localhost"`
-	Port     string `yaml:"port" default:"5432"`
-	DBName   string `yaml:"dbname" default:"postgres"`
-	User     string `yaml:"user" default:"root"`
-	Password string `yaml:"password" default:""`
-	SSLMode  string `yaml:"sslmode" env-default:"disable"`
+	// Driver is copied from storage.driver when the config is loaded.
+	Driver      string `yaml:"-"`
+	Path        string `yaml:"path" env:"DB_PATH" env-default:"qna.db"`
+	Host        string `yaml:"host" env:"DB_HOST" env-default:"localhost"`
+	Port        string `yaml:"port" env:"DB_PORT" env-default:"5432"`
+	DBName      string `yaml:"dbname" env:"DB_NAME" env-default:"postgres"`
+	User        string `yaml:"user" env:"DB_USER" env-default:"root"`
+	Password    string `yaml:"password" env:"DB_PASSWORD" env-default:"" secret:"true"`
+	SSLMode     string `yaml:"sslmode" env:"DB_SSLMODE" env-default:"disable"`
+	LogSQL      bool   `yaml:"log_sql" env:"DB_LOG_SQL"`
+	AutoMigrate bool   `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+}
+
+type SwaggerConfig struct {
+	Enabled bool `yaml:"enabled" env:"SWAGGER_ENABLED"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" env-separator:","`
+}
+
+type CacheConfig struct {
+	Enabled    bool          `yaml:"enabled" env:"CACHE_ENABLED"`
+	TTL        time.Duration `yaml:"ttl" env:"CACHE_TTL" env-default:"30s"`
+	MaxEntries int           `yaml:"max_entries" env:"CACHE_MAX_ENTRIES" env-default:"10000"`
+}
+
+type EventsConfig struct {
+	ReplayBuffer int           `yaml:"replay_buffer" env:"EVENTS_REPLAY_BUFFER" env-default:"1000"`
+	Heartbeat    time.Duration `yaml:"heartbeat" env:"EVENTS_HEARTBEAT" env-default:"15s"`
+}
+
+type WebSocketConfig struct {
+	MaxConnections int           `yaml:"max_connections" env:"WS_MAX_CONNECTIONS" env-default:"1000"`
+	SendBuffer     int           `yaml:"send_buffer" env:"WS_SEND_BUFFER" env-default:"64"`
+	PingInterval   time.Duration `yaml:"ping_interval" env:"WS_PING_INTERVAL" env-default:"30s"`
+}
+
+type WebhooksConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"WEBHOOKS_POLL_INTERVAL" env-default:"5s"`
+	Timeout      time.Duration `yaml:"timeout" env:"WEBHOOKS_TIMEOUT" env-default:"10s"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" env-default:"8"`
+	Backoff      time.Duration `yaml:"backoff" env:"WEBHOOKS_BACKOFF" env-default:"10s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env:"WEBHOOKS_MAX_BACKOFF" env-default:"1h"`
+}
+
+const (
+	OutboxSinkLog  = "log"
+	OutboxSinkBus  = "bus"
+	OutboxSinkHTTP = "http"
+)
+
+var outboxSinks = []string{OutboxSinkLog, OutboxSinkBus, OutboxSinkHTTP}
+
+// OutboxConfig controls the relay that dispatches events written to the
+// outbox. The bus sink feeds SSE, WebSocket and webhooks; without it they
+// do not see created questions and answers.
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"poll_interval" env:"OUTBOX_POLL_INTERVAL" env-default:"1s"`
+	BatchSize    int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	Sinks        []string      `yaml:"sinks" env:"OUTBOX_SINKS" env-separator:"," env-default:"bus"`
+	HTTPURL      string        `yaml:"http_url" env:"OUTBOX_HTTP_URL"`
+	HTTPTimeout  time.Duration `yaml:"http_timeout" env:"OUTBOX_HTTP_TIMEOUT" env-default:"10s"`
+}
+
+// AdminConfig protects the admin API. An empty token disables it.
+type AdminConfig struct {
+	Token string `yaml:"token" env:"ADMIN_TOKEN" secret:"true"`
+}
+
+// GRPCConfig controls the gRPC API served next to the HTTP server. Every
+// call must carry "authorization: Bearer <token>".
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled" env:"GRPC_ENABLED"`
+	Port    string `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+	Token   string `yaml:"token" env:"GRPC_TOKEN" secret:"true"`
+}
+
+// ReputationConfig is the rule table of the reputation system: the points
+// awarded for each kind of activity. Zero turns a rule off.
+type ReputationConfig struct {
+	AnswerPosted int `yaml:"answer_posted" env:"REPUTATION_ANSWER_POSTED" env-default:"2"`
+}
+
+// Rules returns the points keyed by reputation reason.
+func (r ReputationConfig) Rules() map[string]int {
+	return map[string]int{
+		"answer_posted": r.AnswerPosted,
+	}
+}
+
+// ModerationConfig controls flagging. Content is hidden from public reads
+// once its open flags reach FlagThreshold, until a moderator decides.
+type ModerationConfig struct {
+	FlagThreshold int `yaml:"flag_threshold" env:"MODERATION_FLAG_THRESHOLD" env-default:"3"`
+}
+
+const (
+	FilterBannedWords   = "banned_words"
+	FilterLinks         = "links"
+	FilterCaps          = "caps"
+	FilterRepeatedChars = "repeated_chars"
+
+	FilterActionReject = "reject"
+	FilterActionFlag   = "flag"
+)
+
+var (
+	contentFilters = []string{FilterBannedWords, FilterLinks, FilterCaps, FilterRepeatedChars}
+	filterActions  = []string{FilterActionReject, FilterActionFlag}
+)
+
+// ContentFiltersConfig controls the filters that new questions and answers
+// go through before they are saved. Questions and Answers list the filters
+// to run in order. Filters reject matching content unless followed by
+// ":flag", which saves it hidden and queues it for moderation instead.
+type ContentFiltersConfig struct {
+	// BannedWordsFile holds one word per line; "#" starts a comment and a
+	// trailing "*" matches any word starting with the rest. Without a file
+	// banned_words uses the list built into the binary.
+	BannedWordsFile string `yaml:"banned_words_file" env:"CONTENT_FILTERS_BANNED_WORDS_FILE"`
+	// MaxLinks is the number of links a text may contain.
+	MaxLinks int `yaml:"max_links" env:"CONTENT_FILTERS_MAX_LINKS" env-default:"3"`
+	// CapsRatio is the share of capital letters from which a text counts as
+	// shouting. Texts with fewer than 10 letters are never shouting.
+	CapsRatio float64 `yaml:"caps_ratio" env:"CONTENT_FILTERS_CAPS_RATIO" env-default:"0.7"`
+	// MaxRepeatedChars is the longest run of one character a text may
+	// contain.
+	MaxRepeatedChars int      `yaml:"max_repeated_chars" env:"CONTENT_FILTERS_MAX_REPEATED_CHARS" env-default:"5"`
+	Questions        []string `yaml:"questions" env:"CONTENT_FILTERS_QUESTIONS" env-separator:"," env-default:"banned_words,links:flag,caps,repeated_chars"`
+	Answers          []string `yaml:"answers" env:"CONTENT_FILTERS_ANSWERS" env-separator:"," env-default:"banned_words,links:flag,caps,repeated_chars"`
+}
+
+// ParseFilter splits a Questions or Answers entry into the filter name and
+// the action, which is empty when the entry does not override it.
+func ParseFilter(entry string) (name, action string) {
+	name, action, _ = strings.Cut(strings.TrimSpace(entry), ":")
+	return name, action
+}
+
+func (f ContentFiltersConfig) validate() []error {
+	var errs []error
+	for _, chain := range []struct {
+		key     string
+		entries []string
+	}{{"questions", f.Questions}, {"answers", f.Answers}} {
+		for _, entry := range chain.entries {
+			name, action := ParseFilter(entry)
+			if !contains(contentFilters, name) {
+				errs = append(errs, fmt.Errorf("content_filters.%s must contain only %s", chain.key, strings.Join(contentFilters, ", ")))
+				break
+			}
+			if action != "" && !contains(filterActions, action) {
+				errs = append(errs, fmt.Errorf("content_filters.%s actions must be %s", chain.key, strings.Join(filterActions, " or ")))
+				break
+			}
+		}
+	}
+	if f.MaxLinks < 0 {
+		errs = append(errs, errors.New("content_filters.max_links cannot be negative"))
+	}
+	if f.CapsRatio <= 0 || f.CapsRatio > 1 {
+		errs = append(errs, errors.New("content_filters.caps_ratio must be above 0 and at most 1"))
+	}
+	if f.MaxRepeatedChars < 1 {
+		errs = append(errs, errors.New("content_filters.max_repeated_chars must be at least 1"))
+	}
+	return errs
+}
+
+const AttachmentStoreFilesystem = "filesystem"
+
+var attachmentStores = []string{AttachmentStoreFilesystem}
+
+// AttachmentsConfig controls the files uploaded to questions and answers.
+// The type of an upload is sniffed from its first bytes and must be one of
+// AllowedTypes.
+type AttachmentsConfig struct {
+	Store string `yaml:"store" env:"ATTACHMENTS_STORE" env-default:"filesystem"`
+	// Dir is where the filesystem store keeps the files.
+	Dir string `yaml:"dir" env:"ATTACHMENTS_DIR" env-default:"data/attachments"`
+	// MaxSize is the largest file accepted, in bytes.
+	MaxSize      int64    `yaml:"max_size" env:"ATTACHMENTS_MAX_SIZE" env-default:"10485760"`
+	AllowedTypes []string `yaml:"allowed_types" env:"ATTACHMENTS_ALLOWED_TYPES" env-separator:"," env-default:"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain"`
+}
+
+func (a AttachmentsConfig) validate() []error {
+	var errs []error
+	if !contains(attachmentStores, a.Store) {
+		errs = append(errs, fmt.Errorf("attachments.store must be one of %s", strings.Join(attachmentStores, ", ")))
+	}
+	if a.Store == AttachmentStoreFilesystem && strings.TrimSpace(a.Dir) == "" {
+		errs = append(errs, errors.New("attachments.dir is required for the filesystem store"))
+	}
+	if a.MaxSize <= 0 {
+		errs = append(errs, errors.New("attachments.max_size must be positive"))
+	}
+	if len(a.AllowedTypes) == 0 {
+		errs = append(errs, errors.New("attachments.allowed_types cannot be empty"))
+	}
+	for _, allowed := range a.AllowedTypes {
+		mediaType, params, err := mime.ParseMediaType(allowed)
+		if err != nil || len(params) > 0 || mediaType != allowed {
+			errs = append(errs, errors.New("attachments.allowed_types must contain only lower-case media types like image/png"))
+			break
+		}
+	}
+	return errs
 }
 
+// I18nConfig controls the language of validation messages. Each request
+// gets the locale best matching its Accept-Language header, or
+// DefaultLocale when none matches.
+type I18nConfig struct {
+	DefaultLocale string `yaml:"default_locale" env:"I18N_DEFAULT_LOCALE" env-default:"en"`
+}
+
+// DateLayout is the format of dates in the config.
+const DateLayout = "2006-01-02"
+
+// APIConfig controls API versioning.
+type APIConfig struct {
+	V1 DeprecationConfig `yaml:"v1"`
+}
+
+// DeprecationConfig sets the Deprecation and Sunset headers of a deprecated
+// API version. An empty Deprecated leaves the version undeprecated. Routes
+// overrides the dates per route, keyed by method and pattern as registered
+// in the router, e.g. "GET /questions/{id}".
+type DeprecationConfig struct {
+	Deprecated string                      `yaml:"deprecated" env:"API_V1_DEPRECATED" env-default:"2026-10-19"`
+	Sunset     string                      `yaml:"sunset" env:"API_V1_SUNSET" env-default:"2027-10-19"`
+	Routes     map[string]RouteDeprecation `yaml:"routes"`
+}
+
+type RouteDeprecation struct {
+	Deprecated string `yaml:"deprecated"`
+	Sunset     string `yaml:"sunset"`
+	// Disabled omits the headers for the route.
+	Disabled bool `yaml:"disabled"`
+}
+
+// profileDefaults returns the values each environment starts from before
+// the config files and environment variables are applied. Fields that differ
+// between profiles have no env-default tag so that explicit "false" or empty
+// values from a file are not replaced.
+func profileDefaults(env string) Config {
+	switch env {
+	case EnvProduction:
+		return Config{
+			DB:      DatabaseConfig{LogSQL: false, AutoMigrate: false},
+			Swagger: SwaggerConfig{Enabled: false},
+			CORS:    CORSConfig{AllowedOrigins: []string{}},
+		}
+	case EnvTest:
+		return Config{
+			DB:      DatabaseConfig{LogSQL: false, AutoMigrate: true},
+			Swagger: SwaggerConfig{Enabled: true},
+			CORS:    CORSConfig{AllowedOrigins: []string{"*"}},
+		}
+	default:
+		return Config{
+			DB:      DatabaseConfig{LogSQL: true, AutoMigrate: true},
+			Swagger: SwaggerConfig{Enabled: true},
+			CORS:    CORSConfig{AllowedOrigins: []string{"*"}},
+		}
+	}
+}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// LoadConfig reads the configuration and stops the process if it is unusable.
 func LoadConfig() *Config {
+	config, err := Load()
+	if err != nil {
+		log.Fatalf("cannot load config: %s", err)
+	}
+
+	log.Printf("Config loaded: ENV=%s, Storage=%s, DB Host=%s, Port=%s", config.ENV, config.Storage.Driver, config.DB.Host, config.DB.Port)
+
+	return config
+}
+
+// Load builds the effective configuration. Values are layered in order:
+// profile defaults, the base file pointed to by CONFIG_PATH (if any), the
+// profile file next to it (config.<env>.yaml), environment variables and
+// *_FILE secrets. The result is validated before it is returned.
+func Load() (*Config, error) {
 	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		log.Fatal("CONFIG_PATH environment variable not set")
+	if configPath != "" {
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			return nil, fmt.Errorf("config file does not exist: %s", configPath)
+		}
+	}
+
+	env, err := detectEnv(configPath)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		log.Fatalf("congig file does not exist: %s", configPath)
+	config := profileDefaults(env)
+
+	if configPath != "" {
+		if err := cleanenv.ReadConfig(configPath, &config); err != nil {
+			return nil, fmt.Errorf("cannot read config: %w", err)
+		}
+
+		profilePath := ProfilePath(configPath, env)
+		if _, err := os.Stat(profilePath); err == nil {
+			if err := cleanenv.ReadConfig(profilePath, &config); err != nil {
+				return nil, fmt.Errorf("cannot read profile config: %w", err)
+			}
+		}
+	} else {
+		if err := cleanenv.ReadEnv(&config); err != nil {
+			return nil, fmt.Errorf("cannot read environment: %w", err)
+		}
+	}
+	config.ENV = env
+	config.DB.Driver = config.Storage.Driver
+
+	if err := readSecretFiles(reflect.ValueOf(&config).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Validate checks every field and reports all problems at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if !contains(environments, c.ENV) {
+		errs = append(errs, fmt.Errorf("env must be one of %s", strings.Join(environments, ", ")))
+	}
+
+	if strings.TrimSpace(c.Server.Address) == "" {
+		errs = append(errs, errors.New("http_server.address is required"))
+	}
+	if err := validatePort(c.Server.Port); err != nil {
+		errs = append(errs, fmt.Errorf("http_server.port %w", err))
+	}
+
+	if !contains(storageDrivers, c.Storage.Driver) {
+		errs = append(errs, fmt.Errorf("storage.driver must be one of %s", strings.Join(storageDrivers, ", ")))
+	}
+
+	switch c.Storage.Driver {
+	case StorageSQLite:
+		if strings.TrimSpace(c.DB.Path) == "" {
+			errs = append(errs, errors.New("database.path is required for sqlite"))
+		}
+	case StoragePostgres:
+		if strings.TrimSpace(c.DB.Host) == "" {
+			errs = append(errs, errors.New("database.host is required"))
+		}
+		if err := validatePort(c.DB.Port); err != nil {
+			errs = append(errs, fmt.Errorf("database.port %w", err))
+		}
+		if strings.TrimSpace(c.DB.DBName) == "" {
+			errs = append(errs, errors.New("database.dbname is required"))
+		}
+		if strings.TrimSpace(c.DB.User) == "" {
+			errs = append(errs, errors.New("database.user is required"))
+		}
+		if !contains(sslModes, c.DB.SSLMode) {
+			errs = append(errs, fmt.Errorf("database.sslmode must be one of %s", strings.Join(sslModes, ", ")))
+		}
+	}
+
+	if c.Cache.Enabled {
+		if c.Cache.TTL <= 0 {
+			errs = append(errs, errors.New("cache.ttl must be positive"))
+		}
+		if c.Cache.MaxEntries <= 0 {
+			errs = append(errs, errors.New("cache.max_entries must be positive"))
+		}
+	}
+
+	if c.Events.ReplayBuffer < 0 {
+		errs = append(errs, errors.New("events.replay_buffer cannot be negative"))
+	}
+	if c.Events.Heartbeat <= 0 {
+		errs = append(errs, errors.New("events.heartbeat must be positive"))
+	}
+
+	if c.WebSocket.MaxConnections <= 0 {
+		errs = append(errs, errors.New("websocket.max_connections must be positive"))
+	}
+	if c.WebSocket.SendBuffer <= 0 {
+		errs = append(errs, errors.New("websocket.send_buffer must be positive"))
+	}
+	if c.WebSocket.PingInterval <= 0 {
+		errs = append(errs, errors.New("websocket.ping_interval must be positive"))
+	}
+
+	if c.Webhooks.PollInterval <= 0 || c.Webhooks.Timeout <= 0 || c.Webhooks.Backoff <= 0 || c.Webhooks.MaxBackoff <= 0 {
+		errs = append(errs, errors.New("webhooks durations must be positive"))
+	}
+	if c.Webhooks.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("webhooks.max_attempts must be positive"))
+	}
+
+	if c.Outbox.PollInterval <= 0 || c.Outbox.HTTPTimeout <= 0 {
+		errs = append(errs, errors.New("outbox durations must be positive"))
+	}
+	if c.Outbox.BatchSize <= 0 {
+		errs = append(errs, errors.New("outbox.batch_size must be positive"))
+	}
+	if c.Outbox.MaxAttempts <= 0 {
+		errs = append(errs, errors.New("outbox.max_attempts must be positive"))
+	}
+	for _, sink := range c.Outbox.Sinks {
+		if !contains(outboxSinks, sink) {
+			errs = append(errs, fmt.Errorf("outbox.sinks must contain only %s", strings.Join(outboxSinks, ", ")))
+			break
+		}
+	}
+	if contains(c.Outbox.Sinks, OutboxSinkHTTP) && c.Outbox.HTTPURL == "" {
+		errs = append(errs, errors.New("outbox.http_url is required for the http sink"))
+	}
+
+	if c.GRPC.Enabled {
+		if err := validatePort(c.GRPC.Port); err != nil {
+			errs = append(errs, fmt.Errorf("grpc.port %w", err))
+		} else if c.GRPC.Port == c.Server.Port {
+			errs = append(errs, errors.New("grpc.port must differ from http_server.port"))
+		}
+		if c.GRPC.Token == "" {
+			errs = append(errs, errors.New("grpc.token is required when grpc is enabled"))
+		}
+	}
+
+	errs = append(errs, c.API.V1.validate("api.v1")...)
+
+	rules := c.Reputation.Rules()
+	for _, reason := range slices.Sorted(maps.Keys(rules)) {
+		if rules[reason] < 0 {
+			errs = append(errs, fmt.Errorf("reputation.%s cannot be negative", reason))
+		}
 	}
 
-	var config Config
+	if c.Moderation.FlagThreshold < 1 {
+		errs = append(errs, errors.New("moderation.flag_threshold must be at least 1"))
+	}
+
+	errs = append(errs, c.ContentFilters.validate()...)
+	errs = append(errs, c.Attachments.validate()...)
+
+	if !contains(i18n.LocaleNames, c.I18n.DefaultLocale) {
+		errs = append(errs, fmt.Errorf("i18n.default_locale must be one of %s", strings.Join(i18n.LocaleNames, ", ")))
+	}
 
-	if err := cleanenv.ReadConfig(configPath, &config); err != nil {
-		log.Fatalf("cannot read config: %s", err)
+	if c.ENV == EnvProduction && contains(c.CORS.AllowedOrigins, "*") {
+		errs = append(errs, errors.New("cors.allowed_origins cannot contain \"*\" in production"))
 	}
 
-	log.Printf("Config loaded: DB Host=%s, Port=%s", config.DB.Host, config.DB.Port)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
 
-	return &config
+func (d DeprecationConfig) validate(prefix string) []error {
+	errs := validateDates(prefix, d.Deprecated, d.Sunset)
+	for key, route := range d.Routes {
+		method, path, ok := strings.Cut(key, " ")
+		if !ok || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			errs = append(errs, fmt.Errorf("%s.routes key %q must look like \"GET /questions\"", prefix, key))
+		}
+		errs = append(errs, validateDates(fmt.Sprintf("%s.routes[%s]", prefix, key), route.Deprecated, route.Sunset)...)
+	}
+	return errs
+}
+
+// validateDates checks that both dates parse and the sunset does not come
+// before the deprecation.
+func validateDates(prefix, deprecated, sunset string) []error {
+	var errs []error
+
+	var deprecatedAt, sunsetAt time.Time
+	var err error
+	if deprecated != "" {
+		if deprecatedAt, err = time.Parse(DateLayout, deprecated); err != nil {
+			errs = append(errs, fmt.Errorf("%s.deprecated must be a date like %s", prefix, DateLayout))
+		}
+	}
+	if sunset != "" {
+		if sunsetAt, err = time.Parse(DateLayout, sunset); err != nil {
+			errs = append(errs, fmt.Errorf("%s.sunset must be a date like %s", prefix, DateLayout))
+		}
+	}
+	if !deprecatedAt.IsZero() && !sunsetAt.IsZero() && sunsetAt.Before(deprecatedAt) {
+		errs = append(errs, fmt.Errorf("%s.sunset cannot be before the deprecation", prefix))
+	}
+	return errs
+}
+
+// ProfilePath returns the profile file that layers over the base config,
+// e.g. config/config.yaml -> config/config.production.yaml.
+func ProfilePath(basePath, env string) string {
+	ext := filepath.Ext(basePath)
+	return strings.TrimSuffix(basePath, ext) + "." + env + ext
+}
+
+// detectEnv picks the profile from the ENV variable or the base file.
+func detectEnv(configPath string) (string, error) {
+	if env := os.Getenv("ENV"); env != "" {
+		return env, nil
+	}
+
+	var base struct {
+		ENV string `yaml:"env" env-default:"development"`
+	}
+	if configPath != "" {
+		if err := cleanenv.ReadConfig(configPath, &base); err != nil {
+			return "", fmt.Errorf("cannot read config: %w", err)
+		}
+	} else if err := cleanenv.ReadEnv(&base); err != nil {
+		return "", fmt.Errorf("cannot read environment: %w", err)
+	}
+	if base.ENV == "" {
+		return EnvDevelopment, nil
+	}
+	return base.ENV, nil
+}
+
+// Masked returns a copy of the config with fields tagged secret:"true" hidden.
+func (c Config) Masked() Config {
+	maskSecrets(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+// YAML renders the config in the same layout as config.yaml.
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+func maskSecrets(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			maskSecrets(field)
+			continue
+		}
+		if t.Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString("******")
+		}
+	}
+}
+
+func validatePort(port string) error {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("must be a number between 1 and 65535, got %q", port)
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// readSecretFiles walks the config and, for every string field with an env
+// tag NAME, replaces its value with the contents of the file named by NAME_FILE.
+func readSecretFiles(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := readSecretFiles(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := t.Field(i).Tag.Get("env")
+		if name == "" || field.Kind() != reflect.String {
+			continue
+		}
+
+		path := os.Getenv(name + "_FILE")
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read %s_FILE: %w", name, err)
+		}
+		field.SetString(strings.TrimRight(string(content), "\r\n"))
+	}
+	return nil
 }
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_DefaultsWithoutConfigPath(t *testing.T) {
+	t.Setenv("CONFIG_PATH", "")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "development", cfg.ENV)
+	assert.Equal(t, "localhost", cfg.Server.Address)
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, "localhost", cfg.DB.Host)
+	assert.Equal(t, "5432", cfg.DB.Port)
+	assert.Equal(t, "disable", cfg.DB.SSLMode)
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: db\n  port: 5432\n"), 0o600))
+
+	t.Setenv("CONFIG_PATH", path)
+	t.Setenv("DB_HOST", "postgres.internal")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "postgres.internal", cfg.DB.Host)
+}
+
+func TestLoad_PasswordFromFile(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "db_password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cret\n"), 0o600))
+
+	t.Setenv("CONFIG_PATH", "")
+	t.Setenv("DB_PASSWORD", "ignored")
+	t.Setenv("DB_PASSWORD_FILE", secret)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cret", cfg.DB.Password)
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	t.Setenv("CONFIG_PATH", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	cfg := &Config{
+		ENV:     "development",
+		Storage: StorageConfig{Driver: StoragePostgres},
+		Server:  HttpServer{Address: "localhost", Port: "http"},
+		DB:      DatabaseConfig{Host: "", Port: "70000", DBName: "qna", User: "postgres", SSLMode: "sometimes"},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "http_server.port")
+	assert.Contains(t, err.Error(), "database.host")
+	assert.Contains(t, err.Error(), "database.port")
+	assert.Contains(t, err.Error(), "database.sslmode")
+}
+
+func TestLoad_ProductionProfileDefaults(t *testing.T) {
+	t.Setenv("CONFIG_PATH", "")
+	t.Setenv("ENV", EnvProduction)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.False(t, cfg.DB.LogSQL)
+	assert.False(t, cfg.Swagger.Enabled)
+	assert.Empty(t, cfg.CORS.AllowedOrigins)
+}
+
+func TestLoad_ProfileFileLayersOverBase(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("env: production\ndatabase:\n  host: db\n"), 0o600))
+	require.NoError(t, os.WriteFile(ProfilePath(base, EnvProduction), []byte("database:\n  host: db.prod\nswagger:\n  enabled: true\n"), 0o600))
+
+	t.Setenv("CONFIG_PATH", base)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, EnvProduction, cfg.ENV)
+	assert.Equal(t, "db.prod", cfg.DB.Host)
+	assert.True(t, cfg.Swagger.Enabled)
+}
+
+// loadDefaults returns the valid default config of a profile.
+func loadDefaults(t *testing.T, env string) *Config {
+	t.Helper()
+	t.Setenv("CONFIG_PATH", "")
+	t.Setenv("ENV", env)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestValidate_RejectsWildcardCORSInProduction(t *testing.T) {
+	cfg := loadDefaults(t, EnvProduction)
+	cfg.CORS.AllowedOrigins = []string{"*"}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cors.allowed_origins")
+}
+
+func TestMasked_HidesSecrets(t *testing.T) {
+	cfg := Config{DB: DatabaseConfig{Password: "postgres"}}
+
+	masked := cfg.Masked()
+
+	assert.Equal(t, "******", masked.DB.Password)
+	assert.Equal(t, "postgres", cfg.DB.Password)
+}
+
+func TestValidate_SQLiteDoesNotRequirePostgresFields(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	cfg.Storage = StorageConfig{Driver: StorageSQLite}
+	cfg.DB = DatabaseConfig{Path: "qna.db"}
+
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_OutboxHTTPSinkRequiresURL(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	cfg.Outbox.Sinks = []string{OutboxSinkBus, OutboxSinkHTTP}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox.http_url")
+
+	cfg.Outbox.Sinks = []string{"kafka"}
+	err = cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox.sinks")
+}
+
+func TestValidate_GRPCRequiresToken(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	cfg.GRPC = GRPCConfig{Enabled: true, Port: cfg.Server.Port}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "grpc.port must differ")
+	assert.Contains(t, err.Error(), "grpc.token")
+
+	cfg.GRPC = GRPCConfig{Enabled: true, Port: "9090", Token: "secret"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidate_APIDeprecationDates(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	cfg.API.V1 = DeprecationConfig{
+		Deprecated: "2026-10-19",
+		Sunset:     "2026-01-01",
+		Routes: map[string]RouteDeprecation{
+			"questions":         {},
+			"GET /answers/{id}": {Sunset: "soon"},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api.v1.sunset cannot be before")
+	assert.Contains(t, err.Error(), `api.v1.routes key "questions"`)
+	assert.Contains(t, err.Error(), "api.v1.routes[GET /answers/{id}].sunset")
+}
+
+func TestValidate_ReputationPointsNotNegative(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	assert.Equal(t, map[string]int{"answer_posted": 2}, cfg.Reputation.Rules())
+
+	cfg.Reputation.AnswerPosted = -1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "answer_posted")
+}
+
+func TestValidate_ModerationFlagThreshold(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	assert.Equal(t, 3, cfg.Moderation.FlagThreshold)
+
+	cfg.Moderation.FlagThreshold = 0
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "moderation.flag_threshold")
+}
+
+func TestValidate_ContentFilters(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	assert.Equal(t, []string{"banned_words", "links:flag", "caps", "repeated_chars"}, cfg.ContentFilters.Questions)
+	require.NoError(t, cfg.Validate())
+
+	cfg.ContentFilters.Questions = []string{"links:hide"}
+	cfg.ContentFilters.Answers = []string{"profanity"}
+	cfg.ContentFilters.CapsRatio = 1.5
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content_filters.questions actions")
+	assert.Contains(t, err.Error(), "content_filters.answers must contain only")
+	assert.Contains(t, err.Error(), "content_filters.caps_ratio")
+}
+
+func TestValidate_Attachments(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	assert.Equal(t, int64(10<<20), cfg.Attachments.MaxSize)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Attachments.Store = "s3"
+	cfg.Attachments.MaxSize = 0
+	cfg.Attachments.AllowedTypes = []string{"image/png", "text/plain; charset=utf-8"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attachments.store")
+	assert.Contains(t, err.Error(), "attachments.max_size")
+	assert.Contains(t, err.Error(), "attachments.allowed_types")
+}
+
+func TestValidate_I18n(t *testing.T) {
+	cfg := loadDefaults(t, EnvDevelopment)
+	assert.Equal(t, i18n.LocaleEnglish, cfg.I18n.DefaultLocale)
+
+	cfg.I18n.DefaultLocale = i18n.LocaleRussian
+	require.NoError(t, cfg.Validate())
+
+	cfg.I18n.DefaultLocale = "de"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "i18n.default_locale must be one of en, ru")
+}
@@ -2,48 +2,123 @@ package database
 
 import (
 	"api_service_questions_and_answers/internal/config"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"os"
 
-	"github.com/pressly/goose"
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+const (
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+// ErrPostgresOnly is returned by features that have no SQLite equivalent.
+var ErrPostgresOnly = errors.New("feature is only supported on postgres")
+
 type Database struct {
-	DB *gorm.DB
+	DB      *gorm.DB
+	dialect string
 }
 
 func NewDatabase(config config.DatabaseConfig) (*Database, error) {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		config.Host, config.User, config.Password, config.DBName, config.Port, config.SSLMode,
+	logLevel := logger.Warn
+	if config.LogSQL {
+		logLevel = logger.Info
+	}
+
+	// TranslateError turns constraint violations into gorm.ErrDuplicatedKey
+	// and gorm.ErrForeignKeyViolated on every dialect.
+	gormConfig := &gorm.Config{
+		Logger:         logger.Default.LogMode(logLevel),
+		TranslateError: true,
+	}
+
+	var (
+		db      *gorm.DB
+		dialect string
+		err     error
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	switch config.Driver {
+	case DialectSQLite:
+		dialect = DialectSQLite
+		// Foreign keys are off by default in SQLite and answers rely on
+		// ON DELETE CASCADE.
+		dsn := config.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+		db, err = gorm.Open(sqlite.Open(dsn), gormConfig)
+	case DialectPostgres, "":
+		dialect = DialectPostgres
+		dsn := fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+			config.Host, config.User, config.Password, config.DBName, config.Port, config.SSLMode,
+		)
+		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", config.Driver)
+	}
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Connected to database successfully")
+	if dialect == DialectSQLite {
+		// SQLite allows a single writer; one connection avoids "database is locked".
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	log.Printf("Connected to %s database successfully", dialect)
 
-	sqlDB, err := db.DB()
-	if err != nil {
-		log.Fatal("Failed to get sql.DB:", err)
+	database := &Database{DB: db, dialect: dialect}
+
+	if config.AutoMigrate {
+		if err := database.Migrate(context.Background(), "up", os.Stdout); err != nil {
+			return nil, err
+		}
+		log.Println("Database migrated successfully")
 	}
 
-	err = goose.Up(sqlDB, "database/migrations")
+	return database, nil
+}
+
+// Dialect reports which database engine is in use.
+func (d *Database) Dialect() string {
+	return d.dialect
+}
+
+// RequirePostgres returns a descriptive error when feature cannot run on the
+// current dialect.
+func (d *Database) RequirePostgres(feature string) error {
+	if d.dialect != DialectPostgres {
+		return fmt.Errorf("%s: %w (current driver: %s)", feature, ErrPostgresOnly, d.dialect)
+	}
+	return nil
+}
+
+// Migrate runs a migrate subcommand against this database.
+func (d *Database) Migrate(ctx context.Context, command string, out io.Writer) error {
+	sqlDB, err := d.DB.DB()
 	if err != nil {
-		log.Fatal("Failed to run migrations:", err)
+		return fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
-	log.Println("Database migrated successfully")
+	migrator, err := NewMigrator(sqlDB, d.dialect)
+	if err != nil {
+		return err
+	}
 
-	return &Database{DB: db}, nil
+	return migrator.Run(ctx, command, out)
 }
 
 func (d *Database) HealthCheck() error {
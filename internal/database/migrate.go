@@ -0,0 +1,195 @@
+package database
+
+import (
+	"api_service_questions_and_answers/database/migrations"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+var ErrUnknownMigrateCommand = errors.New("unknown migrate command")
+
+// Migrator applies the embedded migrations. On Postgres every run takes an
+// advisory lock, so replicas starting at the same time do not race. SQLite
+// is single-node and runs without a lock.
+type Migrator struct {
+	provider *goose.Provider
+}
+
+func NewMigrator(db *sql.DB, dialect string) (*Migrator, error) {
+	fsys, err := migrations.FS(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		gooseDialect goose.Dialect
+		options      = []goose.ProviderOption{goose.WithGoMigrations(goMigrations()...)}
+	)
+
+	switch dialect {
+	case DialectPostgres:
+		locker, err := lock.NewPostgresSessionLocker()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create migration lock: %w", err)
+		}
+		gooseDialect = goose.DialectPostgres
+		options = append(options, goose.WithSessionLocker(locker))
+	case DialectSQLite:
+		gooseDialect = goose.DialectSQLite3
+	default:
+		return nil, fmt.Errorf("unsupported migration dialect %q", dialect)
+	}
+
+	provider, err := goose.NewProvider(gooseDialect, db, fsys, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// goMigrations run between the SQL migrations, ordered by version like
+// them, on every dialect.
+func goMigrations() []*goose.Migration {
+	return []*goose.Migration{renderTextHTMLMigration()}
+}
+
+// Run executes one of the migrate subcommands: up, down, status, redo, version.
+func (m *Migrator) Run(ctx context.Context, command string, out io.Writer) error {
+	switch command {
+	case "up":
+		return m.Up(ctx, out)
+	case "down":
+		return m.Down(ctx, out)
+	case "redo":
+		return m.Redo(ctx, out)
+	case "status":
+		return m.Status(ctx, out)
+	case "version":
+		return m.Version(ctx, out)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownMigrateCommand, command)
+	}
+}
+
+func (m *Migrator) Up(ctx context.Context, out io.Writer) error {
+	results, err := m.provider.Up(ctx)
+	for _, result := range results {
+		printResult(out, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(out, "no migrations to apply")
+	}
+	return nil
+}
+
+func (m *Migrator) Down(ctx context.Context, out io.Writer) error {
+	result, err := m.provider.Down(ctx)
+	if result != nil {
+		printResult(out, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	return nil
+}
+
+// Redo rolls back the latest migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context, out io.Writer) error {
+	if err := m.Down(ctx, out); err != nil {
+		return err
+	}
+
+	result, err := m.provider.UpByOne(ctx)
+	if result != nil {
+		printResult(out, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reapply migration: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) Status(ctx context.Context, out io.Writer) error {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(out, "%-25s %s\n", appliedAt, sourceName(status.Source))
+	}
+	return nil
+}
+
+func (m *Migrator) Version(ctx context.Context, out io.Writer) error {
+	version, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database version: %w", err)
+	}
+
+	fmt.Fprintf(out, "version %d\n", version)
+	return nil
+}
+
+const migrationTemplate = `-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+-- +goose StatementEnd
+`
+
+// CreateMigration writes an empty SQL migration with the same timestamp
+// version into the directory of every dialect under dir.
+func CreateMigration(dir, name string, out io.Writer) error {
+	if name == "" {
+		return errors.New("migration name is required")
+	}
+
+	filename := time.Now().UTC().Format("20060102150405") + "_" + name + ".sql"
+	for _, dialect := range migrations.Dialects {
+		path := filepath.Join(dir, dialect, filename)
+		if err := os.WriteFile(path, []byte(migrationTemplate), 0o644); err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		fmt.Fprintf(out, "Created new file: %s\n", path)
+	}
+	return nil
+}
+
+func printResult(out io.Writer, result *goose.MigrationResult) {
+	if result.Error != nil {
+		fmt.Fprintf(out, "FAIL %s %s: %v\n", result.Direction, sourceName(result.Source), result.Error)
+		return
+	}
+	fmt.Fprintf(out, "OK   %s %s (%s)\n", result.Direction, sourceName(result.Source), result.Duration.Round(time.Millisecond))
+}
+
+// sourceName is the file of a SQL migration or the version of a Go one,
+// which has no file.
+func sourceName(source *goose.Source) string {
+	if source.Path == "" {
+		return fmt.Sprintf("%d (go)", source.Version)
+	}
+	return source.Path
+}
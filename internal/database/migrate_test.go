@@ -0,0 +1,155 @@
+package database
+
+import (
+	"api_service_questions_and_answers/database/migrations"
+	"api_service_questions_and_answers/internal/config"
+	"bytes"
+	"context"
+	"database/sql"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMigrator returns a migrator over an empty SQLite database.
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	db, err := NewDatabase(config.DatabaseConfig{Driver: DialectSQLite, Path: filepath.Join(t.TempDir(), "qna.db")})
+	require.NoError(t, err)
+	sqlDB, err := db.DB.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	migrator, err := NewMigrator(sqlDB, DialectSQLite)
+	require.NoError(t, err)
+	return migrator, sqlDB
+}
+
+func migrationCount(t *testing.T) int {
+	t.Helper()
+
+	fsys, err := migrations.FS(DialectSQLite)
+	require.NoError(t, err)
+	files, err := fs.Glob(fsys, "*.sql")
+	require.NoError(t, err)
+	return len(files) + len(goMigrations())
+}
+
+func TestMigrator_UpDownRedo(t *testing.T) {
+	migrator, sqlDB := newTestMigrator(t)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	require.NoError(t, migrator.Run(ctx, "up", &out))
+	assert.Equal(t, migrationCount(t), strings.Count(out.String(), "OK   up"))
+	_, err := sqlDB.Exec("SELECT id FROM questions")
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "up", &out))
+	assert.Equal(t, "no migrations to apply\n", out.String())
+
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "version", &out))
+	latest := out.String()
+	assert.Regexp(t, `^version \d{14}\n$`, latest)
+
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "redo", &out))
+	assert.Contains(t, out.String(), "OK   down")
+	assert.Contains(t, out.String(), "OK   up")
+
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "down", &out))
+	assert.Contains(t, out.String(), "OK   down")
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "version", &out))
+	assert.NotEqual(t, latest, out.String())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator, _ := newTestMigrator(t)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	require.NoError(t, migrator.Run(ctx, "status", &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, migrationCount(t))
+	assert.True(t, strings.HasPrefix(lines[0], "Pending"))
+
+	require.NoError(t, migrator.Up(ctx, &bytes.Buffer{}))
+	out.Reset()
+	require.NoError(t, migrator.Run(ctx, "status", &out))
+	assert.NotContains(t, out.String(), "Pending")
+}
+
+func TestMigrator_RendersTextHTMLOfExistingRows(t *testing.T) {
+	migrator, sqlDB := newTestMigrator(t)
+	ctx := context.Background()
+
+	// A database upgraded from before text_html has rows with it empty.
+	_, err := migrator.provider.UpTo(ctx, renderTextHTMLVersion-1)
+	require.NoError(t, err)
+	_, err = sqlDB.Exec("INSERT INTO questions (text, text_html) VALUES ('What is **Go**?', ''), ('Kept', '<p>kept</p>')")
+	require.NoError(t, err)
+	userID := "5f0e8a3c-2b7d-4c61-8e94-a1d3b6c7f802"
+	_, err = sqlDB.Exec("INSERT INTO users (id, display_name) VALUES ($1, 'Tester')", userID)
+	require.NoError(t, err)
+	_, err = sqlDB.Exec("INSERT INTO answers (question_id, user_id, text, text_html) VALUES (1, $1, 'A *language*', '')", userID)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up(ctx, &bytes.Buffer{}))
+
+	var html []string
+	rows, err := sqlDB.Query("SELECT text_html FROM questions UNION ALL SELECT text_html FROM answers")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var h string
+		require.NoError(t, rows.Scan(&h))
+		html = append(html, h)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"<p>What is <strong>Go</strong>?</p>\n", "<p>kept</p>", "<p>A <em>language</em></p>\n"}, html)
+}
+
+func TestMigrator_UnknownCommand(t *testing.T) {
+	migrator, _ := newTestMigrator(t)
+
+	err := migrator.Run(context.Background(), "sideways", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrUnknownMigrateCommand)
+}
+
+func TestNewMigrator_UnsupportedDialect(t *testing.T) {
+	_, err := NewMigrator(nil, "mysql")
+	assert.Error(t, err)
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range migrations.Dialects {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, dialect), 0o755))
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, CreateMigration(dir, "add_tags", &out))
+
+	for _, dialect := range migrations.Dialects {
+		files, err := filepath.Glob(filepath.Join(dir, dialect, "*_add_tags.sql"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Contains(t, out.String(), "Created new file: "+files[0])
+
+		content, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, migrationTemplate, string(content))
+	}
+
+	assert.Error(t, CreateMigration(dir, "", &out))
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/pressly/goose/v3"
+	"github.com/yuin/goldmark"
+)
+
+// renderTextHTMLVersion follows the SQL migrations up to the one that adds
+// text_html. It is a Go migration because the rendering is done in Go.
+const renderTextHTMLVersion = 20261019235900
+
+// renderBatch is the number of rows read at a time.
+const renderBatch = 500
+
+// renderTextHTMLMigration fills text_html for the questions and answers
+// stored before the column existed, which the SQL migration leaves empty.
+// Rolling it back keeps the rendered HTML.
+//
+// It renders with its own copy of the renderer as it was when text_html
+// was added, so that changing internal/markdown does not change what the
+// migration does. Rows rendered by an older renderer are brought up to
+// date by the render-markdown command.
+func renderTextHTMLMigration() *goose.Migration {
+	return goose.NewGoMigration(renderTextHTMLVersion, &goose.GoFunc{
+		RunTx: func(ctx context.Context, tx *sql.Tx) error {
+			for _, table := range []string{"questions", "answers"} {
+				if err := renderEmptyTextHTML(ctx, tx, table); err != nil {
+					return fmt.Errorf("failed to render %s: %w", table, err)
+				}
+			}
+			return nil
+		},
+	}, nil)
+}
+
+func renderEmptyTextHTML(ctx context.Context, tx *sql.Tx, table string) error {
+	for after := 0; ; {
+		rows, err := tx.QueryContext(ctx,
+			"SELECT id, text FROM "+table+" WHERE text_html = '' AND id > $1 ORDER BY id LIMIT $2", after, renderBatch)
+		if err != nil {
+			return err
+		}
+
+		type row struct {
+			id   int
+			text string
+		}
+		var batch []row
+		for rows.Next() {
+			var r row
+			if err := rows.Scan(&r.id, &r.text); err != nil {
+				rows.Close()
+				return err
+			}
+			batch = append(batch, r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil || len(batch) == 0 {
+			return err
+		}
+
+		for _, r := range batch {
+			_, err := tx.ExecContext(ctx, "UPDATE "+table+" SET text_html = $1 WHERE id = $2", renderV1(r.text), r.id)
+			if err != nil {
+				return err
+			}
+		}
+		after = batch[len(batch)-1].id
+	}
+}
+
+var (
+	rendererV1 = goldmark.New()
+	policyV1   = newPolicyV1()
+)
+
+func newPolicyV1() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return policy
+}
+
+// renderV1 is markdown.Render as of the text_html migration.
+func renderV1(text string) string {
+	var out bytes.Buffer
+	err := rendererV1.Convert([]byte(text), &out)
+	if err != nil {
+		return "<p>" + html.EscapeString(text) + "</p>"
+	}
+	return policyV1.Sanitize(out.String())
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+const subscriptionBuffer = 64
+
+// Broadcaster numbers published events, keeps the latest of them in a
+// bounded replay buffer and fans them out to subscribers.
+type Broadcaster struct {
+	mu          sync.Mutex
+	lastID      uint64
+	replay      []Event
+	next        int
+	replaySize  int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewBroadcaster(replaySize int) *Broadcaster {
+	return &Broadcaster{
+		replay:      make([]Event, 0, replaySize),
+		replaySize:  replaySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the events of one question. C is closed when the
+// subscription is closed or falls too far behind; in the latter case the
+// client is expected to reconnect and resume from the last event it saw.
+type Subscription struct {
+	C <-chan Event
+
+	ch          chan Event
+	questionID  uint
+	broadcaster *Broadcaster
+	once        sync.Once
+}
+
+func (b *Broadcaster) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if b.replaySize > 0 {
+		if len(b.replay) < b.replaySize {
+			b.replay = append(b.replay, event)
+		} else {
+			b.replay[b.next] = event
+			b.next = (b.next + 1) % b.replaySize
+		}
+	}
+
+	for sub := range b.subscribers {
+		if sub.questionID != event.QuestionID {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			b.unsubscribe(sub)
+		}
+	}
+}
+
+// Subscribe starts delivering events for questionID. Buffered events with
+// an ID greater than lastEventID are delivered first.
+func (b *Broadcaster) Subscribe(questionID uint, lastEventID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []Event
+	if lastEventID > 0 {
+		for _, event := range b.buffered() {
+			if event.ID > lastEventID && event.QuestionID == questionID {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriptionBuffer+len(missed))
+	for _, event := range missed {
+		ch <- event
+	}
+
+	sub := &Subscription{C: ch, ch: ch, questionID: questionID, broadcaster: b}
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Close stops delivery and releases the subscription. It is safe to call
+// more than once.
+func (s *Subscription) Close() {
+	s.broadcaster.mu.Lock()
+	defer s.broadcaster.mu.Unlock()
+
+	s.broadcaster.unsubscribe(s)
+}
+
+// unsubscribe must be called with b.mu held.
+func (b *Broadcaster) unsubscribe(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	})
+}
+
+// buffered returns the replay buffer oldest first. b.mu must be held.
+func (b *Broadcaster) buffered() []Event {
+	if len(b.replay) < b.replaySize {
+		return b.replay
+	}
+	return append(append([]Event{}, b.replay[b.next:]...), b.replay[:b.next]...)
+}
+
+// Subscribers reports how many subscriptions are open.
+func (b *Broadcaster) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers)
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcaster_DeliversEventsOfSubscribedQuestion(t *testing.T) {
+	b := NewBroadcaster(10)
+	sub := b.Subscribe(1, 0)
+	defer sub.Close()
+
+	b.Publish(Event{Type: AnswerCreated, QuestionID: 2})
+	b.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+
+	event := <-sub.C
+	assert.Equal(t, uint64(2), event.ID)
+	assert.Equal(t, uint(1), event.QuestionID)
+	assert.Empty(t, sub.C)
+}
+
+func TestBroadcaster_ReplaysAfterLastEventID(t *testing.T) {
+	b := NewBroadcaster(10)
+	b.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+	b.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+	b.Publish(Event{Type: AnswerDeleted, QuestionID: 1})
+
+	sub := b.Subscribe(1, 1)
+	defer sub.Close()
+
+	require.Len(t, sub.C, 2)
+	assert.Equal(t, uint64(2), (<-sub.C).ID)
+	assert.Equal(t, uint64(3), (<-sub.C).ID)
+}
+
+func TestBroadcaster_ReplayBufferIsBounded(t *testing.T) {
+	b := NewBroadcaster(2)
+	for i := 0; i < 5; i++ {
+		b.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+	}
+
+	sub := b.Subscribe(1, 1)
+	defer sub.Close()
+
+	require.Len(t, sub.C, 2)
+	assert.Equal(t, uint64(4), (<-sub.C).ID)
+	assert.Equal(t, uint64(5), (<-sub.C).ID)
+}
+
+func TestBroadcaster_DropsSlowSubscriber(t *testing.T) {
+	b := NewBroadcaster(0)
+	sub := b.Subscribe(1, 0)
+
+	for i := 0; i < subscriptionBuffer+1; i++ {
+		b.Publish(Event{Type: AnswerCreated, QuestionID: 1})
+	}
+
+	assert.Equal(t, 0, b.Subscribers())
+	for range sub.C {
+	}
+	sub.Close()
+}
@@ -0,0 +1,52 @@
+// Package events carries domain events from the services to in-process
+// listeners such as the SSE stream.
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	QuestionCreated = "question.created"
+	QuestionDeleted = "question.deleted"
+	AnswerCreated   = "answer.created"
+	AnswerDeleted   = "answer.deleted"
+)
+
+type Event struct {
+	ID         uint64    `json:"id"`
+	Type       string    `json:"type"`
+	QuestionID uint      `json:"question_id"`
+	Data       any       `json:"data"`
+	CreatedAt  time.Time `json:"created_at"`
+	// UserID is the author of the change, used for routing only; the
+	// payload in Data carries it where the API exposes it.
+	UserID uuid.UUID `json:"-"`
+}
+
+// Publisher accepts events from the services. Publish must not block.
+type Publisher interface {
+	Publish(event Event)
+}
+
+type discard struct{}
+
+func (discard) Publish(Event) {}
+
+// Discard is a Publisher that drops every event.
+var Discard Publisher = discard{}
+
+type fanout []Publisher
+
+func (f fanout) Publish(event Event) {
+	for _, publisher := range f {
+		publisher.Publish(event)
+	}
+}
+
+// Fanout returns a Publisher that forwards every event to all publishers.
+func Fanout(publishers ...Publisher) Publisher {
+	return fanout(publishers)
+}
@@ -0,0 +1,117 @@
+// Package filter checks the text of new questions and answers before they
+// are saved.
+package filter
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"fmt"
+)
+
+// Verdict is what a filter decides about a text.
+type Verdict int
+
+const (
+	Allow Verdict = iota
+	// Flag saves the content hidden and queues it for moderation.
+	Flag
+	Reject
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Flag:
+		return config.FilterActionFlag
+	case Reject:
+		return config.FilterActionReject
+	}
+	return "allow"
+}
+
+// Result is the verdict of a filter. Reason says what the filter found and
+// is nil when the text is allowed.
+type Result struct {
+	Verdict Verdict
+	Filter  string
+	Reason  *i18n.Error
+}
+
+// Filter checks a text. Filters return verdict when the text matches and
+// Allow otherwise.
+type Filter interface {
+	Name() string
+	Check(text string) Result
+}
+
+// Chain runs its filters in order. The first rejection wins; without one
+// the first flag does. A nil Chain allows everything.
+type Chain []Filter
+
+func (c Chain) Check(text string) Result {
+	result := Result{Verdict: Allow}
+	for _, filter := range c {
+		found := filter.Check(text)
+		if found.Verdict == Reject {
+			return found
+		}
+		if found.Verdict == Flag && result.Verdict == Allow {
+			result = found
+		}
+	}
+	return result
+}
+
+// Chains holds the chain of each kind of content.
+type Chains struct {
+	Questions Chain
+	Answers   Chain
+}
+
+// New builds the chains described by cfg, loading the banned words file
+// if there is one and the built-in list otherwise. Filters reject matching
+// content unless their entry says ":flag".
+func New(cfg config.ContentFiltersConfig) (Chains, error) {
+	lexicon := DefaultLexicon()
+	if cfg.BannedWordsFile != "" {
+		var err error
+		lexicon, err = LoadLexicon(cfg.BannedWordsFile)
+		if err != nil {
+			return Chains{}, err
+		}
+	}
+
+	build := func(entries []string) (Chain, error) {
+		chain := Chain{}
+		for _, entry := range entries {
+			name, action := config.ParseFilter(entry)
+			verdict := Reject
+			if action == config.FilterActionFlag {
+				verdict = Flag
+			}
+
+			switch name {
+			case config.FilterBannedWords:
+				chain = append(chain, BannedWords(lexicon, verdict))
+			case config.FilterLinks:
+				chain = append(chain, Links(cfg.MaxLinks, verdict))
+			case config.FilterCaps:
+				chain = append(chain, Caps(cfg.CapsRatio, verdict))
+			case config.FilterRepeatedChars:
+				chain = append(chain, RepeatedChars(cfg.MaxRepeatedChars, verdict))
+			default:
+				return nil, fmt.Errorf("unknown content filter %q", name)
+			}
+		}
+		return chain, nil
+	}
+
+	questions, err := build(cfg.Questions)
+	if err != nil {
+		return Chains{}, err
+	}
+	answers, err := build(cfg.Answers)
+	if err != nil {
+		return Chains{}, err
+	}
+	return Chains{Questions: questions, Answers: answers}, nil
+}
@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		text    string
+		verdict Verdict
+	}{
+		{"two links", Links(2, Flag), "See https://go.dev and www.example.com", Allow},
+		{"three links", Links(2, Flag), "http://a.example http://b.example HTTPS://c.example", Flag},
+		{"no links allowed", Links(0, Reject), "Read https://go.dev", Reject},
+		{"shouting", Caps(0.7, Reject), "WHY DOES MY CODE NOT WORK?", Reject},
+		{"shouting in Russian", Caps(0.7, Reject), "ПОЧЕМУ НЕ РАБОТАЕТ КОД?", Reject},
+		{"acronyms", Caps(0.7, Reject), "How do I call a REST API from Go?", Allow},
+		{"short caps", Caps(0.7, Reject), "HELP ME", Allow},
+		{"repeated letters", RepeatedChars(5, Reject), "Pleeeeeease help", Reject},
+		{"repeated marks", RepeatedChars(5, Reject), "Помогите!!!!!!", Reject},
+		{"numbers and rules", RepeatedChars(5, Reject), "It takes 1000000 iterations\n----------\n        indented", Allow},
+		{"at the limit", RepeatedChars(5, Reject), "Whyyyyy?", Allow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.filter.Check(tt.text)
+			assert.Equal(t, tt.verdict, result.Verdict)
+			if tt.verdict != Allow {
+				assert.Equal(t, tt.filter.Name(), result.Filter)
+				assert.NotEmpty(t, result.Reason)
+			}
+		})
+	}
+}
+
+func TestChain_RejectionWinsOverFlag(t *testing.T) {
+	chain := Chain{Links(0, Flag), Caps(0.7, Reject), RepeatedChars(5, Flag)}
+
+	result := chain.Check("Read https://go.dev!!!!!!")
+	assert.Equal(t, Flag, result.Verdict)
+	assert.Equal(t, config.FilterLinks, result.Filter)
+
+	result = chain.Check("READ HTTPS://GO.DEV RIGHT NOW")
+	assert.Equal(t, Reject, result.Verdict)
+	assert.Equal(t, config.FilterCaps, result.Filter)
+
+	assert.Equal(t, Allow, chain.Check("Read the docs").Verdict)
+	assert.Equal(t, Allow, Chain(nil).Check("READ HTTPS://GO.DEV RIGHT NOW").Verdict)
+}
+
+func TestNew_BuildsChainsPerContentType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned.txt")
+	require.NoError(t, os.WriteFile(path, []byte("спам\n"), 0o600))
+
+	chains, err := New(config.ContentFiltersConfig{
+		BannedWordsFile:  path,
+		MaxLinks:         1,
+		CapsRatio:        0.7,
+		MaxRepeatedChars: 5,
+		Questions:        []string{"banned_words:flag", "links"},
+		Answers:          []string{"links:flag"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Flag, chains.Questions.Check("Это спам?").Verdict)
+	assert.Equal(t, Allow, chains.Answers.Check("Это спам?").Verdict)
+	assert.Equal(t, Reject, chains.Questions.Check("www.a.example www.b.example").Verdict)
+	assert.Equal(t, Flag, chains.Answers.Check("www.a.example www.b.example").Verdict)
+
+	_, err = New(config.ContentFiltersConfig{BannedWordsFile: filepath.Join(t.TempDir(), "missing.txt")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+type linksFilter struct {
+	limit   int
+	verdict Verdict
+}
+
+// Links matches texts with more than limit links.
+func Links(limit int, verdict Verdict) Filter {
+	return linksFilter{limit, verdict}
+}
+
+func (linksFilter) Name() string {
+	return config.FilterLinks
+}
+
+func (l linksFilter) Check(text string) Result {
+	if len(linkPattern.FindAllStringIndex(text, l.limit+1)) <= l.limit {
+		return Result{Verdict: Allow}
+	}
+	return Result{Verdict: l.verdict, Filter: l.Name(), Reason: i18n.NewError(i18n.FilterLinks, l.limit)}
+}
+
+// capsMinLetters is the number of letters below which a text is too short
+// to count as shouting.
+const capsMinLetters = 10
+
+type capsFilter struct {
+	ratio   float64
+	verdict Verdict
+}
+
+// Caps matches texts in which at least ratio of the letters are capitals.
+func Caps(ratio float64, verdict Verdict) Filter {
+	return capsFilter{ratio, verdict}
+}
+
+func (capsFilter) Name() string {
+	return config.FilterCaps
+}
+
+func (c capsFilter) Check(text string) Result {
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsUpper(r) {
+			upper++
+			letters++
+		} else if unicode.IsLower(r) {
+			letters++
+		}
+	}
+	if letters < capsMinLetters || float64(upper) < c.ratio*float64(letters) {
+		return Result{Verdict: Allow}
+	}
+	return Result{Verdict: c.verdict, Filter: c.Name(), Reason: i18n.NewError(i18n.FilterCaps)}
+}
+
+type repeatedCharsFilter struct {
+	limit   int
+	verdict Verdict
+}
+
+// RepeatedChars matches texts that repeat a letter or one of "!?." more
+// than limit times in a row, such as "sooooo" or "!!!!!!". Other characters
+// are left alone so that numbers, indentation and markdown rules pass.
+func RepeatedChars(limit int, verdict Verdict) Filter {
+	return repeatedCharsFilter{limit, verdict}
+}
+
+func (repeatedCharsFilter) Name() string {
+	return config.FilterRepeatedChars
+}
+
+func (f repeatedCharsFilter) Check(text string) Result {
+	var last rune
+	run := 0
+	for _, r := range strings.ToLower(text) {
+		if r != last {
+			last, run = r, 0
+		}
+		run++
+		if run > f.limit && (unicode.IsLetter(r) || strings.ContainsRune("!?.", r)) {
+			return Result{Verdict: f.verdict, Filter: f.Name(), Reason: i18n.NewError(i18n.FilterRepeatedChars, r, f.limit)}
+		}
+	}
+	return Result{Verdict: Allow}
+}
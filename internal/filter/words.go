@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed banned_words.txt
+var defaultBannedWords string
+
+// minStem is the shortest stem, in letters, left after cutting an ending,
+// so that short words do not collapse into each other.
+const minStem = 3
+
+// Inflectional endings cut from words and their banned forms, and
+// derivational suffixes cut from words only, so that a banned "спам" also
+// matches "спамеру" and a banned "spam" matches "spammers".
+var (
+	russianEndings = []string{
+		"а", "я", "о", "е", "ы", "и", "у", "ю", "ь", "й",
+		"ой", "ей", "ом", "ем", "ам", "ям", "ах", "ях", "ами", "ями", "ов", "ев",
+		"ия", "ие", "ий", "ию", "ый", "ая", "ое", "ые", "ую", "юю", "ых", "их", "ым", "им",
+		"ого", "его", "ому", "ему", "ыми", "ими",
+		"ть", "ти", "ать", "ять", "еть", "ить", "ет", "ит", "ут", "ют", "ат", "ят",
+		"ешь", "ишь", "ете", "ите", "л", "ла", "ло", "ли",
+		"ал", "ала", "али", "ил", "ила", "или", "ел", "ела", "ели",
+	}
+	russianSuffixes  = []string{"ер", "ник", "щик", "чик", "ист", "ск", "к", "ок", "ек", "ик", "ств"}
+	russianReflexive = []string{"ся", "сь"}
+
+	englishEndings  = []string{"s", "es", "ed", "ing", "ings", "er", "ers", "est", "ly"}
+	englishSuffixes = []string{"ic", "ish", "ness", "y"}
+)
+
+// Lexicon is a list of banned words. A word matches the words of a text
+// that share its stem, and a word ending in "*" matches every word
+// starting with the rest.
+type Lexicon struct {
+	stems    map[string]bool
+	prefixes []string
+}
+
+// NewLexicon builds a lexicon from words.
+func NewLexicon(words []string) *Lexicon {
+	lexicon := &Lexicon{stems: map[string]bool{}}
+	for _, word := range words {
+		word = normalize(word)
+		if prefix, ok := strings.CutSuffix(word, "*"); ok {
+			lexicon.prefixes = append(lexicon.prefixes, prefix)
+			continue
+		}
+		lexicon.stems[word] = true
+		lexicon.stems[cutLongest(word, endingsOf(word))] = true
+	}
+	return lexicon
+}
+
+// LoadLexicon reads a lexicon from a file with one word per line. Empty
+// lines and lines starting with "#" are skipped.
+func LoadLexicon(path string) (*Lexicon, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open banned words: %w", err)
+	}
+	defer file.Close()
+	return readLexicon(path, file)
+}
+
+// DefaultLexicon returns the lexicon of the banned words list built into
+// the binary.
+func DefaultLexicon() *Lexicon {
+	lexicon, err := readLexicon("banned_words.txt", strings.NewReader(defaultBannedWords))
+	if err != nil {
+		panic(err)
+	}
+	return lexicon
+}
+
+// readLexicon reads a lexicon from r, naming it name in errors.
+func readLexicon(name string, r io.Reader) (*Lexicon, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		if strings.IndexFunc(word, unicode.IsSpace) >= 0 {
+			return nil, fmt.Errorf("%s:%d: %q is not a single word", name, line, word)
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read banned words: %w", err)
+	}
+	return NewLexicon(words), nil
+}
+
+// Match returns the first word of text that the lexicon bans.
+func (l *Lexicon) Match(text string) (string, bool) {
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		normalized := normalize(word)
+		for _, prefix := range l.prefixes {
+			if strings.HasPrefix(normalized, prefix) {
+				return word, true
+			}
+		}
+		for _, stem := range stems(normalized) {
+			if l.stems[stem] {
+				return word, true
+			}
+		}
+	}
+	return "", false
+}
+
+type bannedWordsFilter struct {
+	lexicon *Lexicon
+	verdict Verdict
+}
+
+// BannedWords matches texts containing a word of lexicon.
+func BannedWords(lexicon *Lexicon, verdict Verdict) Filter {
+	return bannedWordsFilter{lexicon, verdict}
+}
+
+func (bannedWordsFilter) Name() string {
+	return config.FilterBannedWords
+}
+
+func (b bannedWordsFilter) Check(text string) Result {
+	word, ok := b.lexicon.Match(text)
+	if !ok {
+		return Result{Verdict: Allow}
+	}
+	return Result{Verdict: b.verdict, Filter: b.Name(), Reason: i18n.NewError(i18n.FilterBannedWord, word)}
+}
+
+// stems returns the word and every stem it can have once a reflexive
+// ending, an inflectional ending and a derivational suffix are cut.
+func stems(word string) []string {
+	russian := isRussian(word)
+	reflexive := []string{}
+	suffixes := englishSuffixes
+	if russian {
+		reflexive = russianReflexive
+		suffixes = russianSuffixes
+	}
+
+	found := cutEach([]string{word}, reflexive)
+	found = cutEach(found, endingsOf(word))
+	found = cutEach(found, suffixes)
+	if !russian {
+		// "spamming" and "spammer" double the last consonant of "spam".
+		for _, stem := range found {
+			last, size := utf8.DecodeLastRuneInString(stem)
+			before, _ := utf8.DecodeLastRuneInString(stem[:len(stem)-size])
+			if last == before && !strings.ContainsRune("aeiou", last) && utf8.RuneCountInString(stem) > minStem {
+				found = append(found, stem[:len(stem)-size])
+			}
+		}
+	}
+	return found
+}
+
+// cutEach returns words together with what is left of them after cutting
+// each of endings, keeping stems of at least minStem letters.
+func cutEach(words, endings []string) []string {
+	cut := slices.Clone(words)
+	for _, word := range words {
+		for _, ending := range endings {
+			if stem, ok := strings.CutSuffix(word, ending); ok && utf8.RuneCountInString(stem) >= minStem {
+				cut = append(cut, stem)
+			}
+		}
+	}
+	return cut
+}
+
+// cutLongest cuts the longest of endings from word, keeping a stem of at
+// least minStem letters.
+func cutLongest(word string, endings []string) string {
+	stem := word
+	for _, ending := range endings {
+		if cut, ok := strings.CutSuffix(word, ending); ok && utf8.RuneCountInString(cut) >= minStem && len(cut) < len(stem) {
+			stem = cut
+		}
+	}
+	return stem
+}
+
+func endingsOf(word string) []string {
+	if isRussian(word) {
+		return russianEndings
+	}
+	return englishEndings
+}
+
+func isRussian(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }) >= 0
+}
+
+func normalize(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "ё", "е")
+}
@@ -0,0 +1,71 @@
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexicon_MatchesWordForms(t *testing.T) {
+	lexicon := NewLexicon([]string{"спам", "спамить", "Идиот", "spam", "idiot", "казино*"})
+
+	tests := []struct {
+		text    string
+		matched string
+	}{
+		{"Это спам", "спам"},
+		{"Не пишите спамеру", "спамеру"},
+		{"Хватит спамить", "спамить"},
+		{"Они спамили весь день", "спамили"},
+		{"Кто-то спамится", "спамится"},
+		{"Какой идиотский вопрос", "идиотский"},
+		{"Ты ИДИОТ", "ИДИОТ"},
+		{"Ну ты и идиёт", ""},
+		{"Stop spamming", "spamming"},
+		{"Two spammers met", "spammers"},
+		{"What an idiotic question", "idiotic"},
+		{"Лучшее онлайн-казино тут", "казино"},
+		{"Казиношный бонус", "Казиношный"},
+		{"How do I spawn a goroutine?", ""},
+		{"Как спасти программу?", ""},
+		{"Spammy text", "Spammy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			word, ok := lexicon.Match(tt.text)
+			assert.Equal(t, tt.matched != "", ok)
+			assert.Equal(t, tt.matched, word)
+		})
+	}
+}
+
+func TestLoadLexicon(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# spam\nвиагра\n\n  casino*  \n"), 0o600))
+
+	lexicon, err := LoadLexicon(path)
+	require.NoError(t, err)
+	_, ok := lexicon.Match("Дешёвая виагры")
+	assert.True(t, ok)
+	_, ok = lexicon.Match("casinos online")
+	assert.True(t, ok)
+	_, ok = lexicon.Match("spam")
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte("free money\n"), 0o600))
+	_, err = LoadLexicon(path)
+	assert.ErrorContains(t, err, "banned.txt:1")
+
+	_, err = LoadLexicon(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestDefaultLexicon(t *testing.T) {
+	_, ok := DefaultLexicon().Match("Лучшие казино")
+	assert.True(t, ok)
+	_, ok = DefaultLexicon().Match("Read the docs")
+	assert.False(t, ok)
+}
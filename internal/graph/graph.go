@@ -0,0 +1,104 @@
+// Package graph serves a GraphQL API over the question and answer services.
+package graph
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	maxDepth       = 8
+	maxRequestSize = 1 << 20
+)
+
+type loaders struct {
+	answers   *loader[uint, []*models.Answer]
+	questions *loader[uint, *models.Question]
+}
+
+type loadersKey struct{}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+type Handler struct {
+	schema    *graphql.Schema
+	questions services.QuestionService
+	answers   services.AnswerService
+}
+
+func NewHandler(questions services.QuestionService, answers services.AnswerService) *Handler {
+	return &Handler{
+		schema: graphql.MustParseSchema(schema, &resolver{questions, answers},
+			graphql.UseFieldResolvers(),
+			graphql.MaxDepth(maxDepth),
+		),
+		questions: questions,
+		answers:   answers,
+	}
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP executes a query sent as JSON in a POST body.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req request
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), loadersKey{}, h.newLoaders())
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		log.Printf("Error encoding response: %v", err)
+		return
+	}
+}
+
+// newLoaders returns loaders scoped to one request, so cached values never
+// outlive it.
+func (h *Handler) newLoaders() *loaders {
+	return &loaders{
+		answers: newLoader(func(questionIDs []uint) (map[uint][]*models.Answer, error) {
+			return h.answers.GetAnswersByQuestionIDs(questionIDs)
+		}),
+		questions: newLoader(func(ids []uint) (map[uint]*models.Question, error) {
+			found, err := h.questions.GetQuestionsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+
+			questions := make(map[uint]*models.Question, len(found))
+			for _, question := range found {
+				questions[uint(question.ID)] = question
+			}
+			return questions, nil
+		}),
+	}
+}
@@ -0,0 +1,251 @@
+package graph
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingAnswerRepository counts batch loads to catch N+1 queries.
+type countingAnswerRepository struct {
+	repositories.AnswerRepository
+	batches atomic.Int32
+}
+
+func (c *countingAnswerRepository) FindByQuestionIDs(questionIDs []uint) ([]*models.Answer, error) {
+	c.batches.Add(1)
+	return c.AnswerRepository.FindByQuestionIDs(questionIDs)
+}
+
+// countingQuestionRepository counts the calls that load questions by id.
+type countingQuestionRepository struct {
+	repositories.QuestionRepository
+	lookups atomic.Int32
+	batches atomic.Int32
+}
+
+func (c *countingQuestionRepository) FindByID(id uint) (*models.Question, error) {
+	c.lookups.Add(1)
+	return c.QuestionRepository.FindByID(id)
+}
+
+func (c *countingQuestionRepository) FindByIDs(ids []uint) ([]*models.Question, error) {
+	c.batches.Add(1)
+	return c.QuestionRepository.FindByIDs(ids)
+}
+
+type graphResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// testUserID is a registered user of the handlers built by newTestHandler.
+var testUserID = uuid.MustParse("5f0e8a3c-2b7d-4c61-8e94-a1d3b6c7f802")
+
+func newTestHandler(t *testing.T) (*Handler, *countingAnswerRepository, *countingQuestionRepository) {
+	t.Helper()
+
+	store := memory.NewStore()
+	questionRepo := &countingQuestionRepository{QuestionRepository: memory.NewQuestionRepository(store)}
+	answerRepo := &countingAnswerRepository{AnswerRepository: memory.NewAnswerRepository(store)}
+	userRepo := memory.NewUserRepository(store)
+	require.NoError(t, userRepo.Create(&models.User{ID: testUserID, DisplayName: "Tester", Status: models.UserActive}))
+	transactor := memory.NewTransactor(store, func() {})
+
+	questions := services.NewQuestionService(questionRepo, userRepo, transactor, nil)
+	answers := services.NewAnswerService(questionRepo, answerRepo, userRepo, transactor, nil, nil)
+	return NewHandler(questions, answers), answerRepo, questionRepo
+}
+
+func execute(t *testing.T, handler http.Handler, query string, variables map[string]any) graphResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response graphResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response
+}
+
+func TestGraphQL_MutationsAndBatchedAnswers(t *testing.T) {
+	handler, answerRepo, _ := newTestHandler(t)
+	userID := testUserID.String()
+
+	for i := range 3 {
+		created := execute(t, handler, `mutation($text: String!) { createQuestion(text: $text) { id } }`,
+			map[string]any{"text": "Question number " + string(rune('A'+i))})
+		require.Empty(t, created.Errors)
+
+		var data struct{ CreateQuestion struct{ ID string } }
+		require.NoError(t, json.Unmarshal(created.Data, &data))
+
+		for range i + 1 {
+			answered := execute(t, handler, `mutation($q: ID!, $u: ID!) { createAnswer(questionId: $q, userId: $u, text: "An answer") { id } }`,
+				map[string]any{"q": data.CreateQuestion.ID, "u": userID})
+			require.Empty(t, answered.Errors)
+		}
+	}
+
+	answerRepo.batches.Store(0)
+	response := execute(t, handler, `{
+		questions {
+			totalCount
+			edges { node { id answerCount answers(first: 1) { totalCount edges { node { text textHtml userId } } } } }
+		}
+	}`, nil)
+	require.Empty(t, response.Errors)
+
+	var data struct {
+		Questions struct {
+			TotalCount int
+			Edges      []struct {
+				Node struct {
+					ID          string
+					AnswerCount int
+					Answers     struct {
+						TotalCount int
+						Edges      []struct {
+							Node struct{ Text, TextHTML, UserID string }
+						}
+					}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &data))
+
+	assert.Equal(t, 3, data.Questions.TotalCount)
+	require.Len(t, data.Questions.Edges, 3)
+	for i, edge := range data.Questions.Edges {
+		assert.Equal(t, i+1, edge.Node.AnswerCount)
+		assert.Equal(t, i+1, edge.Node.Answers.TotalCount)
+		require.Len(t, edge.Node.Answers.Edges, 1)
+		assert.Equal(t, userID, edge.Node.Answers.Edges[0].Node.UserID)
+		assert.Equal(t, "<p>An answer</p>\n", edge.Node.Answers.Edges[0].Node.TextHTML)
+	}
+	assert.Equal(t, int32(1), answerRepo.batches.Load(), "answers of all questions are loaded in one batch")
+}
+
+func TestGraphQL_QuestionsPagination(t *testing.T) {
+	handler, _, _ := newTestHandler(t)
+	for range 3 {
+		require.Empty(t, execute(t, handler, `mutation { createQuestion(text: "Paged question") { id } }`, nil).Errors)
+	}
+
+	type page struct {
+		Questions struct {
+			Edges    []struct{ Node struct{ ID string } }
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+	}
+	query := `query($after: String) { questions(first: 2, after: $after) { edges { node { id } } pageInfo { hasNextPage endCursor } } }`
+
+	var first page
+	response := execute(t, handler, query, nil)
+	require.Empty(t, response.Errors)
+	require.NoError(t, json.Unmarshal(response.Data, &first))
+	require.Len(t, first.Questions.Edges, 2)
+	assert.True(t, first.Questions.PageInfo.HasNextPage)
+
+	var second page
+	response = execute(t, handler, query, map[string]any{"after": first.Questions.PageInfo.EndCursor})
+	require.Empty(t, response.Errors)
+	require.NoError(t, json.Unmarshal(response.Data, &second))
+	require.Len(t, second.Questions.Edges, 1)
+	assert.Equal(t, "3", second.Questions.Edges[0].Node.ID)
+	assert.False(t, second.Questions.PageInfo.HasNextPage)
+
+	response = execute(t, handler, query, map[string]any{"after": "bogus"})
+	require.NotEmpty(t, response.Errors)
+	assert.Equal(t, "invalid cursor", response.Errors[0].Message)
+}
+
+func TestGraphQL_BatchedQuestionsOfAnswers(t *testing.T) {
+	handler, _, questionRepo := newTestHandler(t)
+	for range 2 {
+		require.Empty(t, execute(t, handler, `mutation { createQuestion(text: "Which question?") { id } }`, nil).Errors)
+	}
+	for _, q := range []string{"1", "2"} {
+		require.Empty(t, execute(t, handler, `mutation($q: ID!, $u: ID!) { createAnswer(questionId: $q, userId: $u, text: "An answer") { id } }`,
+			map[string]any{"q": q, "u": testUserID.String()}).Errors)
+	}
+
+	questionRepo.lookups.Store(0)
+	response := execute(t, handler, `{ a: answer(id: "1") { question { id } } b: answer(id: "2") { question { id } } }`, nil)
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"a":{"question":{"id":"1"}},"b":{"question":{"id":"2"}}}`, string(response.Data))
+	assert.Equal(t, int32(1), questionRepo.batches.Load(), "questions are loaded in one batch")
+	assert.Zero(t, questionRepo.lookups.Load(), "no question is loaded with its answers")
+}
+
+func TestGraphQL_QuestionsSkipHidden(t *testing.T) {
+	handler, _, questionRepo := newTestHandler(t)
+	for range 3 {
+		require.Empty(t, execute(t, handler, `mutation { createQuestion(text: "Paged question") { id } }`, nil).Errors)
+	}
+	require.NoError(t, questionRepo.SetHidden(2, true))
+
+	response := execute(t, handler, `{ questions(first: 1) { totalCount edges { node { id } } pageInfo { hasNextPage } } }`, nil)
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"questions":{"totalCount":2,"edges":[{"node":{"id":"1"}}],"pageInfo":{"hasNextPage":true}}}`, string(response.Data))
+}
+
+func TestGraphQL_ValidationAndMissingRecords(t *testing.T) {
+	handler, _, _ := newTestHandler(t)
+
+	response := execute(t, handler, `mutation { createQuestion(text: "Hi") { id } }`, nil)
+	require.NotEmpty(t, response.Errors)
+	assert.Equal(t, "text must be at least 5 characters", response.Errors[0].Message)
+
+	response = execute(t, handler, `{ question(id: "42") { id } answer(id: "7") { id } }`, nil)
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"question":null,"answer":null}`, string(response.Data))
+
+	response = execute(t, handler, `mutation { deleteQuestion(id: "42") }`, nil)
+	require.Empty(t, response.Errors)
+	assert.JSONEq(t, `{"deleteQuestion":false}`, string(response.Data))
+
+	require.Empty(t, execute(t, handler, `mutation { createQuestion(text: "Who answers?") { id } }`, nil).Errors)
+	response = execute(t, handler, `mutation($u: ID!) { createAnswer(questionId: "1", userId: $u, text: "An answer") { id } }`,
+		map[string]any{"u": uuid.NewString()})
+	require.NotEmpty(t, response.Errors)
+	assert.Equal(t, "user not found", response.Errors[0].Message)
+}
+
+func TestGraphQL_RejectsBadRequests(t *testing.T) {
+	handler, _, _ := newTestHandler(t)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/graphql", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	assert.Equal(t, "Method not allowed\n", rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte("{")))
+	request = request.WithContext(i18n.WithLocale(request.Context(), i18n.Locales[1]))
+	handler.ServeHTTP(rr, request)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "некорректное тело запроса\n", rr.Body.String())
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	batchWait    = 2 * time.Millisecond
+	maxBatchSize = 100
+)
+
+// loader collects the keys requested by concurrently running resolvers and
+// fetches them with one call, caching the results for the request.
+type loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+	batches map[K]*batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys   []K
+	once   sync.Once
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+func newLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:   fetch,
+		batches: make(map[K]*batch[K, V]),
+	}
+}
+
+// Load returns the value for key, or the zero value when fetch did not
+// return one.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.batches[key]
+	if !ok {
+		b = l.pending
+		if b == nil {
+			b = &batch[K, V]{done: make(chan struct{})}
+			l.pending = b
+			time.AfterFunc(batchWait, func() { l.dispatch(b) })
+		}
+		b.keys = append(b.keys, key)
+		l.batches[key] = b
+		if len(b.keys) >= maxBatchSize {
+			l.pending = nil
+			go l.dispatch(b)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.values[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) dispatch(b *batch[K, V]) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.pending == b {
+			l.pending = nil
+		}
+		keys := b.keys
+		l.mu.Unlock()
+
+		b.values, b.err = l.fetch(keys)
+		close(b.done)
+	})
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxPageSize  = 100
+	cursorPrefix = "cursor:"
+)
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+type questionConnection struct {
+	Edges      []*questionEdge
+	PageInfo   pageInfo
+	TotalCount int32
+}
+
+type questionEdge struct {
+	Cursor string
+	Node   *questionResolver
+}
+
+type answerConnection struct {
+	Edges      []*answerEdge
+	PageInfo   pageInfo
+	TotalCount int32
+}
+
+type answerEdge struct {
+	Cursor string
+	Node   *answerResolver
+}
+
+// paginate returns the items after the cursor. Items must be ordered by id;
+// the cursor is the id of the last item seen, so it stays valid when items
+// are added or removed.
+func paginate[T any](items []T, id func(T) int, args pageArgs) ([]T, pageInfo, error) {
+	first, after, err := parsePageArgs(args)
+	if err != nil {
+		return nil, pageInfo{}, err
+	}
+
+	start := 0
+	for start < len(items) && id(items[start]) <= after {
+		start++
+	}
+
+	end := min(start+first, len(items))
+	page := items[start:end]
+
+	info := pageInfo{HasNextPage: end < len(items)}
+	if len(page) > 0 {
+		cursor := encodeCursor(id(page[len(page)-1]))
+		info.EndCursor = &cursor
+	}
+	return page, info, nil
+}
+
+// parsePageArgs validates first and returns it with the id in the cursor,
+// or zero without one.
+func parsePageArgs(args pageArgs) (first, after int, err error) {
+	first = int(args.First)
+	if first < 0 || first > maxPageSize {
+		return 0, 0, errors.New("first must be between 0 and " + strconv.Itoa(maxPageSize))
+	}
+	if args.After != nil {
+		after, err = decodeCursor(*args.After)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return first, after, nil
+}
+
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(id)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if id, ok := strings.CutPrefix(string(raw), cursorPrefix); ok {
+			if n, err := strconv.Atoi(id); err == nil {
+				return n, nil
+			}
+		}
+	}
+	return 0, errors.New("invalid cursor")
+}
@@ -0,0 +1,290 @@
+package graph
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"gorm.io/gorm"
+)
+
+// resolver is the root of the schema. It only talks to the services.
+type resolver struct {
+	questions services.QuestionService
+	answers   services.AnswerService
+}
+
+// pageArgs are the connection arguments; first defaults in the schema.
+type pageArgs struct {
+	First int32
+	After *string
+}
+
+// Questions asks for one question more than the page holds to learn
+// whether there is a next page.
+func (r *resolver) Questions(args pageArgs) (*questionConnection, error) {
+	first, after, err := parsePageArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := r.questions.GetQuestionsPage(repositories.Page{After: uint(after), Limit: first + 1})
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.questions.CountQuestions()
+	if err != nil {
+		return nil, err
+	}
+
+	info := pageInfo{HasNextPage: len(questions) > first}
+	questions = questions[:min(first, len(questions))]
+	if len(questions) > 0 {
+		cursor := encodeCursor(questions[len(questions)-1].ID)
+		info.EndCursor = &cursor
+	}
+
+	connection := &questionConnection{PageInfo: info, TotalCount: int32(total)}
+	for _, question := range questions {
+		connection.Edges = append(connection.Edges, &questionEdge{
+			Cursor: encodeCursor(question.ID),
+			Node:   &questionResolver{r, question},
+		})
+	}
+	return connection, nil
+}
+
+func (r *resolver) Question(args struct{ ID graphql.ID }) (*questionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := r.questions.GetQuestion(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &questionResolver{r, question}, nil
+}
+
+func (r *resolver) Answer(args struct{ ID graphql.ID }) (*answerResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := r.answers.GetAnswer(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &answerResolver{r, answer}, nil
+}
+
+func (r *resolver) CreateQuestion(ctx context.Context, args struct {
+	Text   string
+	UserID *graphql.ID
+}) (*questionResolver, error) {
+	question := &models.Question{Text: args.Text}
+	if args.UserID != nil {
+		userID, err := uuid.Parse(string(*args.UserID))
+		if err != nil {
+			return nil, errors.New("userId must be a UUID")
+		}
+		question.UserID = &userID
+	}
+	if err := question.Validate(); err != nil {
+		return nil, errors.New(i18n.Localize(ctx, err))
+	}
+
+	created, err := r.questions.CreateQuestion(question)
+	if err != nil {
+		return nil, err
+	}
+	return &questionResolver{r, created}, nil
+}
+
+func (r *resolver) DeleteQuestion(args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.questions.GetQuestion(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, r.questions.DeleteQuestion(id)
+}
+
+func (r *resolver) CreateAnswer(ctx context.Context, args struct {
+	QuestionID graphql.ID
+	UserID     graphql.ID
+	Text       string
+}) (*answerResolver, error) {
+	questionID, err := parseID(args.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(string(args.UserID))
+	if err != nil {
+		return nil, errors.New("userId must be a UUID")
+	}
+
+	answer := &models.Answer{UserID: userID, Text: args.Text}
+	if err := answer.Validate(); err != nil {
+		return nil, errors.New(i18n.Localize(ctx, err))
+	}
+
+	created, err := r.answers.CreateAnswer(questionID, answer)
+	if err != nil {
+		return nil, err
+	}
+	return &answerResolver{r, created}, nil
+}
+
+func (r *resolver) DeleteAnswer(args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.answers.GetAnswer(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, r.answers.DeleteAnswer(id)
+}
+
+type questionResolver struct {
+	root     *resolver
+	question *models.Question
+}
+
+func (q *questionResolver) ID() graphql.ID {
+	return formatID(q.question.ID)
+}
+
+func (q *questionResolver) Text() string {
+	return q.question.Text
+}
+
+func (q *questionResolver) TextHTML() string {
+	return q.question.TextHTML
+}
+
+func (q *questionResolver) UserID() *graphql.ID {
+	if q.question.UserID == nil {
+		return nil
+	}
+	id := graphql.ID(q.question.UserID.String())
+	return &id
+}
+
+func (q *questionResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: q.question.CreatedAt}
+}
+
+func (q *questionResolver) AnswerCount() int32 {
+	return int32(q.question.AnswerCount)
+}
+
+func (q *questionResolver) LastActivityAt() graphql.Time {
+	return graphql.Time{Time: q.question.LastActivityAt}
+}
+
+func (q *questionResolver) Answers(ctx context.Context, args pageArgs) (*answerConnection, error) {
+	answers, err := q.loadAnswers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, info, err := paginate(answers, func(a *models.Answer) int { return a.ID }, args)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &answerConnection{PageInfo: info, TotalCount: int32(len(answers))}
+	for _, answer := range page {
+		connection.Edges = append(connection.Edges, &answerEdge{
+			Cursor: encodeCursor(answer.ID),
+			Node:   &answerResolver{q.root, answer},
+		})
+	}
+	return connection, nil
+}
+
+// loadAnswers goes through the request's answer loader, so the answers of
+// every question in a list are fetched with one query.
+func (q *questionResolver) loadAnswers(ctx context.Context) ([]*models.Answer, error) {
+	return loadersFrom(ctx).answers.Load(ctx, uint(q.question.ID))
+}
+
+type answerResolver struct {
+	root   *resolver
+	answer *models.Answer
+}
+
+func (a *answerResolver) ID() graphql.ID {
+	return formatID(a.answer.ID)
+}
+
+func (a *answerResolver) QuestionID() graphql.ID {
+	return formatID(int(a.answer.QuestionID))
+}
+
+func (a *answerResolver) UserID() graphql.ID {
+	return graphql.ID(a.answer.UserID.String())
+}
+
+func (a *answerResolver) Text() string {
+	return a.answer.Text
+}
+
+func (a *answerResolver) TextHTML() string {
+	return a.answer.TextHTML
+}
+
+func (a *answerResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: a.answer.CreatedAt}
+}
+
+func (a *answerResolver) Question(ctx context.Context) (*questionResolver, error) {
+	question, err := loadersFrom(ctx).questions.Load(ctx, a.answer.QuestionID)
+	if err != nil || question == nil {
+		return nil, err
+	}
+	return &questionResolver{a.root, question}, nil
+}
+
+func parseID(id graphql.ID) (uint, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil || n <= 0 {
+		return 0, errors.New("invalid id " + strconv.Quote(string(id)))
+	}
+	return uint(n), nil
+}
+
+func formatID(id int) graphql.ID {
+	return graphql.ID(strconv.Itoa(id))
+}
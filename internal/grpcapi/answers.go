@@ -0,0 +1,193 @@
+package grpcapi
+
+import (
+	qnav1 "api_service_questions_and_answers/api/qna/v1"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type answerServer struct {
+	qnav1.UnimplementedAnswerServiceServer
+
+	service         services.AnswerService
+	questionService services.QuestionService
+	broadcaster     *events.Broadcaster
+}
+
+func (s *answerServer) ListAnswers(ctx context.Context, req *qnav1.ListAnswersRequest) (*qnav1.ListAnswersResponse, error) {
+	questionID, err := toID(ctx, req.GetQuestionId(), "question_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.questionService.GetQuestion(questionID); err != nil {
+		return nil, toStatus(ctx, err, "question")
+	}
+
+	byQuestion, err := s.service.GetAnswersByQuestionIDs([]uint{questionID})
+	if err != nil {
+		return nil, toStatus(ctx, err, "answers")
+	}
+
+	page, next, err := paginate(byQuestion[questionID], func(a *models.Answer) int { return a.ID }, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	resp := &qnav1.ListAnswersResponse{
+		Answers:       make([]*qnav1.Answer, 0, len(page)),
+		NextPageToken: next,
+	}
+	for _, answer := range page {
+		resp.Answers = append(resp.Answers, toAnswer(answer))
+	}
+	return resp, nil
+}
+
+func (s *answerServer) GetAnswer(ctx context.Context, req *qnav1.GetAnswerRequest) (*qnav1.Answer, error) {
+	id, err := toID(ctx, req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := s.service.GetAnswer(id)
+	if err != nil {
+		return nil, toStatus(ctx, err, "answer")
+	}
+	return toAnswer(answer), nil
+}
+
+func (s *answerServer) CreateAnswer(ctx context.Context, req *qnav1.CreateAnswerRequest) (*qnav1.Answer, error) {
+	questionID, err := toID(ctx, req.GetQuestionId(), "question_id")
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, i18n.Message(ctx, i18n.MustBeUUID, "user_id"))
+	}
+
+	answer := &models.Answer{
+		UserID:    userID,
+		Text:      req.GetText(),
+		CreatedAt: time.Now(),
+	}
+	if err := answer.Validate(); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	created, err := s.service.CreateAnswer(questionID, answer)
+	if err != nil {
+		return nil, toStatus(ctx, err, "answer")
+	}
+	return toAnswer(created), nil
+}
+
+func (s *answerServer) DeleteAnswer(ctx context.Context, req *qnav1.DeleteAnswerRequest) (*emptypb.Empty, error) {
+	id, err := toID(ctx, req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteAnswer(id); err != nil {
+		return nil, toStatus(ctx, err, "answer")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *answerServer) WatchAnswers(req *qnav1.WatchAnswersRequest, stream qnav1.AnswerService_WatchAnswersServer) error {
+	ctx := stream.Context()
+	questionID, err := toID(ctx, req.GetQuestionId(), "question_id")
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.questionService.GetQuestion(questionID); err != nil {
+		return toStatus(ctx, err, "question")
+	}
+
+	sub := s.broadcaster.Subscribe(questionID, req.GetLastEventId())
+	defer sub.Close()
+
+	// Headers tell the client that the subscription is in place.
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.Unavailable, i18n.Message(ctx, i18n.StreamFellBehind))
+			}
+
+			answerEvent, err := toAnswerEvent(event)
+			if err != nil {
+				log.Printf("Failed to read answer of %s event: %v", event.Type, err)
+				continue
+			}
+			if answerEvent == nil {
+				continue
+			}
+			if err := stream.Send(answerEvent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// toAnswerEvent converts an answer event from the broadcaster and returns
+// nil for other events. The answer is either in Data or, when the event
+// came through the outbox, its JSON.
+func toAnswerEvent(event events.Event) (*qnav1.AnswerEvent, error) {
+	var eventType qnav1.AnswerEvent_Type
+	switch event.Type {
+	case events.AnswerCreated:
+		eventType = qnav1.AnswerEvent_TYPE_CREATED
+	case events.AnswerDeleted:
+		eventType = qnav1.AnswerEvent_TYPE_DELETED
+	default:
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer models.Answer
+	if err := json.Unmarshal(raw, &answer); err != nil {
+		return nil, err
+	}
+
+	return &qnav1.AnswerEvent{
+		Id:     event.ID,
+		Type:   eventType,
+		Answer: toAnswer(&answer),
+	}, nil
+}
+
+func toAnswer(answer *models.Answer) *qnav1.Answer {
+	return &qnav1.Answer{
+		Id:         int64(answer.ID),
+		QuestionId: int64(answer.QuestionID),
+		UserId:     answer.UserID.String(),
+		Text:       answer.Text,
+		CreatedAt:  timestamppb.New(answer.CreatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package grpcapi
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// toStatus maps a service error to a gRPC status with a message in the
+// locale of ctx. Unexpected errors are logged and reported as INTERNAL
+// without details.
+func toStatus(ctx context.Context, err error, what string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, i18n.Message(ctx, notFoundCode(what)))
+	case errors.Is(err, services.ErrQuestionNotFound), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return status.Error(codes.NotFound, i18n.Message(ctx, i18n.V2QuestionNotFound))
+	case errors.Is(err, services.ErrUserNotFound):
+		return status.Error(codes.InvalidArgument, i18n.Message(ctx, i18n.V2UnknownUser))
+	case errors.Is(err, services.ErrUserSuspended):
+		return status.Error(codes.PermissionDenied, i18n.Message(ctx, i18n.V2UserSuspended))
+	case errors.Is(err, services.ErrContentRejected):
+		return status.Error(codes.InvalidArgument, i18n.Localize(ctx, err))
+	}
+
+	log.Printf("Failed to handle %s: %v", what, err)
+	return status.Error(codes.Internal, i18n.Message(ctx, i18n.InternalError))
+}
+
+func notFoundCode(what string) string {
+	switch what {
+	case "question":
+		return i18n.V2QuestionNotFound
+	case "answer":
+		return i18n.AnswerNotFound
+	case "questions":
+		return i18n.QuestionsNotFound
+	case "answers":
+		return i18n.AnswersNotFound
+	}
+	return i18n.NotFound
+}
+
+func invalidArgument(ctx context.Context, err error) error {
+	return status.Error(codes.InvalidArgument, i18n.Localize(ctx, err))
+}
+
+// toID converts an id from a request, rejecting ids that cannot exist.
+func toID(ctx context.Context, id int64, field string) (uint, error) {
+	if id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, i18n.Message(ctx, i18n.MustBePositive, field))
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,96 @@
+package grpcapi
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"context"
+	"crypto/subtle"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func unaryLogging(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+func streamLogging(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	log.Printf("gRPC %s %s %s", info.FullMethod, status.Code(err), time.Since(start))
+	return err
+}
+
+// unaryLocale puts the locale best matching the accept-language metadata
+// of each call in its context, for messages to be translated with. Calls
+// matching no supported locale get fallback.
+func unaryLocale(fallback language.Tag) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withLocale(ctx, fallback), req)
+	}
+}
+
+func streamLocale(fallback language.Tag) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &localeStream{stream, withLocale(stream.Context(), fallback)})
+	}
+}
+
+// localeStream is a stream whose context carries the locale of the call.
+type localeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *localeStream) Context() context.Context {
+	return s.ctx
+}
+
+func withLocale(ctx context.Context, fallback language.Tag) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	locale := i18n.Match(strings.Join(md.Get("accept-language"), ","), fallback)
+	return i18n.WithLocale(ctx, locale)
+}
+
+func unaryAuth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorize(ctx, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(token string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), token); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// authorize checks the "authorization: Bearer <token>" metadata. An empty
+// token rejects every call.
+func authorize(ctx context.Context, token string) error {
+	if token == "" {
+		return status.Error(codes.PermissionDenied, i18n.Message(ctx, i18n.GRPCDisabled))
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		provided, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, i18n.Message(ctx, i18n.InvalidToken))
+}
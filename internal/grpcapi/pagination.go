@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+	pageTokenPrefix = "after:"
+)
+
+// paginate returns the page of items after the token and the token of the
+// next page. Items must be ordered by id; the token holds the id of the
+// last item sent, so it stays valid when items are added or removed.
+func paginate[T any](items []T, id func(T) int, pageSize int32, pageToken string) ([]T, string, error) {
+	size := int(pageSize)
+	if size < 0 || size > maxPageSize {
+		return nil, "", i18n.NewError(i18n.PageSizeOutOfRange, maxPageSize)
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+
+	start := 0
+	if pageToken != "" {
+		after, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		for start < len(items) && id(items[start]) <= after {
+			start++
+		}
+	}
+
+	end := min(start+size, len(items))
+	page := items[start:end]
+
+	next := ""
+	if end < len(items) {
+		next = encodePageToken(id(page[len(page)-1]))
+	}
+	return page, next, nil
+}
+
+func encodePageToken(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.Itoa(id)))
+}
+
+func decodePageToken(token string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		if id, ok := strings.CutPrefix(string(raw), pageTokenPrefix); ok {
+			if n, err := strconv.Atoi(id); err == nil {
+				return n, nil
+			}
+		}
+	}
+	return 0, i18n.NewError(i18n.InvalidPageToken)
+}
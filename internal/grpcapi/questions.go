@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	qnav1 "api_service_questions_and_answers/api/qna/v1"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type questionServer struct {
+	qnav1.UnimplementedQuestionServiceServer
+
+	service services.QuestionService
+}
+
+func (s *questionServer) ListQuestions(ctx context.Context, req *qnav1.ListQuestionsRequest) (*qnav1.ListQuestionsResponse, error) {
+	questions, err := s.service.GetAllQuestions()
+	if err != nil {
+		return nil, toStatus(ctx, err, "questions")
+	}
+
+	page, next, err := paginate(questions, func(q *models.Question) int { return q.ID }, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	resp := &qnav1.ListQuestionsResponse{
+		Questions:     make([]*qnav1.Question, 0, len(page)),
+		NextPageToken: next,
+	}
+	for _, question := range page {
+		resp.Questions = append(resp.Questions, toQuestion(question))
+	}
+	return resp, nil
+}
+
+func (s *questionServer) GetQuestion(ctx context.Context, req *qnav1.GetQuestionRequest) (*qnav1.Question, error) {
+	id, err := toID(ctx, req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.service.GetQuestion(id)
+	if err != nil {
+		return nil, toStatus(ctx, err, "question")
+	}
+	return toQuestion(question), nil
+}
+
+func (s *questionServer) CreateQuestion(ctx context.Context, req *qnav1.CreateQuestionRequest) (*qnav1.Question, error) {
+	question := &models.Question{
+		Text:      req.GetText(),
+		CreatedAt: time.Now(),
+	}
+	if req.GetUserId() != "" {
+		userID, err := uuid.Parse(req.GetUserId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, i18n.Message(ctx, i18n.MustBeUUID, "user_id"))
+		}
+		question.UserID = &userID
+	}
+	if err := question.Validate(); err != nil {
+		return nil, invalidArgument(ctx, err)
+	}
+
+	created, err := s.service.CreateQuestion(question)
+	if err != nil {
+		return nil, toStatus(ctx, err, "question")
+	}
+	return toQuestion(created), nil
+}
+
+func (s *questionServer) DeleteQuestion(ctx context.Context, req *qnav1.DeleteQuestionRequest) (*emptypb.Empty, error) {
+	id, err := toID(ctx, req.GetId(), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteQuestion(id); err != nil {
+		return nil, toStatus(ctx, err, "question")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func toQuestion(question *models.Question) *qnav1.Question {
+	result := &qnav1.Question{
+		Id:             int64(question.ID),
+		Text:           question.Text,
+		CreatedAt:      timestamppb.New(question.CreatedAt),
+		AnswerCount:    int32(question.AnswerCount),
+		LastActivityAt: timestamppb.New(question.LastActivityAt),
+	}
+	if question.UserID != nil {
+		result.UserId = question.UserID.String()
+	}
+	return result
+}
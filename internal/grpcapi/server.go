@@ -0,0 +1,38 @@
+// Package grpcapi serves the qna.v1 gRPC services on top of the same
+// services as the REST API.
+package grpcapi
+
+import (
+	qnav1 "api_service_questions_and_answers/api/qna/v1"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/services"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+)
+
+// NewServer returns a gRPC server with the question and answer services
+// registered. Every call is logged and must carry the bearer token.
+// Messages are in the locale named by the accept-language metadata, or in
+// defaultLocale.
+func NewServer(
+	questionService services.QuestionService,
+	answerService services.AnswerService,
+	broadcaster *events.Broadcaster,
+	token string,
+	defaultLocale language.Tag,
+) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogging, unaryLocale(defaultLocale), unaryAuth(token)),
+		grpc.ChainStreamInterceptor(streamLogging, streamLocale(defaultLocale), streamAuth(token)),
+	)
+	qnav1.RegisterQuestionServiceServer(server, &questionServer{
+		service: questionService,
+	})
+	qnav1.RegisterAnswerServiceServer(server, &answerServer{
+		service:         answerService,
+		questionService: questionService,
+		broadcaster:     broadcaster,
+	})
+	return server
+}
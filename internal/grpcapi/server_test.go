@@ -0,0 +1,244 @@
+package grpcapi
+
+import (
+	qnav1 "api_service_questions_and_answers/api/qna/v1"
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testToken = "secret"
+
+type testServer struct {
+	questions qnav1.QuestionServiceClient
+	answers   qnav1.AnswerServiceClient
+	relay     *outbox.Relay
+	users     repositories.UserRepository
+}
+
+// newTestServer serves the API over bufconn on top of in-memory storage.
+// Answer events reach WatchAnswers once the relay has processed them.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	store := memory.NewStore()
+	broadcaster := events.NewBroadcaster(100)
+	relay := outbox.NewRelay(memory.NewOutboxRepository(store), []outbox.Sink{outbox.BusSink(broadcaster)}, nil, config.OutboxConfig{
+		BatchSize:   100,
+		MaxAttempts: 1,
+	})
+	transactor := memory.NewTransactor(store, func() {})
+	questionRepo := memory.NewQuestionRepository(store)
+	userRepo := memory.NewUserRepository(store)
+
+	server := NewServer(
+		services.NewQuestionService(questionRepo, userRepo, transactor, nil),
+		services.NewAnswerService(questionRepo, memory.NewAnswerRepository(store), userRepo, transactor, nil, nil),
+		broadcaster,
+		testToken,
+		language.English,
+	)
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return &testServer{
+		questions: qnav1.NewQuestionServiceClient(conn),
+		answers:   qnav1.NewAnswerServiceClient(conn),
+		relay:     relay,
+		users:     userRepo,
+	}
+}
+
+// registerUser adds an active user to the server's storage.
+func (s *testServer) registerUser(t *testing.T) string {
+	t.Helper()
+
+	user := &models.User{ID: uuid.New(), DisplayName: "Tester", Status: models.UserActive}
+	require.NoError(t, s.users.Create(user))
+	return user.ID.String()
+}
+
+func authorized(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testToken)
+}
+
+func TestAuth_RejectsMissingAndWrongToken(t *testing.T) {
+	s := newTestServer(t)
+
+	_, err := s.questions.ListQuestions(context.Background(), &qnav1.ListQuestionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+	_, err = s.questions.ListQuestions(ctx, &qnav1.ListQuestionsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := s.answers.WatchAnswers(context.Background(), &qnav1.WatchAnswersRequest{QuestionId: 1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestQuestions_CreateGetListDelete(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t)
+
+	var ids []int64
+	for _, text := range []string{"First question", "Second question", "Third question"} {
+		question, err := s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: text})
+		require.NoError(t, err)
+		ids = append(ids, question.GetId())
+	}
+
+	got, err := s.questions.GetQuestion(ctx, &qnav1.GetQuestionRequest{Id: ids[1]})
+	require.NoError(t, err)
+	assert.Equal(t, "Second question", got.GetText())
+
+	first, err := s.questions.ListQuestions(ctx, &qnav1.ListQuestionsRequest{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.GetQuestions(), 2)
+	require.NotEmpty(t, first.GetNextPageToken())
+
+	second, err := s.questions.ListQuestions(ctx, &qnav1.ListQuestionsRequest{PageSize: 2, PageToken: first.GetNextPageToken()})
+	require.NoError(t, err)
+	require.Len(t, second.GetQuestions(), 1)
+	assert.Equal(t, ids[2], second.GetQuestions()[0].GetId())
+	assert.Empty(t, second.GetNextPageToken())
+
+	_, err = s.questions.DeleteQuestion(ctx, &qnav1.DeleteQuestionRequest{Id: ids[0]})
+	require.NoError(t, err)
+
+	_, err = s.questions.GetQuestion(ctx, &qnav1.GetQuestionRequest{Id: ids[0]})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestErrors_MapToStatusCodes(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t)
+
+	_, err := s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: "hi"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.questions.GetQuestion(ctx, &qnav1.GetQuestionRequest{Id: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.questions.ListQuestions(ctx, &qnav1.ListQuestionsRequest{PageToken: "garbage"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.answers.CreateAnswer(ctx, &qnav1.CreateAnswerRequest{QuestionId: 42, UserId: uuid.NewString(), Text: "An answer"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.answers.CreateAnswer(ctx, &qnav1.CreateAnswerRequest{QuestionId: 42, UserId: "nobody", Text: "An answer"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.answers.GetAnswer(ctx, &qnav1.GetAnswerRequest{Id: 7})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = s.answers.ListAnswers(ctx, &qnav1.ListAnswersRequest{QuestionId: 42})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	question, err := s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: "Who may answer?"})
+	require.NoError(t, err)
+	_, err = s.answers.CreateAnswer(ctx, &qnav1.CreateAnswerRequest{QuestionId: question.GetId(), UserId: uuid.NewString(), Text: "An answer"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	suspended := s.registerUser(t)
+	require.NoError(t, s.users.UpdateStatus(uuid.MustParse(suspended), models.UserSuspended))
+	_, err = s.answers.CreateAnswer(ctx, &qnav1.CreateAnswerRequest{QuestionId: question.GetId(), UserId: suspended, Text: "An answer"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{UserId: uuid.NewString(), Text: "Who may ask?"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{UserId: suspended, Text: "Who may ask?"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestErrors_InLocaleOfCall(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t)
+
+	_, err := s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: "hi"})
+	assert.Equal(t, "text must be at least 5 characters", status.Convert(err).Message())
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "accept-language", "ru-RU,ru;q=0.9")
+	_, err = s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: "hi"})
+	assert.Equal(t, "текст должен быть не короче 5 символов", status.Convert(err).Message())
+
+	_, err = s.answers.GetAnswer(ctx, &qnav1.GetAnswerRequest{Id: 7})
+	assert.Equal(t, "ответ не найден", status.Convert(err).Message())
+
+	_, err = s.questions.ListQuestions(ctx, &qnav1.ListQuestionsRequest{PageSize: 101})
+	assert.Equal(t, "page_size должен быть от 0 до 100", status.Convert(err).Message())
+}
+
+func TestWatchAnswers_StreamsCreatedAndDeleted(t *testing.T) {
+	s := newTestServer(t)
+	ctx := authorized(t)
+
+	question, err := s.questions.CreateQuestion(ctx, &qnav1.CreateQuestionRequest{Text: "Watched question"})
+	require.NoError(t, err)
+
+	stream, err := s.answers.WatchAnswers(ctx, &qnav1.WatchAnswersRequest{QuestionId: question.GetId()})
+	require.NoError(t, err)
+	// Headers arrive once the server has subscribed.
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	answer, err := s.answers.CreateAnswer(ctx, &qnav1.CreateAnswerRequest{
+		QuestionId: question.GetId(),
+		UserId:     s.registerUser(t),
+		Text:       "Streamed answer",
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.relay.ProcessPending(ctx))
+
+	_, err = s.answers.DeleteAnswer(ctx, &qnav1.DeleteAnswerRequest{Id: answer.GetId()})
+	require.NoError(t, err)
+	require.NoError(t, s.relay.ProcessPending(ctx))
+
+	created, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, qnav1.AnswerEvent_TYPE_CREATED, created.GetType())
+	assert.Equal(t, answer.GetId(), created.GetAnswer().GetId())
+	assert.Equal(t, "Streamed answer", created.GetAnswer().GetText())
+
+	deleted, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, qnav1.AnswerEvent_TYPE_DELETED, deleted.GetType())
+	assert.Greater(t, deleted.GetId(), created.GetId())
+
+	list, err := s.answers.ListAnswers(ctx, &qnav1.ListAnswersRequest{QuestionId: question.GetId()})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetAnswers())
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"log"
+	"net/http"
+)
+
+type ActivityHandler struct {
+	service services.ActivityService
+}
+
+func NewActivityHandler(service services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{
+		service,
+	}
+}
+
+// GetUserQuestions godoc
+// @Summary Get the questions asked by a user
+// @Description Get one page of a user's questions, newest first by default. The Link header holds the URL of the
+// @Description next page with rel="next" and X-Total-Count the number of questions of the user.
+// @Tags users
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "Cursor from the Link header of the previous page"
+// @Param sort query string false "Order" Enums(newest, oldest) default(newest)
+// @Success 200 {array} models.Question
+// @Header 200 {string} Link "URL of the next page, if any"
+// @Header 200 {integer} X-Total-Count "Number of questions of the user"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/questions [get]
+func (h *ActivityHandler) GetUserQuestions(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query(), repositories.NewestFirst)
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.GetUserQuestions(userID, page)
+	if err != nil {
+		log.Printf("Service error listing questions of user %s: %v", userID, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetQuestions), http.StatusInternalServerError)
+		return
+	}
+
+	setPageHeaders(w, r, list.Total, list.HasMore, list.Questions, questionID)
+	writeJSON(w, http.StatusOK, list.Questions)
+}
+
+// GetUserAnswers godoc
+// @Summary Get the answers written by a user
+// @Description Get one page of a user's answers, newest first by default. The Link header holds the URL of the
+// @Description next page with rel="next" and X-Total-Count the number of answers of the user.
+// @Tags users
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "Cursor from the Link header of the previous page"
+// @Param sort query string false "Order" Enums(newest, oldest) default(newest)
+// @Success 200 {array} models.Answer
+// @Header 200 {string} Link "URL of the next page, if any"
+// @Header 200 {integer} X-Total-Count "Number of answers of the user"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/answers [get]
+func (h *ActivityHandler) GetUserAnswers(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query(), repositories.NewestFirst)
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.GetUserAnswers(userID, page)
+	if err != nil {
+		log.Printf("Service error listing answers of user %s: %v", userID, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetAnswers), http.StatusInternalServerError)
+		return
+	}
+
+	setPageHeaders(w, r, list.Total, list.HasMore, list.Answers, answerID)
+	writeJSON(w, http.StatusOK, list.Answers)
+}
+
+// GetUserStats godoc
+// @Summary Get activity stats of a user
+// @Description Number of questions and answers and the times of the first and last of them; the times are null
+// @Description for a user without activity.
+// @Tags users
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Success 200 {object} services.UserStats
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/stats [get]
+func (h *ActivityHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetUserStats(userID)
+	if err != nil {
+		log.Printf("Service error getting stats of user %s: %v", userID, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetUserStats), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockActivityService struct {
+	mock.Mock
+}
+
+func (m *MockActivityService) GetUserQuestions(userID uuid.UUID, page repositories.Page) (*services.QuestionList, error) {
+	args := m.Called(userID, page)
+	return args.Get(0).(*services.QuestionList), args.Error(1)
+}
+
+func (m *MockActivityService) GetUserAnswers(userID uuid.UUID, page repositories.Page) (*services.AnswerList, error) {
+	args := m.Called(userID, page)
+	return args.Get(0).(*services.AnswerList), args.Error(1)
+}
+
+func (m *MockActivityService) GetUserStats(userID uuid.UUID) (*services.UserStats, error) {
+	args := m.Called(userID)
+	return args.Get(0).(*services.UserStats), args.Error(1)
+}
+
+func TestActivityHandler_GetUserAnswers_NewestFirstByDefault(t *testing.T) {
+	mockService := new(MockActivityService)
+	handler := NewActivityHandler(mockService)
+
+	userID := uuid.New()
+	page := repositories.Page{Limit: 2, Sort: repositories.NewestFirst}
+	mockService.On("GetUserAnswers", userID, page).Return(&services.AnswerList{
+		Answers: []*models.Answer{{ID: 9, UserID: userID}, {ID: 4, UserID: userID}},
+		Total:   3,
+		HasMore: true,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/users/"+userID.String()+"/answers?limit=2", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetUserAnswers(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "3", rr.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</users/`+userID.String()+`/answers?after=`+encodeCursor(4)+`&limit=2>; rel="next"`, rr.Header().Get("Link"))
+
+	var response []models.Answer
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+func TestActivityHandler_GetUserQuestions(t *testing.T) {
+	mockService := new(MockActivityService)
+	handler := NewActivityHandler(mockService)
+
+	userID := uuid.New()
+	page := repositories.Page{Limit: 20, Sort: repositories.OldestFirst}
+	mockService.On("GetUserQuestions", userID, page).Return(&services.QuestionList{
+		Questions: []*models.Question{{ID: 1, UserID: &userID}},
+		Total:     1,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetUserQuestions(rr, httptest.NewRequest("GET", "/users/"+userID.String()+"/questions?sort=oldest", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Link"))
+	assert.Equal(t, "1", rr.Header().Get("X-Total-Count"))
+}
+
+func TestActivityHandler_GetUserStats(t *testing.T) {
+	mockService := new(MockActivityService)
+	handler := NewActivityHandler(mockService)
+
+	userID := uuid.New()
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetUserStats", userID).Return(&services.UserStats{
+		UserID:          userID,
+		Questions:       1,
+		Answers:         2,
+		FirstActivityAt: &first,
+		LastActivityAt:  &first,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetUserStats(rr, httptest.NewRequest("GET", "/users/"+userID.String()+"/stats", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response services.UserStats
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, int64(2), response.Answers)
+	assert.Equal(t, first, *response.FirstActivityAt)
+}
+
+func TestActivityHandler_BadRequests(t *testing.T) {
+	mockService := new(MockActivityService)
+	handler := NewActivityHandler(mockService)
+
+	rr := httptest.NewRecorder()
+	handler.GetUserStats(rr, httptest.NewRequest("GET", "/users/bob/stats", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.GetUserAnswers(rr, httptest.NewRequest("GET", "/users/"+uuid.NewString()+"/answers?sort=top", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "sort must be one of oldest, newest")
+
+	mockService.AssertNotCalled(t, "GetUserStats", mock.Anything)
+	mockService.AssertNotCalled(t, "GetUserAnswers", mock.Anything, mock.Anything)
+}
+
+func TestActivityHandler_ServiceError(t *testing.T) {
+	mockService := new(MockActivityService)
+	handler := NewActivityHandler(mockService)
+
+	mockService.On("GetUserQuestions", mock.Anything, mock.Anything).Return((*services.QuestionList)(nil), errors.New("database down"))
+
+	rr := httptest.NewRecorder()
+	handler.GetUserQuestions(rr, httptest.NewRequest("GET", "/users/"+uuid.NewString()+"/questions", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
 	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
 	"api_service_questions_and_answers/internal/services"
 	"encoding/json"
 	"errors"
@@ -30,6 +32,7 @@ func NewAnswerHandler(service services.AnswerService) *AnswerHandler {
 // @Accept json
 // @Produce json
 // @Param id path int true "Answer ID"
+// @Param format query string false "Text as Markdown, as sanitised HTML or both" Enums(raw, html, both) default(both)
 // @Success 200 {object} models.Answer
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -37,28 +40,91 @@ func NewAnswerHandler(service services.AnswerService) *AnswerHandler {
 // @Router /api/answers/{id} [get]
 func (h *AnswerHandler) GetAnswer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
 	id, err := helpers.ExtractIDFromPath(r)
 	if err != nil {
-		http.Error(w, "Failed to get answer", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetAnswer), http.StatusBadRequest)
+		return
+	}
+
+	format, err := parseTextFormat(r.URL.Query())
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 		return
 	}
 
 	answer, err := h.service.GetAnswer(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			http.Error(w, "Not found", http.StatusBadRequest)
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetAnswer), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(answerJSON(answer, format))
+	if err != nil {
+		log.Printf("Error encoding response: %v", err)
+		return
+	}
+}
+
+// GetQuestionAnswers godoc
+// @Summary Get the answers of a question
+// @Description Get one page of a question's answers. The Link header holds the URL of the next page
+// @Description with rel="next" and X-Total-Count the number of answers of the question.
+// @Description Unknown, repeated and malformed query parameters are rejected with 400.
+// @Tags answers
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "Cursor from the Link header of the previous page"
+// @Param sort query string false "Order" Enums(oldest, newest) default(oldest)
+// @Success 200 {array} models.Answer
+// @Header 200 {string} Link "URL of the next page, if any"
+// @Header 200 {integer} X-Total-Count "Number of answers of the question"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/answers [get]
+func (h *AnswerHandler) GetQuestionAnswers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query(), repositories.OldestFirst)
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.ListAnswers(id, page)
+	if err != nil {
+		if errors.Is(err, services.ErrQuestionNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.QuestionNotFound), http.StatusNotFound)
 			return
 		}
-		http.Error(w, "Failed to get answer", http.StatusBadRequest)
+		log.Printf("Service error listing answers of question %d: %v", id, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetAnswers), http.StatusInternalServerError)
 		return
 	}
+
+	setPageHeaders(w, r, list.Total, list.HasMore, list.Answers, answerID)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(answer)
+	err = json.NewEncoder(w).Encode(list.Answers)
 	if err != nil {
 		log.Printf("Error encoding response: %v", err)
 		return
@@ -67,26 +133,29 @@ func (h *AnswerHandler) GetAnswer(w http.ResponseWriter, r *http.Request) {
 
 // CreateAnswer godoc
 // @Summary Create a new answer for a question
-// @Description Create a new answer for a specific question
+// @Description Create a new answer for a specific question. The user must be registered and not suspended.
+// @Description The content filters reject the text with 400 or save it hidden until a moderator approves it.
 // @Tags answers
 // @Accept json
 // @Produce json
 // @Param question_id path int true "Question ID"
 // @Param answer body models.Answer true "Answer object"
+// @Param Accept-Language header string false "Language of validation messages, en or ru; i18n.default_locale when neither matches"
 // @Success 201 {object} models.Answer
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/questions/{question_id}/answers [post]
 func (h *AnswerHandler) CreateAnswer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
 	id, err := helpers.ExtractIDFromPath(r)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
 		return
 	}
 
@@ -94,12 +163,12 @@ func (h *AnswerHandler) CreateAnswer(w http.ResponseWriter, r *http.Request) {
 
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 		return
 	}
 
@@ -112,11 +181,23 @@ func (h *AnswerHandler) CreateAnswer(w http.ResponseWriter, r *http.Request) {
 	createdAnswer, err := h.service.CreateAnswer(id, answer)
 	if err != nil {
 		if err.Error() == "question not found" {
-			http.Error(w, "Question not found", http.StatusNotFound)
+			http.Error(w, i18n.Message(r.Context(), i18n.QuestionNotFound), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UnknownUser), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UserSuspended), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, services.ErrContentRejected) {
+			http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 			return
 		}
 		log.Printf("Service error creating answer: %v", err)
-		http.Error(w, "Failed to create answer", http.StatusInternalServerError)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToCreateAnswer), http.StatusInternalServerError)
 		return
 	}
 
@@ -143,21 +224,21 @@ func (h *AnswerHandler) CreateAnswer(w http.ResponseWriter, r *http.Request) {
 // @Router /api/answers/{id} [delete]
 func (h *AnswerHandler) DeleteAnswer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
 	id, err := helpers.ExtractIDFromPath(r)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
 		return
 	}
 	err = h.service.DeleteAnswer(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			http.Error(w, "Not found", http.StatusNotFound)
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
 		}
-		http.Error(w, "Failed to deleted answer", http.StatusInternalServerError)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToDeleteAnswer), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
 	"bytes"
 	"encoding/json"
 	"net/http"
@@ -29,6 +31,16 @@ func (m *MockAnswerService) GetAnswer(answerID uint) (*models.Answer, error) {
 	return args.Get(0).(*models.Answer), args.Error(1)
 }
 
+func (m *MockAnswerService) GetAnswersByQuestionIDs(questionIDs []uint) (map[uint][]*models.Answer, error) {
+	args := m.Called(questionIDs)
+	return args.Get(0).(map[uint][]*models.Answer), args.Error(1)
+}
+
+func (m *MockAnswerService) ListAnswers(questionID uint, page repositories.Page) (*services.AnswerList, error) {
+	args := m.Called(questionID, page)
+	return args.Get(0).(*services.AnswerList), args.Error(1)
+}
+
 func (m *MockAnswerService) DeleteAnswer(answerID uint) error {
 	args := m.Called(answerID)
 	return args.Error(0)
@@ -96,3 +108,81 @@ func TestAnswerHandler_CreateAnswer_QuestionNotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
+
+func TestAnswerHandler_GetQuestionAnswers_NextPage(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerHandler(mockService)
+
+	page := repositories.Page{After: 7, Limit: 2, Sort: repositories.NewestFirst}
+	mockService.On("ListAnswers", uint(1), page).Return(&services.AnswerList{
+		Answers: []*models.Answer{{ID: 6, QuestionID: 1}, {ID: 5, QuestionID: 1}},
+		Total:   9,
+		HasMore: true,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/questions/1/answers?limit=2&sort=newest&after="+encodeCursor(7), nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestionAnswers(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "9", rr.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</questions/1/answers?after=`+encodeCursor(5)+`&limit=2&sort=newest>; rel="next"`, rr.Header().Get("Link"))
+
+	var response []models.Answer
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+func TestAnswerHandler_GetQuestionAnswers_LastPage(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerHandler(mockService)
+
+	page := repositories.Page{Limit: 20, Sort: repositories.OldestFirst}
+	mockService.On("ListAnswers", uint(1), page).Return(&services.AnswerList{
+		Answers: []*models.Answer{{ID: 1, QuestionID: 1}},
+		Total:   1,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetQuestionAnswers(rr, httptest.NewRequest("GET", "/questions/1/answers", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, rr.Header().Get("Link"))
+}
+
+func TestAnswerHandler_GetQuestionAnswers_QuestionNotFound(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerHandler(mockService)
+
+	mockService.On("ListAnswers", uint(999), mock.Anything).Return((*services.AnswerList)(nil), services.ErrQuestionNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.GetQuestionAnswers(rr, httptest.NewRequest("GET", "/questions/999/answers", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAnswerHandler_GetQuestionAnswers_RejectsBadParameters(t *testing.T) {
+	tests := map[string]string{
+		"/questions/1/answers?limit=0":         "limit must be an integer between 1 and 100",
+		"/questions/1/answers?after=abc":       "after must be a cursor returned by a previous page",
+		"/questions/1/answers?sort=top":        "sort must be one of oldest, newest",
+		"/questions/1/answers?offset=20":       `unknown query parameter "offset"`,
+		"/questions/1/answers?limit=1&limit=2": `query parameter "limit" must be given once`,
+	}
+
+	for target, message := range tests {
+		t.Run(target, func(t *testing.T) {
+			mockService := new(MockAnswerService)
+			handler := NewAnswerHandler(mockService)
+
+			rr := httptest.NewRecorder()
+			handler.GetQuestionAnswers(rr, httptest.NewRequest("GET", target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), message)
+			mockService.AssertNotCalled(t, "ListAnswers", mock.Anything, mock.Anything)
+		})
+	}
+}
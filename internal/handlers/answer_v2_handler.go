@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AnswerV2Handler struct {
+	service services.AnswerService
+}
+
+func NewAnswerV2Handler(service services.AnswerService) *AnswerV2Handler {
+	return &AnswerV2Handler{
+		service,
+	}
+}
+
+type createAnswerV2Request struct {
+	UserID string `json:"user_id" example:"3f1c2a5e-8f0e-4c4b-9a57-2b1f3f6f9a10"`
+	Text   string `json:"text" example:"Call the cancel function returned by WithCancel."`
+}
+
+// GetAnswer godoc
+// @Summary Get answer by ID
+// @Tags v2
+// @Produce json
+// @Param id path string true "Answer ID"
+// @Param format query string false "Text as Markdown, as sanitised HTML or both" Enums(raw, html, both) default(both)
+// @Success 200 {object} V2Envelope{data=V2Answer}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 404 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/answers/{id} [get]
+func (h *AnswerV2Handler) GetAnswer(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidAnswerID))
+		return
+	}
+	format, err := parseTextFormat(r.URL.Query())
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	answer, err := h.service.GetAnswer(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeV2Error(w, http.StatusNotFound, V2CodeNotFound, i18n.Message(r.Context(), i18n.AnswerNotFound))
+			return
+		}
+		log.Printf("Failed to get answer %d: %v", id, err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToGetAnswer))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, V2Envelope{
+		Data:  v2AnswerJSON(answer, format),
+		Links: answerV2Links(answer),
+	})
+}
+
+// GetQuestionAnswers godoc
+// @Summary Get the answers of a question
+// @Description Get one page of a question's answers; meta.total is the number of answers of the question,
+// @Description meta.next_cursor and links.next point to the next page when there is one.
+// @Description Unknown, repeated and malformed query parameters are rejected with 400.
+// @Tags v2
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "meta.next_cursor of the previous page"
+// @Param sort query string false "Order" Enums(oldest, newest) default(oldest)
+// @Success 200 {object} V2Envelope{data=[]V2Answer}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 404 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions/{id}/answers [get]
+func (h *AnswerV2Handler) GetQuestionAnswers(w http.ResponseWriter, r *http.Request) {
+	questionID, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidQuestionID))
+		return
+	}
+
+	page, err := parsePage(r.URL.Query(), repositories.OldestFirst)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	list, err := h.service.ListAnswers(questionID, page)
+	if err != nil {
+		if errors.Is(err, services.ErrQuestionNotFound) {
+			writeV2Error(w, http.StatusNotFound, V2CodeNotFound, i18n.Message(r.Context(), i18n.V2QuestionNotFound))
+			return
+		}
+		log.Printf("Failed to list answers of question %d: %v", questionID, err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToGetAnswers))
+		return
+	}
+
+	data := make([]V2Answer, 0, len(list.Answers))
+	for _, answer := range list.Answers {
+		data = append(data, toV2Answer(answer))
+	}
+
+	meta := map[string]any{"count": len(data), "total": list.Total}
+	links := map[string]string{"self": r.URL.RequestURI()}
+	if list.HasMore {
+		last := list.Answers[len(list.Answers)-1].ID
+		meta["next_cursor"] = encodeCursor(last)
+		links["next"] = nextPageURL(r.URL, last)
+	}
+
+	writeJSON(w, http.StatusOK, V2Envelope{
+		Data:  data,
+		Meta:  meta,
+		Links: links,
+	})
+}
+
+// CreateAnswer godoc
+// @Summary Create a new answer for a question
+// @Description The user must be registered and not suspended.
+// @Description The content filters reject the text with 422 content_rejected or save it hidden until a moderator approves it.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param answer body createAnswerV2Request true "Answer"
+// @Param Accept-Language header string false "Language of validation messages, en or ru; i18n.default_locale when neither matches"
+// @Success 201 {object} V2Envelope{data=V2Answer}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 403 {object} V2ErrorBody
+// @Failure 404 {object} V2ErrorBody
+// @Failure 422 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions/{id}/answers [post]
+func (h *AnswerV2Handler) CreateAnswer(w http.ResponseWriter, r *http.Request) {
+	questionID, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidQuestionID))
+		return
+	}
+
+	var req createAnswerV2Request
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.V2InvalidRequestBody))
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Message(r.Context(), i18n.MustBeUUID, "user_id"))
+		return
+	}
+
+	answer := &models.Answer{
+		UserID:    userID,
+		Text:      req.Text,
+		CreatedAt: time.Now(),
+	}
+	if err := answer.Validate(); err != nil {
+		writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	created, err := h.service.CreateAnswer(questionID, answer)
+	if err != nil {
+		if errors.Is(err, services.ErrQuestionNotFound) {
+			writeV2Error(w, http.StatusNotFound, V2CodeNotFound, i18n.Message(r.Context(), i18n.V2QuestionNotFound))
+			return
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Message(r.Context(), i18n.V2UnknownUser))
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			writeV2Error(w, http.StatusForbidden, V2CodeForbidden, i18n.Message(r.Context(), i18n.V2UserSuspended))
+			return
+		}
+		if errors.Is(err, services.ErrContentRejected) {
+			writeV2Error(w, http.StatusUnprocessableEntity, V2CodeContentRejected, i18n.Localize(r.Context(), err))
+			return
+		}
+		log.Printf("Failed to create answer: %v", err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToCreateAnswer))
+		return
+	}
+
+	links := answerV2Links(created)
+	w.Header().Set("Location", links["self"])
+	writeJSON(w, http.StatusCreated, V2Envelope{
+		Data:  toV2Answer(created),
+		Links: links,
+	})
+}
+
+// DeleteAnswer godoc
+// @Summary Delete an answer
+// @Description Deleting a missing answer succeeds.
+// @Tags v2
+// @Param id path string true "Answer ID"
+// @Success 204
+// @Failure 400 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/answers/{id} [delete]
+func (h *AnswerV2Handler) DeleteAnswer(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidAnswerID))
+		return
+	}
+
+	err = h.service.DeleteAnswer(id)
+	if err != nil {
+		log.Printf("Failed to delete answer %d: %v", id, err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToDeleteAnswer))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func answerV2Links(answer *models.Answer) map[string]string {
+	return map[string]string{
+		"self":     v2AnswerLink(answer.ID),
+		"question": v2QuestionLink(int(answer.QuestionID)),
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// multipartOverhead is the room left in an upload request for the
+// multipart boundaries and headers around the file.
+const multipartOverhead = 64 << 10
+
+// inlineTypes are shown by browsers in place; other files are downloaded.
+var inlineTypes = []string{"application/pdf", "text/plain"}
+
+type AttachmentHandler struct {
+	service services.AttachmentService
+	maxSize int64
+}
+
+// NewAttachmentHandler refuses upload requests larger than maxSize, the
+// file size limit of service, before reading them whole.
+func NewAttachmentHandler(service services.AttachmentService, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		service,
+		maxSize,
+	}
+}
+
+// UploadQuestionAttachment godoc
+// @Summary Attach a file to a question
+// @Description Upload a file in the "file" field of a multipart form. Its type is sniffed from its content and
+// @Description must be one of attachments.allowed_types; its size must not exceed attachments.max_size.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param file formData file true "File"
+// @Success 201 {object} models.Attachment
+// @Header 201 {string} Location "URL of the file"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/attachments [post]
+func (h *AttachmentHandler) UploadQuestionAttachment(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, models.ContentQuestion)
+}
+
+// UploadAnswerAttachment godoc
+// @Summary Attach a file to an answer
+// @Description Upload a file in the "file" field of a multipart form. Its type is sniffed from its content and
+// @Description must be one of attachments.allowed_types; its size must not exceed attachments.max_size.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Answer ID"
+// @Param file formData file true "File"
+// @Success 201 {object} models.Attachment
+// @Header 201 {string} Location "URL of the file"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/answers/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAnswerAttachment(w http.ResponseWriter, r *http.Request) {
+	h.upload(w, r, models.ContentAnswer)
+}
+
+func (h *AttachmentHandler) upload(w http.ResponseWriter, r *http.Request, contentType string) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.MultipartRequired), http.StatusBadRequest)
+		return
+	}
+
+	// The file is streamed to the blob store instead of being parsed into
+	// memory or temporary files first.
+	var attachment *models.Attachment
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			http.Error(w, i18n.Message(r.Context(), i18n.FileRequired), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			uploadError(w, r, contentType, id, err)
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		attachment, err = h.service.Attach(contentType, id, part.FileName(), part)
+		if err != nil {
+			uploadError(w, r, contentType, id, err)
+			return
+		}
+		break
+	}
+
+	attachment.URL = attachmentURL(attachment)
+	w.Header().Set("Location", attachment.URL)
+	writeJSON(w, http.StatusCreated, attachment)
+}
+
+func uploadError(w http.ResponseWriter, r *http.Request, contentType string, id uint, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.Is(err, services.ErrAttachmentTooLarge) || errors.As(err, &tooLarge) {
+		http.Error(w, i18n.Message(r.Context(), i18n.FileTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if errors.Is(err, services.ErrAttachmentType) {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusUnsupportedMediaType)
+		return
+	}
+	if errors.Is(err, services.ErrContentNotFound) {
+		http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+		return
+	}
+	log.Printf("Service error attaching a file to %s %d: %v", contentType, id, err)
+	http.Error(w, i18n.Message(r.Context(), i18n.FailedToUploadFile), http.StatusInternalServerError)
+}
+
+// GetAttachment godoc
+// @Summary Download an attachment
+// @Description Serve the file of an attachment with the type sniffed when it was uploaded. Range requests are
+// @Description supported and the ETag is the SHA-256 of the file. Attachments of hidden content are not found.
+// @Tags attachments
+// @Produce octet-stream
+// @Param id path int true "Attachment ID"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Success 200 {file} file
+// @Success 206 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 416 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/attachments/{id} [get]
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	attachment, file, err := h.service.OpenAttachment(id)
+	if err != nil {
+		if errors.Is(err, services.ErrAttachmentNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		log.Printf("Service error opening attachment %d: %v", id, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetAttachment), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	disposition := "attachment"
+	mediaType, _, _ := mime.ParseMediaType(attachment.ContentType)
+	if strings.HasPrefix(mediaType, "image/") || slices.Contains(inlineTypes, mediaType) {
+		disposition = "inline"
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", `"`+attachment.SHA256+`"`)
+	http.ServeContent(w, r, attachment.Filename, attachment.CreatedAt, file)
+}
+
+func attachmentURL(attachment *models.Attachment) string {
+	return fmt.Sprintf("/api/attachments/%d", attachment.ID)
+}
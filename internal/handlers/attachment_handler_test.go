@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) Attach(contentType string, contentID uint, filename string, body io.Reader) (*models.Attachment, error) {
+	args := m.Called(contentType, contentID, filename, body)
+	return args.Get(0).(*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) OpenAttachment(id uint) (*models.Attachment, io.ReadSeekCloser, error) {
+	args := m.Called(id)
+	file, _ := args.Get(1).(io.ReadSeekCloser)
+	return args.Get(0).(*models.Attachment), file, args.Error(2)
+}
+
+// newUploadRequest returns a multipart request with a form field and a
+// file in the "file" field.
+func newUploadRequest(t *testing.T, target, filename, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("comment", "before the file"))
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = io.WriteString(part, content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest("POST", target, &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestAttachmentHandler_UploadAnswerAttachment(t *testing.T) {
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService, 1<<20)
+
+	answerID := uint(4)
+	mockService.On("Attach", models.ContentAnswer, uint(4), "notes.txt", mock.Anything).
+		Run(func(args mock.Arguments) {
+			content, err := io.ReadAll(args.Get(3).(io.Reader))
+			require.NoError(t, err)
+			assert.Equal(t, "some notes", string(content))
+		}).
+		Return(&models.Attachment{ID: 7, QuestionID: 1, AnswerID: &answerID, Filename: "notes.txt", ContentType: "text/plain; charset=utf-8"}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.UploadAnswerAttachment(rr, newUploadRequest(t, "/answers/4/attachments", "notes.txt", "some notes"))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "/api/attachments/7", rr.Header().Get("Location"))
+	var response models.Attachment
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "/api/attachments/7", response.URL)
+	assert.NotContains(t, rr.Body.String(), "blob_key")
+	mockService.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_UploadQuestionAttachment_Errors(t *testing.T) {
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService, 1<<20)
+
+	mockService.On("Attach", models.ContentQuestion, uint(1), "archive.zip", mock.Anything).
+		Return((*models.Attachment)(nil), fmt.Errorf("%w: application/zip", services.ErrAttachmentType))
+	mockService.On("Attach", models.ContentQuestion, uint(1), "huge.txt", mock.Anything).
+		Return((*models.Attachment)(nil), services.ErrAttachmentTooLarge)
+	mockService.On("Attach", models.ContentQuestion, uint(2), "cat.png", mock.Anything).
+		Return((*models.Attachment)(nil), services.ErrContentNotFound)
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		status  int
+	}{
+		{"not multipart", httptest.NewRequest("POST", "/questions/1/attachments", strings.NewReader("{}")), http.StatusBadRequest},
+		{"type not allowed", newUploadRequest(t, "/questions/1/attachments", "archive.zip", "PK"), http.StatusUnsupportedMediaType},
+		{"too large", newUploadRequest(t, "/questions/1/attachments", "huge.txt", "text"), http.StatusRequestEntityTooLarge},
+		{"hidden question", newUploadRequest(t, "/questions/2/attachments", "cat.png", "png"), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.UploadQuestionAttachment(rr, tt.request)
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+type testFile struct {
+	*strings.Reader
+}
+
+func (testFile) Close() error {
+	return nil
+}
+
+func TestAttachmentHandler_GetAttachment(t *testing.T) {
+	mockService := new(MockAttachmentService)
+	handler := NewAttachmentHandler(mockService, 1<<20)
+
+	attachment := &models.Attachment{ID: 7, Filename: "отчёт.pdf", ContentType: "application/pdf", SHA256: "abc",
+		CreatedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)}
+	mockService.On("OpenAttachment", uint(7)).Return(attachment, testFile{strings.NewReader("%PDF-1.7 content")}, nil)
+	mockService.On("OpenAttachment", uint(8)).Return((*models.Attachment)(nil), nil, services.ErrAttachmentNotFound)
+
+	rr := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/attachments/7", nil)
+	request.Header.Set("Range", "bytes=0-3")
+	handler.GetAttachment(rr, request)
+
+	assert.Equal(t, http.StatusPartialContent, rr.Code)
+	assert.Equal(t, "%PDF", rr.Body.String())
+	assert.Equal(t, "bytes 0-3/16", rr.Header().Get("Content-Range"))
+	assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, `"abc"`, rr.Header().Get("ETag"))
+	assert.Equal(t, "inline; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.pdf", rr.Header().Get("Content-Disposition"))
+
+	rr = httptest.NewRecorder()
+	request = httptest.NewRequest("GET", "/attachments/7", nil)
+	request.Header.Set("If-None-Match", `"abc"`)
+	handler.GetAttachment(rr, request)
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.GetAttachment(rr, httptest.NewRequest("GET", "/attachments/8", nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
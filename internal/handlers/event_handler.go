@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EventHandler struct {
+	questionService services.QuestionService
+	broadcaster     *events.Broadcaster
+	heartbeat       time.Duration
+}
+
+func NewEventHandler(
+	questionService services.QuestionService,
+	broadcaster *events.Broadcaster,
+	heartbeat time.Duration,
+) *EventHandler {
+	return &EventHandler{
+		questionService,
+		broadcaster,
+		heartbeat,
+	}
+}
+
+// StreamQuestionEvents godoc
+// @Summary Stream answer events of a question
+// @Description Server-Sent Events stream with answer.created and answer.deleted events.
+// @Description Send the Last-Event-ID header to resume after a reconnect; events still in the replay buffer are delivered first.
+// @Tags events
+// @Produce text/event-stream
+// @Param id path int true "Question ID"
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Success 200 {string} string "text/event-stream"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/events [get]
+func (h *EventHandler) StreamQuestionEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var lastEventID uint64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		lastEventID, err = strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			http.Error(w, i18n.Message(r.Context(), i18n.InvalidLastEventID), http.StatusBadRequest)
+			return
+		}
+	}
+
+	_, err = h.questionService.GetQuestion(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetQuestion), http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.Message(r.Context(), i18n.StreamingUnsupported), http.StatusInternalServerError)
+		return
+	}
+
+	sub := h.broadcaster.Subscribe(id, lastEventID)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-sub.C:
+			if !ok {
+				// Dropped for falling behind; the client resumes with Last-Event-ID.
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				log.Printf("Error writing event: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event events.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func readSSEEvent(t *testing.T, reader *bufio.Reader) []string {
+	t.Helper()
+
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestEventHandler_StreamsAndReplays(t *testing.T) {
+	mockService := new(MockQuestionService)
+	mockService.On("GetQuestion", uint(1)).Return(&models.Question{ID: 1}, nil)
+
+	broadcaster := events.NewBroadcaster(10)
+	broadcaster.Publish(events.Event{Type: events.AnswerCreated, QuestionID: 1, Data: models.Answer{ID: 1}})
+	broadcaster.Publish(events.Event{Type: events.AnswerCreated, QuestionID: 1, Data: models.Answer{ID: 2}})
+
+	handler := NewEventHandler(mockService, broadcaster, time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(handler.StreamQuestionEvents))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/questions/1/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, []string{"id: 2", "event: answer.created", `data: {"id":2,"question_id":0,"user_id":"00000000-0000-0000-0000-000000000000","text":"","created_at":"0001-01-01T00:00:00Z"}`}, readSSEEvent(t, reader))
+
+	broadcaster.Publish(events.Event{Type: events.AnswerDeleted, QuestionID: 1, Data: models.Answer{ID: 2}})
+	lines := readSSEEvent(t, reader)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "id: 3", lines[0])
+	assert.Equal(t, "event: answer.deleted", lines[1])
+
+	cancel()
+	assert.Eventually(t, func() bool { return broadcaster.Subscribers() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestEventHandler_Heartbeat(t *testing.T) {
+	mockService := new(MockQuestionService)
+	mockService.On("GetQuestion", uint(1)).Return(&models.Question{ID: 1}, nil)
+
+	handler := NewEventHandler(mockService, events.NewBroadcaster(10), 10*time.Millisecond)
+	server := httptest.NewServer(http.HandlerFunc(handler.StreamQuestionEvents))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/questions/1/events", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, []string{": heartbeat"}, readSSEEvent(t, bufio.NewReader(resp.Body)))
+}
+
+func TestEventHandler_QuestionNotFound(t *testing.T) {
+	mockService := new(MockQuestionService)
+	mockService.On("GetQuestion", uint(999)).Return(&models.Question{}, gorm.ErrRecordNotFound)
+
+	handler := NewEventHandler(mockService, events.NewBroadcaster(10), time.Hour)
+
+	req := httptest.NewRequest("GET", "/questions/999/events", nil)
+	rr := httptest.NewRecorder()
+
+	handler.StreamQuestionEvents(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
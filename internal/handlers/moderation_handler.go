@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ModerationHandler struct {
+	service services.ModerationService
+}
+
+func NewModerationHandler(service services.ModerationService) *ModerationHandler {
+	return &ModerationHandler{
+		service,
+	}
+}
+
+type flagRequest struct {
+	UserID uuid.UUID `json:"user_id" example:"0b7c2f5e-6d0a-4f43-9a57-2f1f3c5d8e21"`
+	Reason string    `json:"reason" example:"spam"`
+}
+
+type resolveCaseRequest struct {
+	Action      string    `json:"action" example:"approve"`
+	ModeratorID uuid.UUID `json:"moderator_id" example:"0b7c2f5e-6d0a-4f43-9a57-2f1f3c5d8e21"`
+}
+
+// FlagQuestion godoc
+// @Summary Flag a question
+// @Description Report a question as spam, abusive, off topic or other. Once its open flags reach
+// @Description moderation.flag_threshold the question is hidden until a moderator decides.
+// @Description The user must be registered and not suspended, and can flag a question once per moderation case.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path int true "Question ID"
+// @Param flag body flagRequest true "Flag"
+// @Success 201 {object} models.Flag
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/flags [post]
+func (h *ModerationHandler) FlagQuestion(w http.ResponseWriter, r *http.Request) {
+	h.flag(w, r, models.ContentQuestion)
+}
+
+// FlagAnswer godoc
+// @Summary Flag an answer
+// @Description Report an answer as spam, abusive, off topic or other. Once its open flags reach
+// @Description moderation.flag_threshold the answer is hidden until a moderator decides.
+// @Description The user must be registered and not suspended, and can flag an answer once per moderation case.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path int true "Answer ID"
+// @Param flag body flagRequest true "Flag"
+// @Success 201 {object} models.Flag
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/answers/{id}/flags [post]
+func (h *ModerationHandler) FlagAnswer(w http.ResponseWriter, r *http.Request) {
+	h.flag(w, r, models.ContentAnswer)
+}
+
+// flagFailures holds the message of a failed flag of each content type.
+var flagFailures = map[string]string{
+	models.ContentQuestion: i18n.FailedToFlagQuestion,
+	models.ContentAnswer:   i18n.FailedToFlagAnswer,
+}
+
+func (h *ModerationHandler) flag(w http.ResponseWriter, r *http.Request, contentType string) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var req flagRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+	if req.UserID == uuid.Nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.UserIDRequired), http.StatusBadRequest)
+		return
+	}
+	if !models.ValidFlagReason(req.Reason) {
+		http.Error(w, i18n.Message(r.Context(), i18n.MustBeOneOf, "reason", strings.Join(models.FlagReasons, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	flag, err := h.service.FlagContent(contentType, id, &models.Flag{UserID: req.UserID, Reason: req.Reason})
+	if err != nil {
+		if errors.Is(err, services.ErrContentNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UnknownUser), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UserSuspended), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, services.ErrAlreadyFlagged) {
+			http.Error(w, i18n.Message(r.Context(), i18n.AlreadyFlagged), http.StatusConflict)
+			return
+		}
+		log.Printf("Service error flagging %s %d: %v", contentType, id, err)
+		http.Error(w, i18n.Message(r.Context(), flagFailures[contentType]), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, flag)
+}
+
+// GetQueue godoc
+// @Summary Get the moderation queue
+// @Description Get one page of the open moderation cases, oldest first by default, with their flags and the
+// @Description flagged question or answer, which is missing once deleted. The Link header holds the URL of the
+// @Description next page with rel="next" and X-Total-Count the number of open cases.
+// @Tags moderation
+// @Produce json
+// @Security AdminToken
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "Cursor from the Link header of the previous page"
+// @Param sort query string false "Order" Enums(oldest, newest) default(oldest)
+// @Success 200 {array} models.ModerationCase
+// @Header 200 {string} Link "URL of the next page, if any"
+// @Header 200 {integer} X-Total-Count "Number of open cases"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/moderation/queue [get]
+func (h *ModerationHandler) GetQueue(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePage(r.URL.Query(), repositories.OldestFirst)
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	queue, err := h.service.GetQueue(page)
+	if err != nil {
+		log.Printf("Service error getting moderation queue: %v", err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetModerationQueue), http.StatusInternalServerError)
+		return
+	}
+
+	setPageHeaders(w, r, queue.Total, queue.HasMore, queue.Cases, caseID)
+	writeJSON(w, http.StatusOK, queue.Cases)
+}
+
+// ResolveCase godoc
+// @Summary Resolve a moderation case
+// @Description Approve the content, which shows it again and keeps later flags from hiding it, remove it,
+// @Description which deletes it, or dismiss the flags, which shows it again. The moderator must be a registered,
+// @Description active user and is recorded on the case with the time.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Case ID"
+// @Param decision body resolveCaseRequest true "Action: approve, remove or dismiss"
+// @Success 200 {object} models.ModerationCase
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/moderation/cases/{id} [post]
+func (h *ModerationHandler) ResolveCase(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var req resolveCaseRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+	if _, ok := models.ModerationActions[req.Action]; !ok {
+		actions := slices.Sorted(maps.Keys(models.ModerationActions))
+		http.Error(w, i18n.Message(r.Context(), i18n.MustBeOneOf, "action", strings.Join(actions, ", ")), http.StatusBadRequest)
+		return
+	}
+	if req.ModeratorID == uuid.Nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.ModeratorIDRequired), http.StatusBadRequest)
+		return
+	}
+
+	resolved, err := h.service.ResolveCase(id, req.Action, req.ModeratorID)
+	if err != nil {
+		if errors.Is(err, services.ErrCaseNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, services.ErrCaseResolved) {
+			http.Error(w, i18n.Message(r.Context(), i18n.CaseResolved), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UnknownModerator), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			http.Error(w, i18n.Message(r.Context(), i18n.ModeratorSuspended), http.StatusForbidden)
+			return
+		}
+		log.Printf("Service error resolving moderation case %d: %v", id, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToResolveCase), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resolved)
+}
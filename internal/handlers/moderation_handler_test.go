@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockModerationService struct {
+	mock.Mock
+}
+
+func (m *MockModerationService) FlagContent(contentType string, contentID uint, request *models.Flag) (*models.Flag, error) {
+	args := m.Called(contentType, contentID, request)
+	return args.Get(0).(*models.Flag), args.Error(1)
+}
+
+func (m *MockModerationService) GetQueue(page repositories.Page) (*services.ModerationQueue, error) {
+	args := m.Called(page)
+	return args.Get(0).(*services.ModerationQueue), args.Error(1)
+}
+
+func (m *MockModerationService) ResolveCase(id uint, action string, moderatorID uuid.UUID) (*models.ModerationCase, error) {
+	args := m.Called(id, action, moderatorID)
+	return args.Get(0).(*models.ModerationCase), args.Error(1)
+}
+
+func TestModerationHandler_FlagAnswer(t *testing.T) {
+	mockService := new(MockModerationService)
+	handler := NewModerationHandler(mockService)
+
+	userID := uuid.New()
+	request := &models.Flag{UserID: userID, Reason: models.FlagSpam}
+	mockService.On("FlagContent", models.ContentAnswer, uint(4), request).
+		Return(&models.Flag{ID: 1, CaseID: 2, UserID: userID, Reason: models.FlagSpam}, nil)
+
+	body := `{"user_id": "` + userID.String() + `", "reason": "spam"}`
+	rr := httptest.NewRecorder()
+	handler.FlagAnswer(rr, httptest.NewRequest("POST", "/answers/4/flags", bytes.NewBufferString(body)))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response models.Flag
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.CaseID)
+}
+
+func TestModerationHandler_FlagQuestion_Errors(t *testing.T) {
+	mockService := new(MockModerationService)
+	handler := NewModerationHandler(mockService)
+
+	flagged, hidden := uuid.New(), uuid.New()
+	mockService.On("FlagContent", models.ContentQuestion, uint(1), &models.Flag{UserID: flagged, Reason: models.FlagSpam}).
+		Return((*models.Flag)(nil), services.ErrAlreadyFlagged)
+	mockService.On("FlagContent", models.ContentQuestion, uint(1), &models.Flag{UserID: hidden, Reason: models.FlagSpam}).
+		Return((*models.Flag)(nil), services.ErrContentNotFound)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"unknown reason", `{"user_id": "` + flagged.String() + `", "reason": "boring"}`, http.StatusBadRequest},
+		{"missing user", `{"reason": "spam"}`, http.StatusBadRequest},
+		{"already flagged", `{"user_id": "` + flagged.String() + `", "reason": "spam"}`, http.StatusConflict},
+		{"hidden question", `{"user_id": "` + hidden.String() + `", "reason": "spam"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.FlagQuestion(rr, httptest.NewRequest("POST", "/questions/1/flags", bytes.NewBufferString(tt.body)))
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestModerationHandler_GetQueue(t *testing.T) {
+	mockService := new(MockModerationService)
+	handler := NewModerationHandler(mockService)
+
+	page := repositories.Page{Limit: 1, Sort: repositories.OldestFirst}
+	mockService.On("GetQueue", page).Return(&services.ModerationQueue{
+		Cases:   []*models.ModerationCase{{ID: 3, ContentType: models.ContentQuestion, ContentID: 1, Status: models.CaseOpen, FlagCount: 2}},
+		Total:   2,
+		HasMore: true,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetQueue(rr, httptest.NewRequest("GET", "/moderation/queue?limit=1", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</moderation/queue?after=`+encodeCursor(3)+`&limit=1>; rel="next"`, rr.Header().Get("Link"))
+}
+
+func TestModerationHandler_ResolveCase(t *testing.T) {
+	mockService := new(MockModerationService)
+	handler := NewModerationHandler(mockService)
+
+	moderator := uuid.New()
+	mockService.On("ResolveCase", uint(3), "remove", moderator).
+		Return(&models.ModerationCase{ID: 3, Status: models.CaseRemoved, ModeratorID: &moderator}, nil)
+	mockService.On("ResolveCase", uint(4), "remove", moderator).
+		Return((*models.ModerationCase)(nil), services.ErrCaseResolved)
+
+	body := `{"action": "remove", "moderator_id": "` + moderator.String() + `"}`
+	rr := httptest.NewRecorder()
+	handler.ResolveCase(rr, httptest.NewRequest("POST", "/cases/3", bytes.NewBufferString(body)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response models.ModerationCase
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, models.CaseRemoved, response.Status)
+
+	rr = httptest.NewRecorder()
+	handler.ResolveCase(rr, httptest.NewRequest("POST", "/cases/4", bytes.NewBufferString(body)))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ResolveCase(rr, httptest.NewRequest("POST", "/cases/3", bytes.NewBufferString(`{"action": "ban", "moderator_id": "`+moderator.String()+`"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNumberOfCalls(t, "ResolveCase", 2)
+}
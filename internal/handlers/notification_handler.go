@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultInboxLimit = 50
+	maxInboxLimit     = 100
+)
+
+type NotificationHandler struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler(service services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		service,
+	}
+}
+
+type followRequest struct {
+	UserID uuid.UUID `json:"user_id" example:"6f1d2c3b-1111-4222-8333-944445555666"`
+}
+
+// FollowQuestion godoc
+// @Summary Follow a question
+// @Description Notify the user about new answers to the question. Following twice is a no-op.
+// @Tags notifications
+// @Accept json
+// @Param id path int true "Question ID"
+// @Param follow body followRequest true "Follower"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/follow [post]
+func (h *NotificationHandler) FollowQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, userID, ok := h.parseFollow(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.Follow(questionID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.QuestionNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToFollowQuestion), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnfollowQuestion godoc
+// @Summary Unfollow a question
+// @Tags notifications
+// @Accept json
+// @Param id path int true "Question ID"
+// @Param follow body followRequest true "Follower"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/questions/{id}/follow [delete]
+func (h *NotificationHandler) UnfollowQuestion(w http.ResponseWriter, r *http.Request) {
+	questionID, userID, ok := h.parseFollow(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.Unfollow(questionID, userID)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToUnfollowQuestion), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) parseFollow(w http.ResponseWriter, r *http.Request) (uint, uuid.UUID, bool) {
+	questionID, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return 0, uuid.Nil, false
+	}
+
+	var req followRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.UserID == uuid.Nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.UserIDMissing), http.StatusBadRequest)
+		return 0, uuid.Nil, false
+	}
+
+	return questionID, req.UserID, true
+}
+
+// GetNotifications godoc
+// @Summary Get the notification inbox of a user
+// @Description Newest notifications first, with the number of unread ones
+// @Tags notifications
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Maximum number of notifications (1-100, default 50)"
+// @Success 200 {object} services.Inbox
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/notifications [get]
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	limit := defaultInboxLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxInboxLimit {
+			http.Error(w, i18n.Message(r.Context(), i18n.InboxLimitOutOfRange, "limit", maxInboxLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	inbox, err := h.service.GetInbox(userID, unreadOnly, limit)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetNotifications), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, inbox)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Param uuid path string true "User ID"
+// @Param id path int true "Notification ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.MarkRead(userID, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToMarkNotificationRead), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications of a user as read
+// @Tags notifications
+// @Param uuid path string true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/notifications/read [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.MarkAllRead(userID)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToMarkNotificationsRead), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) Publish(event events.Event) {
+	m.Called(event)
+}
+
+func (m *MockNotificationService) Follow(questionID uint, userID uuid.UUID) error {
+	args := m.Called(questionID, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Unfollow(questionID uint, userID uuid.UUID) error {
+	args := m.Called(questionID, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetInbox(userID uuid.UUID, unreadOnly bool, limit int) (*services.Inbox, error) {
+	args := m.Called(userID, unreadOnly, limit)
+	return args.Get(0).(*services.Inbox), args.Error(1)
+}
+
+func (m *MockNotificationService) MarkRead(userID uuid.UUID, id uint) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) MarkAllRead(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func newNotificationRouter(handler *NotificationHandler) http.Handler {
+	r := chi.NewRouter()
+	r.Post("/questions/{id}/follow", handler.FollowQuestion)
+	r.Get("/users/{uuid}/notifications", handler.GetNotifications)
+	r.Post("/users/{uuid}/notifications/{id}/read", handler.MarkNotificationRead)
+	return r
+}
+
+func TestNotificationHandler_FollowQuestion(t *testing.T) {
+	mockService := new(MockNotificationService)
+	router := newNotificationRouter(NewNotificationHandler(mockService))
+
+	userID := uuid.New()
+	mockService.On("Follow", uint(1), userID).Return(nil)
+	mockService.On("Follow", uint(2), userID).Return(gorm.ErrRecordNotFound)
+
+	for _, tc := range []struct {
+		path   string
+		body   any
+		status int
+	}{
+		{"/questions/1/follow", map[string]any{"user_id": userID}, http.StatusNoContent},
+		{"/questions/2/follow", map[string]any{"user_id": userID}, http.StatusNotFound},
+		{"/questions/1/follow", map[string]any{}, http.StatusBadRequest},
+	} {
+		body, _ := json.Marshal(tc.body)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("POST", tc.path, bytes.NewBuffer(body)))
+		assert.Equal(t, tc.status, rr.Code, tc.path)
+	}
+}
+
+func TestNotificationHandler_GetNotifications(t *testing.T) {
+	mockService := new(MockNotificationService)
+	router := newNotificationRouter(NewNotificationHandler(mockService))
+
+	userID := uuid.New()
+	inbox := &services.Inbox{Unread: 1, Notifications: []*models.Notification{{ID: 3, UserID: userID, Type: models.NotificationNewAnswer, QuestionID: 1, AnswerID: 2}}}
+	mockService.On("GetInbox", userID, true, 10).Return(inbox, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/users/"+userID.String()+"/notifications?unread=true&limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response services.Inbox
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, int64(1), response.Unread)
+	require.Len(t, response.Notifications, 1)
+	assert.Equal(t, uint(2), response.Notifications[0].AnswerID)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/users/not-a-uuid/notifications", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestNotificationHandler_MarkNotificationRead_NotFound(t *testing.T) {
+	mockService := new(MockNotificationService)
+	router := newNotificationRouter(NewNotificationHandler(mockService))
+
+	userID := uuid.New()
+	mockService.On("MarkRead", userID, uint(5)).Return(gorm.ErrRecordNotFound)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/users/"+userID.String()+"/notifications/5/read", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+	cursorPrefix     = "after:"
+)
+
+var pageParams = []string{"limit", "after", "sort"}
+
+// parsePage builds a page from the limit, after and sort parameters of the
+// URL query. Unknown, repeated and malformed parameters are all reported
+// together, joined *i18n.Error values.
+func parsePage(values url.Values, defaultSort repositories.PageSort) (repositories.Page, error) {
+	errs := checkQueryParams(values, pageParams)
+	page := repositories.Page{Limit: defaultPageLimit, Sort: defaultSort}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := parseLimit("limit", value)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		page.Limit = limit
+	}
+
+	if value := values.Get("after"); value != "" {
+		after, err := decodeCursor(value)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		page.After = after
+	}
+
+	if value := values.Get("sort"); value != "" {
+		page.Sort = repositories.PageSort(value)
+		if !slices.Contains(repositories.PageSorts, page.Sort) {
+			errs = append(errs, i18n.NewError(i18n.MustBeOneOf, "sort", joinSorts(repositories.PageSorts)))
+		}
+	}
+
+	if len(errs) > 0 {
+		return repositories.Page{}, errors.Join(errs...)
+	}
+	return page, nil
+}
+
+func parseLimit(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > maxPageLimit {
+		return 0, i18n.NewError(i18n.LimitOutOfRange, name, maxPageLimit)
+	}
+	return n, nil
+}
+
+// encodeCursor returns the opaque cursor of the page that follows the
+// record with the given id.
+func encodeCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(id)))
+}
+
+func decodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		if id, ok := strings.CutPrefix(string(raw), cursorPrefix); ok {
+			if n, err := strconv.ParseUint(id, 10, 0); err == nil && n > 0 {
+				return uint(n), nil
+			}
+		}
+	}
+	return 0, i18n.NewError(i18n.InvalidCursor)
+}
+
+// nextPageURL returns the URL of the page after the record with id lastID,
+// keeping the other query parameters.
+func nextPageURL(u *url.URL, lastID int) string {
+	query := u.Query()
+	query.Set("after", encodeCursor(lastID))
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return next.String()
+}
+
+// setPageHeaders sets X-Total-Count to the number of records across all
+// pages and, when another page follows, Link to its URL.
+func setPageHeaders[T any](w http.ResponseWriter, r *http.Request, total int, hasMore bool, page []T, id func(T) int) {
+	if hasMore && len(page) > 0 {
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, nextPageURL(r.URL, id(page[len(page)-1]))))
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+}
+
+func answerID(answer *models.Answer) int { return answer.ID }
+
+func questionID(question *models.Question) int { return question.ID }
+
+func reputationEventID(entry *models.ReputationEvent) int { return entry.ID }
+
+func caseID(moderationCase *models.ModerationCase) int { return moderationCase.ID }
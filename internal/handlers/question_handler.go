@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
 	"api_service_questions_and_answers/internal/models"
 	"api_service_questions_and_answers/internal/services"
 	"encoding/json"
@@ -24,23 +25,37 @@ func NewQuestionHandler(service services.QuestionService) *QuestionHandler {
 }
 
 // GetQuestions godoc
-// @Summary Get all questions
-// @Description Get a list of all questions
+// @Summary Get questions
+// @Description Get questions, optionally filtered and sorted; by default all questions ordered by ID.
+// @Description Unknown, repeated and malformed query parameters are rejected with 400.
 // @Tags questions
 // @Accept json
 // @Produce json
+// @Param created_after query string false "Only questions created after this RFC 3339 time or YYYY-MM-DD date"
+// @Param created_before query string false "Only questions created before this RFC 3339 time or YYYY-MM-DD date"
+// @Param unanswered query bool false "Only questions without answers"
+// @Param min_answers query int false "Only questions with at least this many answers" minimum(0)
+// @Param author query string false "Only questions asked by this user" format(uuid)
+// @Param sort query string false "Order" Enums(newest, oldest, most_answers, recent_activity)
 // @Success 200 {array} models.Question
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/questions [get]
 func (h *QuestionHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	query, err := parseQuestionQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 		return
 	}
 
-	question, err := h.service.GetAllQuestions()
+	question, err := h.service.FindQuestions(query)
 	if err != nil {
-		http.Error(w, "Failed to get questions", http.StatusInternalServerError)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetQuestions), http.StatusInternalServerError)
 		return
 	}
 
@@ -56,17 +71,20 @@ func (h *QuestionHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 // CreateQuestion godoc
 // @Summary Create a new question
 // @Description Create a new question
+// @Description The content filters reject the text with 400 or save it hidden until a moderator approves it.
 // @Tags questions
 // @Accept json
 // @Produce json
 // @Param question body models.Question true "Question object"
+// @Param Accept-Language header string false "Language of validation messages, en or ru; i18n.default_locale when neither matches"
 // @Success 201 {object} models.Question
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/questions [post]
 func (h *QuestionHandler) CreateQuestion(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
@@ -74,23 +92,36 @@ func (h *QuestionHandler) CreateQuestion(w http.ResponseWriter, r *http.Request)
 
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
 		return
 	}
 
 	if err := req.Validate(); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 		return
 	}
 
 	question := &models.Question{
 		Text:      req.Text,
+		UserID:    req.UserID,
 		CreatedAt: time.Now(),
 	}
 
 	createdQuestion, err := h.service.CreateQuestion(question)
 	if err != nil {
-		http.Error(w, "Failed to create question", http.StatusInternalServerError)
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UnknownUser), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			http.Error(w, i18n.Message(r.Context(), i18n.UserSuspended), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, services.ErrContentRejected) {
+			http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToCreateQuestion), http.StatusInternalServerError)
 		return
 	}
 
@@ -109,7 +140,12 @@ func (h *QuestionHandler) CreateQuestion(w http.ResponseWriter, r *http.Request)
 // @Tags questions
 // @Accept json
 // @Produce json
+// @Description With include=answers only the first answers_limit answers are returned; answer_count is the total
+// @Description and GET /api/questions/{id}/answers pages through the rest.
 // @Param id path int true "Question ID"
+// @Param include query string false "Return only the first answers" Enums(answers)
+// @Param answers_limit query int false "Number of answers with include=answers" minimum(1) maximum(100) default(10)
+// @Param format query string false "Text as Markdown, as sanitised HTML or both" Enums(raw, html, both) default(both)
 // @Success 200 {object} models.Question
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
@@ -117,28 +153,44 @@ func (h *QuestionHandler) CreateQuestion(w http.ResponseWriter, r *http.Request)
 // @Router /api/questions/{id} [get]
 func (h *QuestionHandler) GetQuestion(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
 	id, err := helpers.ExtractIDFromPath(r)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	answersLimit, err := parseQuestionInclude(r.URL.Query())
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+	format, err := parseTextFormat(r.URL.Query())
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
 		return
 	}
 
-	question, err := h.service.GetQuestion(id)
+	var question *models.Question
+	if answersLimit > 0 {
+		question, err = h.service.GetQuestionPreview(id, answersLimit)
+	} else {
+		question, err = h.service.GetQuestion(id)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			http.Error(w, "Not found", http.StatusNotFound)
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
 			return
 		}
-		http.Error(w, "Failed to get question", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetQuestion), http.StatusBadRequest)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	err = json.NewEncoder(w).Encode(question)
+	err = json.NewEncoder(w).Encode(questionJSON(question, format))
 	if err != nil {
 		log.Printf("Error encoding response: %v", err)
 		return
@@ -159,22 +211,22 @@ func (h *QuestionHandler) GetQuestion(w http.ResponseWriter, r *http.Request) {
 // @Router /api/questions/{id} [delete]
 func (h *QuestionHandler) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
 		return
 	}
 
 	id, err := helpers.ExtractIDFromPath(r)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
 		return
 	}
 
 	err = h.service.DeleteQuestion(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			http.Error(w, "Not found", http.StatusNotFound)
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
 		}
-		http.Error(w, "Failed to deleted question", http.StatusInternalServerError)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToDeleteQuestion), http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -1,18 +1,26 @@
 package handlers
 
 import (
+	"api_service_questions_and_answers/internal/i18n"
 	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 	"gorm.io/gorm"
 )
 
@@ -25,6 +33,26 @@ func (m *MockQuestionService) GetAllQuestions() ([]*models.Question, error) {
 	return args.Get(0).([]*models.Question), args.Error(1)
 }
 
+func (m *MockQuestionService) FindQuestions(query repositories.QuestionQuery) ([]*models.Question, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*models.Question), args.Error(1)
+}
+
+func (m *MockQuestionService) GetQuestionsPage(page repositories.Page) ([]*models.Question, error) {
+	args := m.Called(page)
+	return args.Get(0).([]*models.Question), args.Error(1)
+}
+
+func (m *MockQuestionService) CountQuestions() (int64, error) {
+	args := m.Called()
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQuestionService) GetQuestionsByIDs(ids []uint) ([]*models.Question, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]*models.Question), args.Error(1)
+}
+
 func (m *MockQuestionService) CreateQuestion(question *models.Question) (*models.Question, error) {
 	args := m.Called(question)
 	return args.Get(0).(*models.Question), args.Error(1)
@@ -35,6 +63,11 @@ func (m *MockQuestionService) GetQuestion(id uint) (*models.Question, error) {
 	return args.Get(0).(*models.Question), args.Error(1)
 }
 
+func (m *MockQuestionService) GetQuestionPreview(id uint, answersLimit int) (*models.Question, error) {
+	args := m.Called(id, answersLimit)
+	return args.Get(0).(*models.Question), args.Error(1)
+}
+
 func (m *MockQuestionService) DeleteQuestion(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -49,7 +82,7 @@ func TestQuestionHandler_GetQuestions_Success(t *testing.T) {
 		{ID: 2, Text: "Second question", CreatedAt: time.Now()},
 	}
 
-	mockService.On("GetAllQuestions").Return(expectedQuestions, nil)
+	mockService.On("FindQuestions", repositories.QuestionQuery{}).Return(expectedQuestions, nil)
 
 	req := httptest.NewRequest("GET", "/questions", nil)
 	rr := httptest.NewRecorder()
@@ -70,7 +103,7 @@ func TestQuestionHandler_GetQuestions_ServiceError(t *testing.T) {
 	mockService := new(MockQuestionService)
 	handler := NewQuestionHandler(mockService)
 
-	mockService.On("GetAllQuestions").Return([]*models.Question{}, errors.New("database error"))
+	mockService.On("FindQuestions", repositories.QuestionQuery{}).Return([]*models.Question{}, errors.New("database error"))
 
 	req := httptest.NewRequest("GET", "/questions", nil)
 	rr := httptest.NewRecorder()
@@ -140,6 +173,112 @@ func TestQuestionHandler_CreateQuestion_ValidationError(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
+func TestQuestionHandler_CreateQuestion_ValidationErrorLocalized(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	for locale, message := range map[language.Tag]string{
+		language.English: "text must be at least 5 characters",
+		language.Russian: "текст должен быть не короче 5 символов",
+	} {
+		req := httptest.NewRequest("POST", "/questions", bytes.NewBufferString(`{"text":"Hi"}`))
+		req = req.WithContext(i18n.WithLocale(req.Context(), locale))
+		rr := httptest.NewRecorder()
+
+		handler.CreateQuestion(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, message+"\n", rr.Body.String())
+	}
+}
+
+func TestQuestionHandler_CreateQuestion_LengthInCharacters(t *testing.T) {
+	tests := []struct {
+		text string
+		code int
+	}{
+		{"Кто я", http.StatusCreated},
+		{"Кто?", http.StatusBadRequest},
+		{strings.Repeat("ё", 1000), http.StatusCreated},
+		{strings.Repeat("ё", 1001), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d characters", utf8.RuneCountInString(tt.text)), func(t *testing.T) {
+			mockService := new(MockQuestionService)
+			handler := NewQuestionHandler(mockService)
+			mockService.On("CreateQuestion", mock.AnythingOfType("*models.Question")).Return(&models.Question{ID: 1, Text: tt.text}, nil)
+
+			body, _ := json.Marshal(models.Question{Text: tt.text})
+			rr := httptest.NewRecorder()
+			handler.CreateQuestion(rr, httptest.NewRequest("POST", "/questions", bytes.NewBuffer(body)))
+
+			assert.Equal(t, tt.code, rr.Code, rr.Body.String())
+		})
+	}
+}
+
+func TestQuestionHandler_CreateQuestion_Rejected(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	mockService.On("CreateQuestion", mock.AnythingOfType("*models.Question")).
+		Return((*models.Question)(nil), i18n.Wrap(services.ErrContentRejected, i18n.ContentRejected, i18n.NewError(i18n.FilterCaps)))
+
+	for locale, message := range map[language.Tag]string{
+		language.English: "content rejected: text is written in capital letters",
+		language.Russian: "содержимое отклонено: текст написан заглавными буквами",
+	} {
+		requestBody, _ := json.Marshal(models.Question{Text: "WHY DOES THIS NOT WORK"})
+		req := httptest.NewRequest("POST", "/questions", bytes.NewBuffer(requestBody))
+		req = req.WithContext(i18n.WithLocale(req.Context(), locale))
+		rr := httptest.NewRecorder()
+		handler.CreateQuestion(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, message+"\n", rr.Body.String())
+	}
+}
+
+func TestQuestionHandler_CreateQuestion_UserErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{services.ErrUserNotFound, http.StatusBadRequest},
+		{services.ErrUserSuspended, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockService := new(MockQuestionService)
+			handler := NewQuestionHandler(mockService)
+			mockService.On("CreateQuestion", mock.AnythingOfType("*models.Question")).Return((*models.Question)(nil), tt.err)
+
+			userID := uuid.New()
+			requestBody, _ := json.Marshal(models.Question{Text: "What is Go?", UserID: &userID})
+			rr := httptest.NewRecorder()
+			handler.CreateQuestion(rr, httptest.NewRequest("POST", "/questions", bytes.NewBuffer(requestBody)))
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestQuestionHandler_GetQuestion_NotFoundLocalized(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	mockService.On("GetQuestion", uint(999)).Return((*models.Question)(nil), gorm.ErrRecordNotFound)
+
+	req := httptest.NewRequest("GET", "/questions/999", nil)
+	req = req.WithContext(i18n.WithLocale(req.Context(), language.Russian))
+	rr := httptest.NewRecorder()
+	handler.GetQuestion(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "не найдено\n", rr.Body.String())
+}
+
 func TestQuestionHandler_GetQuestion_Success(t *testing.T) {
 	mockService := new(MockQuestionService)
 	handler := NewQuestionHandler(mockService)
@@ -180,6 +319,103 @@ func TestQuestionHandler_GetQuestion_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 }
 
+func TestQuestionHandler_GetQuestion_IncludeAnswers(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	preview := &models.Question{
+		ID:          1,
+		Text:        "Test question",
+		AnswerCount: 5000,
+		Answers:     []models.Answer{{ID: 4, QuestionID: 1, Text: "First"}, {ID: 5, QuestionID: 1, Text: "Second"}},
+	}
+	mockService.On("GetQuestionPreview", uint(1), 2).Return(preview, nil)
+
+	req := httptest.NewRequest("GET", "/questions/1?include=answers&answers_limit=2", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestion(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.Question
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response.Answers, 2)
+	assert.Equal(t, 5000, response.AnswerCount)
+	mockService.AssertNotCalled(t, "GetQuestion", mock.Anything)
+}
+
+func TestQuestionHandler_GetQuestion_Format(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	question := &models.Question{
+		ID:       1,
+		Text:     "Why *Go*?",
+		TextHTML: "<p>Why <em>Go</em>?</p>\n",
+		Answers:  []models.Answer{{ID: 4, QuestionID: 1, Text: "`go run`", TextHTML: "<p><code>go run</code></p>\n"}},
+	}
+	mockService.On("GetQuestion", uint(1)).Return(question, nil)
+
+	tests := []struct {
+		query      string
+		text, html string
+	}{
+		{"", question.Text, question.TextHTML},
+		{"?format=both", question.Text, question.TextHTML},
+		{"?format=raw", question.Text, ""},
+		{"?format=html", "", question.TextHTML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.GetQuestion(rr, httptest.NewRequest("GET", "/questions/1"+tt.query, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var response models.Question
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, tt.text, response.Text)
+			assert.Equal(t, tt.html, response.TextHTML)
+			require.Len(t, response.Answers, 1)
+			assert.Equal(t, tt.html != "", response.Answers[0].TextHTML != "")
+			assert.Equal(t, tt.text != "", response.Answers[0].Text != "")
+			assert.Equal(t, tt.text != "", strings.Contains(rr.Body.String(), `"text":`), "text is left out only of html")
+		})
+	}
+	// The service's question is left as it was.
+	assert.Equal(t, "Why *Go*?", question.Text)
+	assert.NotEmpty(t, question.Answers[0].TextHTML)
+
+	rr := httptest.NewRecorder()
+	handler.GetQuestion(rr, httptest.NewRequest("GET", "/questions/1?format=pdf", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "format must be one of raw, html, both")
+}
+
+func TestQuestionHandler_GetQuestion_RejectsBadInclude(t *testing.T) {
+	tests := map[string]string{
+		"/questions/1?include=comments":                  `include must be "answers"`,
+		"/questions/1?answers_limit=5":                   "answers_limit requires include=answers",
+		"/questions/1?include=answers&answers_limit=0":   "answers_limit must be an integer between 1 and 100",
+		"/questions/1?include=answers&answers_limit=101": "answers_limit must be an integer between 1 and 100",
+		"/questions/1?expand=answers":                    `unknown query parameter "expand"`,
+	}
+
+	for target, message := range tests {
+		t.Run(target, func(t *testing.T) {
+			mockService := new(MockQuestionService)
+			handler := NewQuestionHandler(mockService)
+
+			rr := httptest.NewRecorder()
+			handler.GetQuestion(rr, httptest.NewRequest("GET", target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), message)
+			mockService.AssertNotCalled(t, "GetQuestionPreview", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestQuestionHandler_DeleteQuestion_Success(t *testing.T) {
 	mockService := new(MockQuestionService)
 	handler := NewQuestionHandler(mockService)
@@ -219,3 +455,53 @@ func TestQuestionHandler_MethodNotAllowed(t *testing.T) {
 
 	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
 }
+
+func TestQuestionHandler_GetQuestions_FiltersAndSort(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionHandler(mockService)
+
+	author := uuid.New()
+	expected := repositories.NewQuestionQuery(
+		repositories.CreatedAfter(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		repositories.MinAnswers(2),
+		repositories.ByAuthor(author),
+		repositories.SortedBy(repositories.SortMostAnswers),
+	)
+	mockService.On("FindQuestions", expected).Return([]*models.Question{}, nil)
+
+	req := httptest.NewRequest("GET", "/questions?created_after=2026-01-01&min_answers=2&author="+author.String()+"&sort=most_answers", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestQuestionHandler_GetQuestions_RejectsBadParameters(t *testing.T) {
+	tests := map[string]string{
+		"/questions?page=2":                                             `unknown query parameter "page"`,
+		"/questions?sort=best":                                          "sort must be one of newest, oldest, most_answers, recent_activity",
+		"/questions?sort=newest&sort=oldest":                            `query parameter "sort" must be given once`,
+		"/questions?created_after=yesterday":                            "created_after must be an RFC 3339 time",
+		"/questions?created_after=2026-02-01&created_before=2026-01-01": "created_after must be before created_before",
+		"/questions?unanswered=maybe":                                   "unanswered must be true or false",
+		"/questions?min_answers=-1":                                     "min_answers must be a non-negative integer",
+		"/questions?unanswered=true&min_answers=1":                      "min_answers cannot be combined with unanswered=true",
+		"/questions?author=bob":                                         "author must be a UUID",
+	}
+
+	for target, message := range tests {
+		t.Run(target, func(t *testing.T) {
+			mockService := new(MockQuestionService)
+			handler := NewQuestionHandler(mockService)
+
+			rr := httptest.NewRecorder()
+			handler.GetQuestions(rr, httptest.NewRequest("GET", target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), message)
+			mockService.AssertNotCalled(t, "FindQuestions", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/repositories"
+	"errors"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultIncludeAnswersLimit = 10
+
+var (
+	questionQueryParams   = []string{"created_after", "created_before", "unanswered", "min_answers", "author", "sort"}
+	questionIncludeParams = []string{"include", "answers_limit", "format"}
+)
+
+// parseQuestionQuery builds the question list query from the URL query.
+// Unknown, repeated and malformed parameters are all reported together.
+func parseQuestionQuery(values url.Values) (repositories.QuestionQuery, error) {
+	errs := checkQueryParams(values, questionQueryParams)
+	var specs []repositories.QuestionSpec
+
+	var createdAfter, createdBefore time.Time
+	if value := values.Get("created_after"); value != "" {
+		t, err := parseQueryTime(value)
+		if err != nil {
+			errs = append(errs, i18n.NewError(i18n.InvalidQueryTime, "created_after"))
+		}
+		createdAfter = t
+		specs = append(specs, repositories.CreatedAfter(t))
+	}
+	if value := values.Get("created_before"); value != "" {
+		t, err := parseQueryTime(value)
+		if err != nil {
+			errs = append(errs, i18n.NewError(i18n.InvalidQueryTime, "created_before"))
+		}
+		createdBefore = t
+		specs = append(specs, repositories.CreatedBefore(t))
+	}
+	if !createdAfter.IsZero() && !createdBefore.IsZero() && !createdAfter.Before(createdBefore) {
+		errs = append(errs, i18n.NewError(i18n.CreatedAfterNotBefore))
+	}
+
+	unanswered := false
+	if value := values.Get("unanswered"); value != "" {
+		var err error
+		unanswered, err = strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, i18n.NewError(i18n.MustBeBool, "unanswered"))
+		}
+		if unanswered {
+			specs = append(specs, repositories.Unanswered())
+		}
+	}
+
+	if value := values.Get("min_answers"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			errs = append(errs, i18n.NewError(i18n.MustBeNonNegative, "min_answers"))
+		} else if unanswered && n > 0 {
+			errs = append(errs, i18n.NewError(i18n.MinAnswersWithUnanswered))
+		}
+		specs = append(specs, repositories.MinAnswers(n))
+	}
+
+	if value := values.Get("author"); value != "" {
+		author, err := uuid.Parse(value)
+		if err != nil {
+			errs = append(errs, i18n.NewError(i18n.MustBeUUID, "author"))
+		}
+		specs = append(specs, repositories.ByAuthor(author))
+	}
+
+	if value := values.Get("sort"); value != "" {
+		order := repositories.QuestionSort(value)
+		if !slices.Contains(repositories.QuestionSorts, order) {
+			errs = append(errs, i18n.NewError(i18n.MustBeOneOf, "sort", joinSorts(repositories.QuestionSorts)))
+		}
+		specs = append(specs, repositories.SortedBy(order))
+	}
+
+	if len(errs) > 0 {
+		return repositories.QuestionQuery{}, errors.Join(errs...)
+	}
+	return repositories.NewQuestionQuery(specs...), nil
+}
+
+// parseQuestionInclude reads ?include=answers&answers_limit=N. It returns
+// a zero limit when the question should come with all of its answers.
+func parseQuestionInclude(values url.Values) (int, error) {
+	errs := checkQueryParams(values, questionIncludeParams)
+
+	include := values.Get("include")
+	if values.Has("include") && include != "answers" {
+		errs = append(errs, i18n.NewError(i18n.IncludeMustBeAnswers))
+	}
+
+	limit := 0
+	if values.Has("include") {
+		limit = defaultIncludeAnswersLimit
+	}
+	if value := values.Get("answers_limit"); value != "" {
+		n, err := parseLimit("answers_limit", value)
+		if err != nil {
+			errs = append(errs, err)
+		} else if !values.Has("include") {
+			errs = append(errs, i18n.NewError(i18n.AnswersLimitNeedsInclude))
+		}
+		limit = n
+	}
+
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return limit, nil
+}
+
+// checkQueryParams reports the parameters that are not in known or are
+// given more than once.
+func checkQueryParams(values url.Values, known []string) []error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			errs = append(errs, i18n.NewError(i18n.UnknownQueryParam, name, strings.Join(known, ", ")))
+			continue
+		}
+		if len(values[name]) > 1 {
+			errs = append(errs, i18n.NewError(i18n.RepeatedQueryParam, name))
+		}
+	}
+	return errs
+}
+
+// parseQueryTime accepts an RFC 3339 time or a date, which means midnight UTC.
+func parseQueryTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Parse(time.DateOnly, value)
+	}
+	return t, nil
+}
+
+func joinSorts[S ~string](sorts []S) string {
+	names := make([]string, len(sorts))
+	for i, s := range sorts {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
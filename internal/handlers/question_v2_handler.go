@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionV2Handler struct {
+	service services.QuestionService
+}
+
+func NewQuestionV2Handler(service services.QuestionService) *QuestionV2Handler {
+	return &QuestionV2Handler{
+		service,
+	}
+}
+
+type createQuestionV2Request struct {
+	Text   string `json:"text" example:"How do I cancel a context?"`
+	UserID string `json:"user_id,omitempty" example:"3f1c2a5e-8f0e-4c4b-9a57-2b1f3f6f9a10"`
+}
+
+// GetQuestions godoc
+// @Summary Get questions
+// @Description Get questions in an envelope, optionally filtered and sorted; meta.count is the number of questions.
+// @Description Unknown, repeated and malformed query parameters are rejected with 400.
+// @Tags v2
+// @Produce json
+// @Param created_after query string false "Only questions created after this RFC 3339 time or YYYY-MM-DD date"
+// @Param created_before query string false "Only questions created before this RFC 3339 time or YYYY-MM-DD date"
+// @Param unanswered query bool false "Only questions without answers"
+// @Param min_answers query int false "Only questions with at least this many answers" minimum(0)
+// @Param author query string false "Only questions asked by this user" format(uuid)
+// @Param sort query string false "Order" Enums(newest, oldest, most_answers, recent_activity)
+// @Success 200 {object} V2Envelope{data=[]V2Question}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions [get]
+func (h *QuestionV2Handler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuestionQuery(r.URL.Query())
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	questions, err := h.service.FindQuestions(query)
+	if err != nil {
+		log.Printf("Failed to get questions: %v", err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToGetQuestions))
+		return
+	}
+
+	data := make([]V2Question, 0, len(questions))
+	for _, question := range questions {
+		data = append(data, toV2Question(question))
+	}
+
+	writeJSON(w, http.StatusOK, V2Envelope{
+		Data:  data,
+		Meta:  map[string]any{"count": len(data)},
+		Links: map[string]string{"self": v2Prefix + "/questions"},
+	})
+}
+
+// CreateQuestion godoc
+// @Summary Create a new question
+// @Description The content filters reject the text with 422 content_rejected or save it hidden until a moderator approves it.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param question body createQuestionV2Request true "Question"
+// @Param Accept-Language header string false "Language of validation messages, en or ru; i18n.default_locale when neither matches"
+// @Success 201 {object} V2Envelope{data=V2Question}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 403 {object} V2ErrorBody
+// @Failure 422 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions [post]
+func (h *QuestionV2Handler) CreateQuestion(w http.ResponseWriter, r *http.Request) {
+	var req createQuestionV2Request
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.V2InvalidRequestBody))
+		return
+	}
+
+	question := &models.Question{
+		Text:      req.Text,
+		CreatedAt: time.Now(),
+	}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Message(r.Context(), i18n.MustBeUUID, "user_id"))
+			return
+		}
+		question.UserID = &userID
+	}
+	if err := question.Validate(); err != nil {
+		writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	created, err := h.service.CreateQuestion(question)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			writeV2Error(w, http.StatusUnprocessableEntity, V2CodeValidationFailed, i18n.Message(r.Context(), i18n.V2UnknownUser))
+			return
+		}
+		if errors.Is(err, services.ErrUserSuspended) {
+			writeV2Error(w, http.StatusForbidden, V2CodeForbidden, i18n.Message(r.Context(), i18n.V2UserSuspended))
+			return
+		}
+		if errors.Is(err, services.ErrContentRejected) {
+			writeV2Error(w, http.StatusUnprocessableEntity, V2CodeContentRejected, i18n.Localize(r.Context(), err))
+			return
+		}
+		log.Printf("Failed to create question: %v", err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToCreateQuestion))
+		return
+	}
+
+	self := v2QuestionLink(created.ID)
+	w.Header().Set("Location", self)
+	writeJSON(w, http.StatusCreated, V2Envelope{
+		Data:  toV2Question(created),
+		Links: map[string]string{"self": self},
+	})
+}
+
+// GetQuestion godoc
+// @Summary Get question by ID with its answers
+// @Description meta.answer_count is the number of answers of the question. With include=answers only the first
+// @Description answers_limit answers are returned; GET /api/v2/questions/{id}/answers pages through the rest.
+// @Tags v2
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param include query string false "Return only the first answers" Enums(answers)
+// @Param answers_limit query int false "Number of answers with include=answers" minimum(1) maximum(100) default(10)
+// @Param format query string false "Text as Markdown, as sanitised HTML or both" Enums(raw, html, both) default(both)
+// @Success 200 {object} V2Envelope{data=V2Question}
+// @Failure 400 {object} V2ErrorBody
+// @Failure 404 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions/{id} [get]
+func (h *QuestionV2Handler) GetQuestion(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidQuestionID))
+		return
+	}
+
+	answersLimit, err := parseQuestionInclude(r.URL.Query())
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Localize(r.Context(), err))
+		return
+	}
+	format, err := parseTextFormat(r.URL.Query())
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Localize(r.Context(), err))
+		return
+	}
+
+	var question *models.Question
+	if answersLimit > 0 {
+		question, err = h.service.GetQuestionPreview(id, answersLimit)
+	} else {
+		question, err = h.service.GetQuestion(id)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeV2Error(w, http.StatusNotFound, V2CodeNotFound, i18n.Message(r.Context(), i18n.V2QuestionNotFound))
+			return
+		}
+		log.Printf("Failed to get question %d: %v", id, err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToGetQuestion))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, V2Envelope{
+		Data: v2QuestionJSON(question, format),
+		Meta: map[string]any{"answer_count": question.AnswerCount},
+		Links: map[string]string{
+			"self":    v2QuestionLink(question.ID),
+			"answers": v2QuestionLink(question.ID) + "/answers",
+		},
+	})
+}
+
+// DeleteQuestion godoc
+// @Summary Delete a question and its answers
+// @Description Deleting a missing question succeeds.
+// @Tags v2
+// @Param id path string true "Question ID"
+// @Success 204
+// @Failure 400 {object} V2ErrorBody
+// @Failure 500 {object} V2ErrorBody
+// @Router /api/v2/questions/{id} [delete]
+func (h *QuestionV2Handler) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, V2CodeInvalidRequest, i18n.Message(r.Context(), i18n.InvalidQuestionID))
+		return
+	}
+
+	err = h.service.DeleteQuestion(id)
+	if err != nil {
+		log.Printf("Failed to delete question %d: %v", id, err)
+		writeV2Error(w, http.StatusInternalServerError, V2CodeInternal, i18n.Message(r.Context(), i18n.V2FailedToDeleteQuestion))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"errors"
+	"log"
+	"net/http"
+)
+
+type ReputationHandler struct {
+	service services.ReputationService
+}
+
+func NewReputationHandler(service services.ReputationService) *ReputationHandler {
+	return &ReputationHandler{
+		service,
+	}
+}
+
+// GetUserReputation godoc
+// @Summary Get the reputation of a user
+// @Description Get a user's reputation and one page of the ledger it is the sum of, newest first by default.
+// @Description The Link header holds the URL of the next page with rel="next" and X-Total-Count the number of entries.
+// @Tags users
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param after query string false "Cursor from the Link header of the previous page"
+// @Param sort query string false "Order" Enums(newest, oldest) default(newest)
+// @Success 200 {object} services.UserReputation
+// @Header 200 {string} Link "URL of the next page, if any"
+// @Header 200 {integer} X-Total-Count "Number of ledger entries of the user"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid}/reputation [get]
+func (h *ReputationHandler) GetUserReputation(w http.ResponseWriter, r *http.Request) {
+	userID, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	page, err := parsePage(r.URL.Query(), repositories.NewestFirst)
+	if err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	reputation, err := h.service.GetUserReputation(userID, page)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		log.Printf("Service error getting reputation of user %s: %v", userID, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetReputation), http.StatusInternalServerError)
+		return
+	}
+
+	setPageHeaders(w, r, reputation.Total, reputation.HasMore, reputation.History, reputationEventID)
+	writeJSON(w, http.StatusOK, reputation)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReputationService struct {
+	mock.Mock
+}
+
+func (m *MockReputationService) GetUserReputation(userID uuid.UUID, page repositories.Page) (*services.UserReputation, error) {
+	args := m.Called(userID, page)
+	return args.Get(0).(*services.UserReputation), args.Error(1)
+}
+
+func TestReputationHandler_GetUserReputation(t *testing.T) {
+	mockService := new(MockReputationService)
+	handler := NewReputationHandler(mockService)
+
+	userID := uuid.New()
+	page := repositories.Page{Limit: 1, Sort: repositories.NewestFirst}
+	mockService.On("GetUserReputation", userID, page).Return(&services.UserReputation{
+		UserID:     userID,
+		Reputation: 12,
+		History:    []*models.ReputationEvent{{ID: 7, UserID: userID, Reason: models.ReputationAnswerPosted, Points: 10}},
+		Total:      2,
+		HasMore:    true,
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetUserReputation(rr, httptest.NewRequest("GET", "/users/"+userID.String()+"/reputation?limit=1", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</users/`+userID.String()+`/reputation?after=`+encodeCursor(7)+`&limit=1>; rel="next"`, rr.Header().Get("Link"))
+
+	var response services.UserReputation
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, 12, response.Reputation)
+	require.Len(t, response.History, 1)
+	assert.Equal(t, models.ReputationAnswerPosted, response.History[0].Reason)
+}
+
+func TestReputationHandler_GetUserReputation_NotFound(t *testing.T) {
+	mockService := new(MockReputationService)
+	handler := NewReputationHandler(mockService)
+
+	userID := uuid.New()
+	mockService.On("GetUserReputation", userID, mock.Anything).Return((*services.UserReputation)(nil), services.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.GetUserReputation(rr, httptest.NewRequest("GET", "/users/"+userID.String()+"/reputation", nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"net/url"
+	"strings"
+)
+
+// Text formats of ?format: the Markdown, the rendered HTML or both.
+const (
+	formatRaw  = "raw"
+	formatHTML = "html"
+	formatBoth = "both"
+)
+
+// parseTextFormat reads ?format=raw|html|both, which defaults to both.
+func parseTextFormat(values url.Values) (string, error) {
+	format := values.Get("format")
+	if format == "" {
+		return formatBoth, nil
+	}
+	if format != formatRaw && format != formatHTML && format != formatBoth {
+		return "", i18n.NewError(i18n.MustBeOneOf, "format", strings.Join([]string{formatRaw, formatHTML, formatBoth}, ", "))
+	}
+	return format, nil
+}
+
+// questionInFormat returns a copy of the question whose text, and that of
+// its answers, is only in format.
+func questionInFormat(question *models.Question, format string) *models.Question {
+	formatted := *question
+	formatted.Text, formatted.TextHTML = textInFormat(question.Text, question.TextHTML, format)
+	if question.Answers != nil {
+		formatted.Answers = make([]models.Answer, len(question.Answers))
+		for i, answer := range question.Answers {
+			formatted.Answers[i] = *answerInFormat(&answer, format)
+		}
+	}
+	return &formatted
+}
+
+// answerInFormat returns a copy of the answer whose text is only in format.
+func answerInFormat(answer *models.Answer, format string) *models.Answer {
+	formatted := *answer
+	formatted.Text, formatted.TextHTML = textInFormat(answer.Text, answer.TextHTML, format)
+	return &formatted
+}
+
+// htmlQuestion and htmlAnswer are the JSON of a question and an answer in
+// formatHTML, which leaves their text out instead of sending it empty.
+type htmlQuestion struct {
+	*models.Question
+	Text    string       `json:"text,omitempty"`
+	Answers []htmlAnswer `json:"answers,omitempty"`
+}
+
+type htmlAnswer struct {
+	*models.Answer
+	Text string `json:"text,omitempty"`
+}
+
+// questionJSON returns what v1 encodes for the question in format.
+func questionJSON(question *models.Question, format string) any {
+	formatted := questionInFormat(question, format)
+	if format != formatHTML {
+		return formatted
+	}
+	projected := htmlQuestion{Question: formatted}
+	for i := range formatted.Answers {
+		projected.Answers = append(projected.Answers, htmlAnswer{Answer: &formatted.Answers[i]})
+	}
+	return projected
+}
+
+// answerJSON returns what v1 encodes for the answer in format.
+func answerJSON(answer *models.Answer, format string) any {
+	formatted := answerInFormat(answer, format)
+	if format != formatHTML {
+		return formatted
+	}
+	return htmlAnswer{Answer: formatted}
+}
+
+// v2HTMLQuestion and v2HTMLAnswer do the same for v2.
+type v2HTMLQuestion struct {
+	V2Question
+	Text    string         `json:"text,omitempty"`
+	Answers []v2HTMLAnswer `json:"answers,omitempty"`
+}
+
+type v2HTMLAnswer struct {
+	V2Answer
+	Text string `json:"text,omitempty"`
+}
+
+// v2QuestionJSON returns what v2 encodes for the question in format.
+func v2QuestionJSON(question *models.Question, format string) any {
+	formatted := toV2Question(questionInFormat(question, format))
+	if format != formatHTML {
+		return formatted
+	}
+	projected := v2HTMLQuestion{V2Question: formatted}
+	for _, answer := range formatted.Answers {
+		projected.Answers = append(projected.Answers, v2HTMLAnswer{V2Answer: answer})
+	}
+	return projected
+}
+
+// v2AnswerJSON returns what v2 encodes for the answer in format.
+func v2AnswerJSON(answer *models.Answer, format string) any {
+	formatted := toV2Answer(answerInFormat(answer, format))
+	if format != formatHTML {
+		return formatted
+	}
+	return v2HTMLAnswer{V2Answer: formatted}
+}
+
+func textInFormat(text, html, format string) (string, string) {
+	if format == formatRaw {
+		return text, ""
+	}
+	if format == formatHTML {
+		return "", html
+	}
+	return text, html
+}
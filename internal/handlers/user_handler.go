@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+type UserHandler struct {
+	service services.UserService
+}
+
+func NewUserHandler(service services.UserService) *UserHandler {
+	return &UserHandler{
+		service,
+	}
+}
+
+type registerUserRequest struct {
+	DisplayName string `json:"display_name" example:"Ada"`
+	Email       string `json:"email" example:"ada@example.com"`
+}
+
+// userRegisteredResponse is the only response that includes the email.
+type userRegisteredResponse struct {
+	*models.User
+	Email string `json:"email"`
+}
+
+type setUserStatusRequest struct {
+	Status string `json:"status" example:"suspended"`
+}
+
+// RegisterUser godoc
+// @Summary Register a user
+// @Description Register a user with a display name and an email, which must not be registered yet.
+// @Description The email is only returned here.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body registerUserRequest true "User"
+// @Param Accept-Language header string false "Language of validation messages, en or ru; i18n.default_locale when neither matches"
+// @Success 201 {object} userRegisteredResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users [post]
+func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
+	var req registerUserRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+
+	user := &models.User{DisplayName: req.DisplayName, Email: &req.Email}
+	if err := user.Validate(); err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.RegisterUser(user)
+	if err != nil {
+		if errors.Is(err, services.ErrEmailTaken) {
+			http.Error(w, i18n.Message(r.Context(), i18n.EmailTaken), http.StatusConflict)
+			return
+		}
+		log.Printf("Service error registering user: %v", err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToRegisterUser), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, userRegisteredResponse{created, *created.Email})
+}
+
+// GetUser godoc
+// @Summary Get user by ID
+// @Description Get a user's public profile.
+// @Tags users
+// @Produce json
+// @Param uuid path string true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users/{uuid} [get]
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.GetUser(id)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		log.Printf("Service error getting user %s: %v", id, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetUser), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// SetUserStatus godoc
+// @Summary Suspend or reactivate a user
+// @Description Suspended users cannot answer questions.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param uuid path string true "User ID"
+// @Param status body setUserStatusRequest true "New status, active or suspended"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{uuid}/status [put]
+func (h *UserHandler) SetUserStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractUserIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	var req setUserStatusRequest
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+	if !models.ValidUserStatus(req.Status) {
+		http.Error(w, i18n.Message(r.Context(), i18n.MustBeOneOf, "status", strings.Join(models.UserStatuses, ", ")), http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.service.SetUserStatus(id, req.Status)
+	if err != nil {
+		if errors.Is(err, services.ErrUserNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		log.Printf("Service error setting status of user %s: %v", id, err)
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToUpdateUser), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) RegisterUser(request *models.User) (*models.User, error) {
+	args := m.Called(request)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) GetUser(id uuid.UUID) (*models.User, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) SetUserStatus(id uuid.UUID, status string) (*models.User, error) {
+	args := m.Called(id, status)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func TestUserHandler_RegisterUser_ReturnsEmailOnce(t *testing.T) {
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService)
+
+	email := "ada@example.com"
+	user := &models.User{ID: uuid.New(), DisplayName: "Ada", Email: &email, Status: models.UserActive, CreatedAt: time.Now()}
+	mockService.On("RegisterUser", mock.AnythingOfType("*models.User")).Return(user, nil)
+
+	body := `{"display_name": "Ada", "email": "Ada@Example.com"}`
+	rr := httptest.NewRecorder()
+	handler.RegisterUser(rr, httptest.NewRequest("POST", "/users", bytes.NewBufferString(body)))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, user.ID.String(), response["id"])
+	assert.Equal(t, email, response["email"])
+
+	mockService.On("GetUser", user.ID).Return(user, nil)
+	rr = httptest.NewRecorder()
+	handler.GetUser(rr, httptest.NewRequest("GET", "/users/"+user.ID.String(), nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	response = nil
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "Ada", response["display_name"])
+	assert.NotContains(t, response, "email")
+}
+
+func TestUserHandler_RegisterUser_Errors(t *testing.T) {
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService)
+	mockService.On("RegisterUser", mock.AnythingOfType("*models.User")).Return((*models.User)(nil), services.ErrEmailTaken)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"invalid email", `{"display_name": "Ada", "email": "Ada <ada@example.com>"}`, http.StatusBadRequest},
+		{"missing name", `{"email": "ada@example.com"}`, http.StatusBadRequest},
+		{"email taken", `{"display_name": "Ada", "email": "ada@example.com"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.RegisterUser(rr, httptest.NewRequest("POST", "/users", bytes.NewBufferString(tt.body)))
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestUserHandler_GetUser_NotFound(t *testing.T) {
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService)
+
+	id := uuid.New()
+	mockService.On("GetUser", id).Return((*models.User)(nil), services.ErrUserNotFound)
+
+	rr := httptest.NewRecorder()
+	handler.GetUser(rr, httptest.NewRequest("GET", "/users/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestUserHandler_SetUserStatus(t *testing.T) {
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService)
+
+	id := uuid.New()
+	mockService.On("SetUserStatus", id, models.UserSuspended).Return(&models.User{ID: id, Status: models.UserSuspended}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.SetUserStatus(rr, httptest.NewRequest("PUT", "/users/"+id.String()+"/status", bytes.NewBufferString(`{"status": "suspended"}`)))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.SetUserStatus(rr, httptest.NewRequest("PUT", "/users/"+id.String()+"/status", bytes.NewBufferString(`{"status": "banned"}`)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNumberOfCalls(t, "SetUserStatus", 1)
+}
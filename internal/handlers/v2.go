@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const v2Prefix = "/api/v2"
+
+// V2Envelope wraps every successful /api/v2 response.
+type V2Envelope struct {
+	Data  any               `json:"data"`
+	Meta  map[string]any    `json:"meta,omitempty"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// V2ErrorBody is the body of every failed /api/v2 response.
+type V2ErrorBody struct {
+	Error V2Error `json:"error"`
+}
+
+type V2Error struct {
+	Status  int    `json:"status" example:"404"`
+	Code    string `json:"code" example:"not_found"`
+	Message string `json:"message" example:"question not found"`
+}
+
+const (
+	V2CodeInvalidRequest   = "invalid_request"
+	V2CodeValidationFailed = "validation_failed"
+	V2CodeContentRejected  = "content_rejected"
+	V2CodeNotFound         = "not_found"
+	V2CodeForbidden        = "forbidden"
+	V2CodeMethodNotAllowed = "method_not_allowed"
+	V2CodeInternal         = "internal"
+)
+
+type V2Question struct {
+	ID             string     `json:"id" example:"1"`
+	Text           string     `json:"text"`
+	TextHTML       string     `json:"text_html,omitempty"`
+	UserID         string     `json:"user_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	AnswerCount    int        `json:"answer_count"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	Hidden         bool       `json:"hidden,omitempty"`
+	Answers        []V2Answer `json:"answers,omitempty"`
+}
+
+type V2Answer struct {
+	ID         string    `json:"id" example:"1"`
+	QuestionID string    `json:"question_id" example:"1"`
+	UserID     string    `json:"user_id"`
+	Text       string    `json:"text"`
+	TextHTML   string    `json:"text_html,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	Hidden     bool      `json:"hidden,omitempty"`
+}
+
+func toV2Question(question *models.Question) V2Question {
+	v2 := V2Question{
+		ID:             strconv.Itoa(question.ID),
+		Text:           question.Text,
+		TextHTML:       question.TextHTML,
+		CreatedAt:      question.CreatedAt,
+		AnswerCount:    question.AnswerCount,
+		LastActivityAt: question.LastActivityAt,
+		Hidden:         question.Hidden,
+	}
+	if question.UserID != nil {
+		v2.UserID = question.UserID.String()
+	}
+	for _, answer := range question.Answers {
+		v2.Answers = append(v2.Answers, toV2Answer(&answer))
+	}
+	return v2
+}
+
+func toV2Answer(answer *models.Answer) V2Answer {
+	return V2Answer{
+		ID:         strconv.Itoa(answer.ID),
+		QuestionID: strconv.FormatUint(uint64(answer.QuestionID), 10),
+		UserID:     answer.UserID.String(),
+		Text:       answer.Text,
+		TextHTML:   answer.TextHTML,
+		CreatedAt:  answer.CreatedAt,
+		Hidden:     answer.Hidden,
+	}
+}
+
+func v2QuestionLink(id int) string {
+	return v2Prefix + "/questions/" + strconv.Itoa(id)
+}
+
+func v2AnswerLink(id int) string {
+	return v2Prefix + "/answers/" + strconv.Itoa(id)
+}
+
+func writeV2Error(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, V2ErrorBody{Error: V2Error{Status: status, Code: code, Message: message}})
+}
+
+// V2NotFound and V2MethodNotAllowed give unmatched /api/v2 requests the
+// same error body as the handlers.
+func V2NotFound(w http.ResponseWriter, r *http.Request) {
+	writeV2Error(w, http.StatusNotFound, V2CodeNotFound, i18n.Message(r.Context(), i18n.RouteNotFound))
+}
+
+func V2MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeV2Error(w, http.StatusMethodNotAllowed, V2CodeMethodNotAllowed, i18n.Message(r.Context(), i18n.V2MethodNotAllowed))
+}
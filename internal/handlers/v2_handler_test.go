@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+func decodeV2Error(t *testing.T, rr *httptest.ResponseRecorder) V2Error {
+	t.Helper()
+	var body V2ErrorBody
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, rr.Code, body.Error.Status)
+	return body.Error
+}
+
+func TestQuestionV2Handler_GetQuestion_Envelope(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionV2Handler(mockService)
+
+	question := &models.Question{ID: 7, Text: "Question text", CreatedAt: time.Now(), AnswerCount: 1,
+		Answers: []models.Answer{{ID: 3, QuestionID: 7, UserID: uuid.New(), Text: "Answer text"}}}
+	mockService.On("GetQuestion", uint(7)).Return(question, nil)
+
+	req := httptest.NewRequest("GET", "/questions/7", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestion(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var body struct {
+		Data  V2Question        `json:"data"`
+		Meta  map[string]any    `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Equal(t, "7", body.Data.ID)
+	require.Len(t, body.Data.Answers, 1)
+	assert.Equal(t, "3", body.Data.Answers[0].ID)
+	assert.Equal(t, "7", body.Data.Answers[0].QuestionID)
+	assert.Equal(t, float64(1), body.Meta["answer_count"])
+	assert.Equal(t, "/api/v2/questions/7", body.Links["self"])
+}
+
+func TestQuestionV2Handler_GetQuestion_Format(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionV2Handler(mockService)
+
+	question := &models.Question{ID: 7, Text: "Why *Go*?", TextHTML: "<p>Why <em>Go</em>?</p>\n",
+		Answers: []models.Answer{{ID: 3, QuestionID: 7, Text: "`go run`", TextHTML: "<p><code>go run</code></p>\n"}}}
+	mockService.On("GetQuestion", uint(7)).Return(question, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetQuestion(rr, httptest.NewRequest("GET", "/questions/7?format=html", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), `"text":`)
+	assert.Contains(t, rr.Body.String(), `"text_html":"\u003cp\u003e\u003ccode\u003ego run`)
+
+	rr = httptest.NewRecorder()
+	handler.GetQuestion(rr, httptest.NewRequest("GET", "/questions/7?format=raw", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"text":"Why *Go*?"`)
+}
+
+func TestQuestionV2Handler_GetQuestion_NotFound(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionV2Handler(mockService)
+
+	mockService.On("GetQuestion", uint(7)).Return((*models.Question)(nil), gorm.ErrRecordNotFound)
+
+	req := httptest.NewRequest("GET", "/questions/7", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestion(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, V2CodeNotFound, decodeV2Error(t, rr).Code)
+}
+
+func TestQuestionV2Handler_CreateQuestion_ValidationFailed(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionV2Handler(mockService)
+
+	req := httptest.NewRequest("POST", "/questions", bytes.NewBufferString(`{"text":"hi"}`))
+	req = req.WithContext(i18n.WithLocale(req.Context(), language.Russian))
+	rr := httptest.NewRecorder()
+
+	handler.CreateQuestion(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	body := decodeV2Error(t, rr)
+	assert.Equal(t, V2CodeValidationFailed, body.Code)
+	assert.Equal(t, "текст должен быть не короче 5 символов", body.Message)
+	mockService.AssertNotCalled(t, "CreateQuestion", mock.Anything)
+}
+
+func TestAnswerV2Handler_CreateAnswer(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerV2Handler(mockService)
+
+	userID := uuid.New()
+	mockService.On("CreateAnswer", uint(5), mock.AnythingOfType("*models.Answer")).
+		Return(&models.Answer{ID: 9, QuestionID: 5, UserID: userID, Text: "A long answer"}, nil)
+
+	body := `{"user_id":"` + userID.String() + `","text":"A long answer"}`
+	req := httptest.NewRequest("POST", "/questions/5/answers", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateAnswer(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "/api/v2/answers/9", rr.Header().Get("Location"))
+
+	var envelope struct {
+		Data  V2Answer          `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &envelope))
+	assert.Equal(t, "9", envelope.Data.ID)
+	assert.Equal(t, userID.String(), envelope.Data.UserID)
+	assert.Equal(t, "/api/v2/questions/5", envelope.Links["question"])
+}
+
+func TestAnswerV2Handler_CreateAnswer_QuestionNotFound(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerV2Handler(mockService)
+
+	mockService.On("CreateAnswer", uint(5), mock.AnythingOfType("*models.Answer")).
+		Return((*models.Answer)(nil), services.ErrQuestionNotFound)
+
+	body := `{"user_id":"` + uuid.NewString() + `","text":"A long answer"}`
+	req := httptest.NewRequest("POST", "/questions/5/answers", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateAnswer(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "question not found", decodeV2Error(t, rr).Message)
+}
+
+func TestQuestionV2Handler_CreateQuestion_UserErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{services.ErrUserNotFound, http.StatusUnprocessableEntity, V2CodeValidationFailed},
+		{services.ErrUserSuspended, http.StatusForbidden, V2CodeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockService := new(MockQuestionService)
+			handler := NewQuestionV2Handler(mockService)
+			mockService.On("CreateQuestion", mock.AnythingOfType("*models.Question")).Return((*models.Question)(nil), tt.err)
+
+			body := `{"user_id":"` + uuid.NewString() + `","text":"What is Go?"}`
+			rr := httptest.NewRecorder()
+			handler.CreateQuestion(rr, httptest.NewRequest("POST", "/questions", bytes.NewBufferString(body)))
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.code, decodeV2Error(t, rr).Code)
+		})
+	}
+}
+
+func TestAnswerV2Handler_CreateAnswer_UserErrors(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{services.ErrUserNotFound, http.StatusUnprocessableEntity, V2CodeValidationFailed},
+		{services.ErrUserSuspended, http.StatusForbidden, V2CodeForbidden},
+		{fmt.Errorf("%w: text contains more than 3 links", services.ErrContentRejected), http.StatusUnprocessableEntity, V2CodeContentRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			mockService := new(MockAnswerService)
+			handler := NewAnswerV2Handler(mockService)
+			mockService.On("CreateAnswer", uint(5), mock.AnythingOfType("*models.Answer")).Return((*models.Answer)(nil), tt.err)
+
+			body := `{"user_id":"` + uuid.NewString() + `","text":"A long answer"}`
+			rr := httptest.NewRecorder()
+			handler.CreateAnswer(rr, httptest.NewRequest("POST", "/questions/5/answers", bytes.NewBufferString(body)))
+
+			assert.Equal(t, tt.status, rr.Code)
+			assert.Equal(t, tt.code, decodeV2Error(t, rr).Code)
+		})
+	}
+}
+
+func TestAnswerV2Handler_GetQuestionAnswers(t *testing.T) {
+	mockService := new(MockAnswerService)
+	handler := NewAnswerV2Handler(mockService)
+
+	page := repositories.Page{Limit: 1, Sort: repositories.OldestFirst}
+	mockService.On("ListAnswers", uint(7), page).Return(&services.AnswerList{
+		Answers: []*models.Answer{{ID: 3, QuestionID: 7, UserID: uuid.New()}},
+		Total:   2,
+		HasMore: true,
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/questions/7/answers?limit=1", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetQuestionAnswers(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var body struct {
+		Data  []V2Answer        `json:"data"`
+		Meta  map[string]any    `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "3", body.Data[0].ID)
+	assert.Equal(t, float64(2), body.Meta["total"])
+	assert.Equal(t, encodeCursor(3), body.Meta["next_cursor"])
+	assert.Equal(t, "/questions/7/answers?after="+encodeCursor(3)+"&limit=1", body.Links["next"])
+}
+
+func TestQuestionV2Handler_GetQuestion_IncludeAnswers(t *testing.T) {
+	mockService := new(MockQuestionService)
+	handler := NewQuestionV2Handler(mockService)
+
+	preview := &models.Question{ID: 7, AnswerCount: 40, Answers: []models.Answer{{ID: 3, QuestionID: 7}}}
+	mockService.On("GetQuestionPreview", uint(7), 10).Return(preview, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetQuestion(rr, httptest.NewRequest("GET", "/questions/7?include=answers", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var body struct {
+		Data V2Question     `json:"data"`
+		Meta map[string]any `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	assert.Len(t, body.Data.Answers, 1)
+	assert.Equal(t, float64(40), body.Meta["answer_count"])
+}
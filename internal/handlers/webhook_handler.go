@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/helpers"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/services"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type WebhookHandler struct {
+	service services.WebhookService
+}
+
+func NewWebhookHandler(service services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service,
+	}
+}
+
+type createWebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/hooks/qna"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events" example:"answer.created"`
+}
+
+// webhookCreatedResponse is the only response that includes the secret.
+type webhookCreatedResponse struct {
+	*models.Webhook
+	Secret string `json:"secret"`
+}
+
+// GetWebhooks godoc
+// @Summary List webhooks
+// @Description Get all registered webhook endpoints
+// @Tags webhooks
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} models.Webhook
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.service.GetAllWebhooks()
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetWebhooks), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhooks)
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Register an endpoint for question.created, question.deleted, answer.created and answer.deleted events.
+// @Description Deliveries are signed with HMAC-SHA256 in the X-Webhook-Signature header. The secret is generated when omitted and returned only here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param webhook body createWebhookRequest true "Webhook"
+// @Success 201 {object} webhookCreatedResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req createWebhookRequest
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.InvalidRequestBody), http.StatusBadRequest)
+		return
+	}
+
+	webhook := &models.Webhook{URL: req.URL, Secret: req.Secret, Events: req.Events}
+	if err := webhook.Validate(); err != nil {
+		http.Error(w, i18n.Localize(r.Context(), err), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.service.CreateWebhook(webhook)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToCreateWebhook), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, webhookCreatedResponse{Webhook: created, Secret: created.Secret})
+}
+
+// GetWebhook godoc
+// @Summary Get webhook by ID
+// @Tags webhooks
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.service.GetWebhook(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetWebhook), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook together with its delivery log
+// @Tags webhooks
+// @Security AdminToken
+// @Param id path int true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.DeleteWebhook(id)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToDeleteWebhook), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries godoc
+// @Summary List deliveries of a webhook
+// @Description Delivery log of a webhook, newest first
+// @Tags webhooks
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Webhook ID"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToGetDeliveries), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Queue a new delivery with the same payload as an earlier one
+// @Tags webhooks
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/admin/deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, err := helpers.ExtractIDFromPath(r)
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.BadRequest), http.StatusBadRequest)
+		return
+	}
+
+	delivery, err := h.service.Redeliver(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, i18n.Message(r.Context(), i18n.NotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, i18n.Message(r.Context(), i18n.FailedToRedeliver), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, delivery)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		log.Printf("Error encoding response: %v", err)
+		return
+	}
+}
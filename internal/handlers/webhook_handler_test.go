@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhook(webhook *models.Webhook) (*models.Webhook, error) {
+	args := m.Called(webhook)
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) GetAllWebhooks() ([]*models.Webhook, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhook(id uint) (*models.Webhook, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteWebhook(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetDeliveries(webhookID uint) ([]*models.WebhookDelivery, error) {
+	args := m.Called(webhookID)
+	return args.Get(0).([]*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) Redeliver(deliveryID uint) (*models.WebhookDelivery, error) {
+	args := m.Called(deliveryID)
+	return args.Get(0).(*models.WebhookDelivery), args.Error(1)
+}
+
+func TestWebhookHandler_CreateWebhook_ReturnsSecretOnce(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	created := &models.Webhook{ID: 1, URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"answer.created"}, Active: true}
+	mockService.On("CreateWebhook", mock.AnythingOfType("*models.Webhook")).Return(created, nil)
+	mockService.On("GetWebhook", uint(1)).Return(created, nil)
+
+	body, _ := json.Marshal(map[string]any{"url": created.URL, "events": created.Events})
+	req := httptest.NewRequest("POST", "/api/admin/webhooks", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateWebhook(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Equal(t, "s3cret", response["secret"])
+
+	req = httptest.NewRequest("GET", "/webhooks/1", nil)
+	rr = httptest.NewRecorder()
+
+	handler.GetWebhook(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "s3cret")
+}
+
+func TestWebhookHandler_CreateWebhook_ValidationError(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	body, _ := json.Marshal(map[string]any{"url": "ftp://example.com", "events": []string{"answer.created"}})
+	req := httptest.NewRequest("POST", "/api/admin/webhooks", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+
+	handler.CreateWebhook(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "CreateWebhook", mock.Anything)
+}
+
+func TestWebhookHandler_Redeliver_NotFound(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	mockService.On("Redeliver", uint(7)).Return((*models.WebhookDelivery)(nil), gorm.ErrRecordNotFound)
+
+	req := httptest.NewRequest("POST", "/deliveries/7/redeliver", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Redeliver(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/ws"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+)
+
+type WebSocketHandler struct {
+	hub      *ws.Hub
+	upgrader websocket.Upgrader
+}
+
+// NewWebSocketHandler accepts connections from the same host and from the
+// CORS allowed origins.
+func NewWebSocketHandler(hub *ws.Hub, allowedOrigins []string) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkOrigin(allowedOrigins),
+		},
+	}
+}
+
+// Connect godoc
+// @Summary Subscribe to question and answer activity
+// @Description Upgrades to a WebSocket. Send {"action":"subscribe","topics":["questions","question:1","user:<uuid>"]} to receive events;
+// @Description "unsubscribe" removes topics. Events are question.created, question.deleted, answer.created and answer.deleted.
+// @Tags events
+// @Success 101 "Switching Protocols"
+// @Failure 400 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /api/ws [get]
+func (h *WebSocketHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, i18n.Message(r.Context(), i18n.MethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	client, err := h.hub.Register()
+	if err != nil {
+		http.Error(w, i18n.Message(r.Context(), i18n.TooManyConnections), http.StatusServiceUnavailable)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written the error response.
+		client.Close()
+		return
+	}
+
+	client.Serve(conn)
+}
+
+func checkOrigin(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err == nil && u.Host == r.Host {
+			return true
+		}
+
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || allowed == origin {
+				return true
+			}
+		}
+		return false
+	}
+}
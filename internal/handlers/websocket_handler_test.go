@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/ws"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dialWebSocket(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWebSocketHandler_SubscribeAndReceive(t *testing.T) {
+	hub := ws.NewHub(10, 10, time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, nil).Connect))
+	defer server.Close()
+
+	conn := dialWebSocket(t, server)
+	require.NoError(t, conn.WriteJSON(map[string]any{"action": "subscribe", "topics": []string{"question:1"}}))
+
+	var ack map[string]any
+	require.NoError(t, conn.ReadJSON(&ack))
+	assert.Equal(t, "subscribed", ack["type"])
+
+	hub.Publish(events.Event{Type: events.QuestionCreated, QuestionID: 2})
+	hub.Publish(events.Event{Type: events.AnswerCreated, QuestionID: 1, Data: map[string]int{"id": 7}})
+
+	var event map[string]any
+	require.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, events.AnswerCreated, event["type"])
+	assert.Equal(t, float64(1), event["question_id"])
+}
+
+func TestWebSocketHandler_InvalidTopic(t *testing.T) {
+	hub := ws.NewHub(10, 10, time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, nil).Connect))
+	defer server.Close()
+
+	conn := dialWebSocket(t, server)
+	require.NoError(t, conn.WriteJSON(map[string]any{"action": "subscribe", "topics": []string{"answers"}}))
+
+	var reply map[string]any
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "error", reply["type"])
+}
+
+func TestWebSocketHandler_ConnectionLimit(t *testing.T) {
+	hub := ws.NewHub(1, 10, time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, nil).Connect))
+	defer server.Close()
+
+	dialWebSocket(t, server)
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestWebSocketHandler_RejectsForeignOrigin(t *testing.T) {
+	hub := ws.NewHub(10, 10, time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(NewWebSocketHandler(hub, []string{"https://qna.example.com"}).Connect))
+	defer server.Close()
+
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), header)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Eventually(t, func() bool { return hub.Connections() == 0 }, time.Second, 10*time.Millisecond)
+}
@@ -5,16 +5,24 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/go-chi/chi/v5"
 )
 
+// ExtractIDFromPath returns the {id} route parameter. When the request was
+// not routed through chi, it falls back to the second path segment.
 func ExtractIDFromPath(r *http.Request) (uint, error) {
-	path := strings.Trim(r.URL.Path, "/")
-	parts := strings.Split(path, "/")
-	if len(parts) < 2 {
-		return 0, errors.New("invalid path format")
+	raw := chi.URLParam(r, "id")
+	if raw == "" {
+		path := strings.Trim(r.URL.Path, "/")
+		parts := strings.Split(path, "/")
+		if len(parts) < 2 {
+			return 0, errors.New("invalid path format")
+		}
+		raw = parts[1]
 	}
 
-	id, err := strconv.Atoi(parts[1])
+	id, err := strconv.Atoi(raw)
 	if err != nil || id <= 0 {
 		return 0, errors.New("invalid ID format")
 	}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ExtractUserIDFromPath returns the {uuid} route parameter. When the request
+// was not routed through chi, it falls back to the second path segment.
+func ExtractUserIDFromPath(r *http.Request) (uuid.UUID, error) {
+	raw := chi.URLParam(r, "uuid")
+	if raw == "" {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if len(parts) < 2 {
+			return uuid.Nil, errors.New("invalid path format")
+		}
+		raw = parts[1]
+	}
+
+	userID, err := uuid.Parse(raw)
+	if err != nil || userID == uuid.Nil {
+		return uuid.Nil, errors.New("invalid user ID format")
+	}
+
+	return userID, nil
+}
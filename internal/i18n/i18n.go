@@ -0,0 +1,141 @@
+// Package i18n translates validation and error messages into the locale of
+// the request.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	LocaleEnglish = "en"
+	LocaleRussian = "ru"
+)
+
+// LocaleNames lists the names of the supported locales, in the order of
+// Locales.
+var LocaleNames = []string{LocaleEnglish, LocaleRussian}
+
+// Locales lists the supported locales. The first one is used for Error
+// messages and when a locale has no message for a code.
+var Locales = []language.Tag{language.Make(LocaleEnglish), language.Make(LocaleRussian)}
+
+var matcher = language.NewMatcher(Locales)
+
+// Error is an error with a message code and its parameters. Error returns
+// the message in the first of Locales; Localize translates it. Parameters
+// that are themselves an *Error are translated along with it.
+type Error struct {
+	Code   string
+	Params []any
+	err    error
+}
+
+func NewError(code string, params ...any) *Error {
+	return &Error{Code: code, Params: params}
+}
+
+// Wrap returns an Error with code and params that wraps err, so callers
+// can still match err with errors.Is.
+func Wrap(err error, code string, params ...any) *Error {
+	return &Error{Code: code, Params: params, err: err}
+}
+
+func (e *Error) Error() string {
+	return Translate(Locales[0], e.Code, e.Params...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Translate returns the message of code in locale with params filled in.
+// Unsupported locales get the closest supported one, or the first.
+func Translate(locale language.Tag, code string, params ...any) string {
+	_, index, _ := matcher.Match(locale)
+	translated := make([]any, len(params))
+	for i, param := range params {
+		switch param := param.(type) {
+		case *Error:
+			translated[i] = Translate(locale, param.Code, param.Params...)
+		case int:
+			translated[i] = number(param)
+		default:
+			translated[i] = param
+		}
+	}
+	return message.NewPrinter(Locales[index], message.Catalog(messageCatalog)).Sprintf(code, translated...)
+}
+
+// Message returns the message of code in the locale of ctx.
+func Message(ctx context.Context, code string, params ...any) string {
+	return Translate(FromContext(ctx), code, params...)
+}
+
+// Match returns the supported locale best matching an Accept-Language
+// header, or fallback when the header names none of them.
+func Match(acceptLanguage string, fallback language.Tag) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return fallback
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return fallback
+	}
+	return Locales[index]
+}
+
+type localeKey struct{}
+
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale set by WithLocale, or the first of
+// Locales.
+func FromContext(ctx context.Context) language.Tag {
+	if locale, ok := ctx.Value(localeKey{}).(language.Tag); ok {
+		return locale
+	}
+	return Locales[0]
+}
+
+// Localize returns the message of err in the locale of ctx. Errors that
+// are not an Error keep their own message. Errors joined with errors.Join
+// are localized one by one, a line each.
+func Localize(ctx context.Context, err error) string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var lines []string
+		for _, err := range joined.Unwrap() {
+			lines = append(lines, Localize(ctx, err))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	var coded *Error
+	if errors.As(err, &coded) {
+		return Translate(FromContext(ctx), coded.Code, coded.Params...)
+	}
+	return err.Error()
+}
+
+// number is an int parameter. It prints without digit grouping, as the
+// messages did before they were translated, and still picks the plural
+// form of the message.
+type number int
+
+func (n number) Format(state fmt.State, _ rune) {
+	state.Write([]byte(strconv.Itoa(int(n))))
+}
+
+func (n number) PluralForm(locale language.Tag, _ int) (plural.Form, int) {
+	return plural.Cardinal.MatchPlural(locale, int(n), 0, 0, 0, 0), int(n)
+}
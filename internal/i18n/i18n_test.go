@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// sampleParams holds parameters for the codes that do not take a single
+// number or nothing.
+var sampleParams = map[string][]any{
+	WebhookEventUnknown:      {"answer.edited"},
+	MustBeOneOf:              {"sort", "oldest, newest"},
+	MustBeUUID:               {"author"},
+	MustBeBool:               {"unanswered"},
+	MustBeNonNegative:        {"min_answers"},
+	MustBePositive:           {"id"},
+	LimitOutOfRange:          {"limit", 100},
+	InboxLimitOutOfRange:     {"limit", 100},
+	UnknownQueryParam:        {"page", "limit, after, sort"},
+	RepeatedQueryParam:       {"sort"},
+	InvalidQueryTime:         {"created_after"},
+	AttachmentTypeNotAllowed: {"image/gif"},
+	ContentRejected:          {NewError(FilterCaps)},
+	FilterBannedWord:         {"казино"},
+	FilterRepeatedChars:      {'!', 5},
+}
+
+func TestMessages_EveryCodeTranslated(t *testing.T) {
+	for _, locale := range Locales {
+		t.Run(locale.String(), func(t *testing.T) {
+			for _, code := range Codes {
+				assert.Contains(t, messages[locale], code, "no message for %s", code)
+
+				params, ok := sampleParams[code]
+				if !ok {
+					params = []any{5}
+				}
+				message := Translate(locale, code, params...)
+				assert.NotEqual(t, code, message)
+				assert.NotContains(t, message, "%!", "bad parameters for %s", code)
+			}
+			for code := range messages[locale] {
+				assert.True(t, slices.Contains(Codes, code), "%s is missing from Codes", code)
+			}
+		})
+	}
+	assert.Len(t, messages, len(Locales))
+}
+
+func TestTranslate(t *testing.T) {
+	english, russian := Locales[0], Locales[1]
+	assert.Equal(t, "text must be at least 5 characters", Translate(english, TextTooShort, 5))
+
+	for count, want := range map[int]string{
+		1:    "текст должен быть не короче 1 символа",
+		5:    "текст должен быть не короче 5 символов",
+		21:   "текст должен быть не короче 21 символа",
+		1000: "текст должен быть не короче 1000 символов",
+		1001: "текст должен быть не короче 1001 символа",
+	} {
+		assert.Equal(t, want, Translate(russian, TextTooShort, count))
+	}
+
+	assert.Equal(t, "text is required", Translate(language.German, TextRequired))
+}
+
+func TestMatch(t *testing.T) {
+	english, russian := Locales[0], Locales[1]
+	tests := []struct {
+		header string
+		want   language.Tag
+	}{
+		{"", russian},
+		{"ru-RU,ru;q=0.9,en;q=0.8", russian},
+		{"en-GB", english},
+		{"de, ru;q=0.5", russian},
+		{"de", russian},
+		{"not a header;;", russian},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, Match(tt.header, russian))
+		})
+	}
+	assert.Equal(t, english, Match("de", english))
+}
+
+func TestLocalize(t *testing.T) {
+	ctx := WithLocale(context.Background(), Locales[1])
+	err := fmt.Errorf("invalid question: %w", NewError(TextTooLong, 1000))
+
+	// Numbers are written without digit grouping in every locale.
+	assert.Equal(t, "текст должен быть не длиннее 1000 символов", Localize(ctx, err))
+	assert.Equal(t, "text cannot exceed 1000 characters", Localize(context.Background(), err))
+	assert.Equal(t, "invalid question: text cannot exceed 1000 characters", err.Error())
+	assert.Equal(t, "plain", Localize(ctx, fmt.Errorf("plain")))
+}
+
+func TestLocalize_WrappedNestedAndJoined(t *testing.T) {
+	ctx := WithLocale(context.Background(), Locales[1])
+	rejected := errors.New("content rejected")
+	err := Wrap(rejected, ContentRejected, NewError(FilterRepeatedChars, '!', 21))
+
+	assert.ErrorIs(t, err, rejected)
+	assert.Equal(t, "content rejected: text repeats '!' more than 21 times", err.Error())
+	assert.Equal(t, "содержимое отклонено: текст повторяет '!' больше 21 раза", Localize(ctx, err))
+
+	joined := errors.Join(NewError(MustBeUUID, "author"), errors.New("plain"), NewError(FilterLinks, 2))
+	assert.Equal(t, "author должен быть UUID\nplain\nсодержит больше 2 ссылок", Localize(ctx, joined))
+	assert.Equal(t, "Bad request", Message(context.Background(), BadRequest))
+}
@@ -0,0 +1,476 @@
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+// Message codes of validation. Parameters, if any, are listed after the
+// code.
+const (
+	TextRequired = "text_required"
+	// TextTooShort takes the minimum length.
+	TextTooShort = "text_too_short"
+	// TextTooLong takes the maximum length.
+	TextTooLong         = "text_too_long"
+	UserIDRequired      = "user_id_required"
+	DisplayNameRequired = "display_name_required"
+	// DisplayNameTooShort takes the minimum length.
+	DisplayNameTooShort = "display_name_too_short"
+	// DisplayNameTooLong takes the maximum length.
+	DisplayNameTooLong = "display_name_too_long"
+	EmailRequired      = "email_required"
+	EmailInvalid       = "email_invalid"
+	// EmailTooLong takes the maximum length.
+	EmailTooLong          = "email_too_long"
+	WebhookURLInvalid     = "webhook_url_invalid"
+	WebhookEventsRequired = "webhook_events_required"
+	// WebhookEventUnknown takes the event.
+	WebhookEventUnknown = "webhook_event_unknown"
+)
+
+// Message codes of request parameters.
+const (
+	// MustBeOneOf takes the parameter and the list of values.
+	MustBeOneOf = "must_be_one_of"
+	// MustBeUUID takes the parameter.
+	MustBeUUID = "must_be_uuid"
+	// MustBeBool takes the parameter.
+	MustBeBool = "must_be_bool"
+	// MustBeNonNegative takes the parameter.
+	MustBeNonNegative = "must_be_non_negative"
+	// MustBePositive takes the parameter.
+	MustBePositive = "must_be_positive"
+	// LimitOutOfRange takes the parameter and the maximum.
+	LimitOutOfRange = "limit_out_of_range"
+	// PageSizeOutOfRange takes the maximum.
+	PageSizeOutOfRange = "page_size_out_of_range"
+	InvalidCursor      = "invalid_cursor"
+	InvalidPageToken   = "invalid_page_token"
+	// UnknownQueryParam takes the parameter and the list of known ones.
+	UnknownQueryParam = "unknown_query_param"
+	// RepeatedQueryParam takes the parameter.
+	RepeatedQueryParam = "repeated_query_param"
+	// InvalidQueryTime takes the parameter.
+	InvalidQueryTime         = "invalid_query_time"
+	CreatedAfterNotBefore    = "created_after_not_before"
+	MinAnswersWithUnanswered = "min_answers_with_unanswered"
+	IncludeMustBeAnswers     = "include_must_be_answers"
+	AnswersLimitNeedsInclude = "answers_limit_needs_include"
+	InvalidLastEventID       = "invalid_last_event_id"
+	// InboxLimitOutOfRange takes the parameter and its maximum.
+	InboxLimitOutOfRange = "inbox_limit_out_of_range"
+)
+
+// Message codes of failed requests.
+const (
+	BadRequest          = "bad_request"
+	InvalidRequestBody  = "invalid_request_body"
+	InvalidQuestionID   = "invalid_question_id"
+	InvalidAnswerID     = "invalid_answer_id"
+	MethodNotAllowed    = "method_not_allowed"
+	NotFound            = "not_found"
+	RouteNotFound       = "route_not_found"
+	QuestionNotFound    = "question_not_found"
+	AnswerNotFound      = "answer_not_found"
+	QuestionsNotFound   = "questions_not_found"
+	AnswersNotFound     = "answers_not_found"
+	UnknownUser         = "unknown_user"
+	UserIDMissing       = "user_id_missing"
+	UserSuspended       = "user_suspended"
+	UnknownModerator    = "unknown_moderator"
+	ModeratorIDRequired = "moderator_id_required"
+	ModeratorSuspended  = "moderator_suspended"
+	AlreadyFlagged      = "already_flagged"
+	CaseResolved        = "case_resolved"
+	EmailTaken          = "email_taken"
+	MultipartRequired   = "multipart_required"
+	FileRequired        = "file_required"
+	FileTooLarge        = "file_too_large"
+	// AttachmentTypeNotAllowed takes the sniffed media type.
+	AttachmentTypeNotAllowed = "attachment_type_not_allowed"
+	// ContentRejected takes the Error of the filter that rejected the text.
+	ContentRejected      = "content_rejected"
+	StreamingUnsupported = "streaming_unsupported"
+	StreamFellBehind     = "stream_fell_behind"
+	TooManyConnections   = "too_many_connections"
+	GRPCDisabled         = "grpc_disabled"
+	InvalidToken         = "invalid_token"
+	AdminDisabled        = "admin_disabled"
+	Unauthorized         = "unauthorized"
+	InternalError        = "internal_error"
+)
+
+// Message codes of v2 and gRPC errors that v1 words differently: v2 and
+// gRPC messages start in lower case.
+const (
+	V2InvalidRequestBody = "v2_invalid_request_body"
+	V2MethodNotAllowed   = "v2_method_not_allowed"
+	V2QuestionNotFound   = "v2_question_not_found"
+	V2UnknownUser        = "v2_unknown_user"
+	V2UserSuspended      = "v2_user_suspended"
+
+	V2FailedToGetQuestions   = "v2_failed_to_get_questions"
+	V2FailedToGetQuestion    = "v2_failed_to_get_question"
+	V2FailedToCreateQuestion = "v2_failed_to_create_question"
+	V2FailedToDeleteQuestion = "v2_failed_to_delete_question"
+	V2FailedToGetAnswers     = "v2_failed_to_get_answers"
+	V2FailedToGetAnswer      = "v2_failed_to_get_answer"
+	V2FailedToCreateAnswer   = "v2_failed_to_create_answer"
+	V2FailedToDeleteAnswer   = "v2_failed_to_delete_answer"
+)
+
+// Message codes of requests that failed on the server side.
+const (
+	FailedToGetQuestions          = "failed_to_get_questions"
+	FailedToGetQuestion           = "failed_to_get_question"
+	FailedToCreateQuestion        = "failed_to_create_question"
+	FailedToDeleteQuestion        = "failed_to_delete_question"
+	FailedToGetAnswers            = "failed_to_get_answers"
+	FailedToGetAnswer             = "failed_to_get_answer"
+	FailedToCreateAnswer          = "failed_to_create_answer"
+	FailedToDeleteAnswer          = "failed_to_delete_answer"
+	FailedToGetUser               = "failed_to_get_user"
+	FailedToGetUserStats          = "failed_to_get_user_stats"
+	FailedToRegisterUser          = "failed_to_register_user"
+	FailedToUpdateUser            = "failed_to_update_user"
+	FailedToGetReputation         = "failed_to_get_reputation"
+	FailedToUploadFile            = "failed_to_upload_file"
+	FailedToGetAttachment         = "failed_to_get_attachment"
+	FailedToFlagQuestion          = "failed_to_flag_question"
+	FailedToFlagAnswer            = "failed_to_flag_answer"
+	FailedToGetModerationQueue    = "failed_to_get_moderation_queue"
+	FailedToResolveCase           = "failed_to_resolve_case"
+	FailedToFollowQuestion        = "failed_to_follow_question"
+	FailedToUnfollowQuestion      = "failed_to_unfollow_question"
+	FailedToGetNotifications      = "failed_to_get_notifications"
+	FailedToMarkNotificationRead  = "failed_to_mark_notification_read"
+	FailedToMarkNotificationsRead = "failed_to_mark_notifications_read"
+	FailedToGetWebhooks           = "failed_to_get_webhooks"
+	FailedToGetWebhook            = "failed_to_get_webhook"
+	FailedToCreateWebhook         = "failed_to_create_webhook"
+	FailedToDeleteWebhook         = "failed_to_delete_webhook"
+	FailedToGetDeliveries         = "failed_to_get_deliveries"
+	FailedToRedeliver             = "failed_to_redeliver"
+)
+
+// Message codes of the reasons content filters give. They complete
+// ContentRejected.
+const (
+	// FilterBannedWord takes the word.
+	FilterBannedWord = "filter_banned_word"
+	// FilterLinks takes the number of links allowed.
+	FilterLinks = "filter_links"
+	FilterCaps  = "filter_caps"
+	// FilterRepeatedChars takes the character and the number of repeats
+	// allowed.
+	FilterRepeatedChars = "filter_repeated_chars"
+)
+
+// Codes lists every message code. Each one has a message in every locale.
+var Codes = []string{
+	TextRequired, TextTooShort, TextTooLong, UserIDRequired,
+	DisplayNameRequired, DisplayNameTooShort, DisplayNameTooLong,
+	EmailRequired, EmailInvalid, EmailTooLong,
+	WebhookURLInvalid, WebhookEventsRequired, WebhookEventUnknown,
+
+	MustBeOneOf, MustBeUUID, MustBeBool, MustBeNonNegative, MustBePositive,
+	LimitOutOfRange, PageSizeOutOfRange, InvalidCursor, InvalidPageToken,
+	UnknownQueryParam, RepeatedQueryParam, InvalidQueryTime, CreatedAfterNotBefore,
+	MinAnswersWithUnanswered, IncludeMustBeAnswers, AnswersLimitNeedsInclude, InvalidLastEventID,
+	InboxLimitOutOfRange,
+
+	BadRequest, InvalidRequestBody, InvalidQuestionID, InvalidAnswerID, MethodNotAllowed,
+	NotFound, RouteNotFound, QuestionNotFound, AnswerNotFound, QuestionsNotFound, AnswersNotFound,
+	UnknownUser, UserIDMissing, UserSuspended,
+	UnknownModerator, ModeratorIDRequired, ModeratorSuspended, AlreadyFlagged, CaseResolved,
+	EmailTaken, MultipartRequired, FileRequired, FileTooLarge, AttachmentTypeNotAllowed,
+	ContentRejected, StreamingUnsupported, StreamFellBehind, TooManyConnections,
+	GRPCDisabled, InvalidToken, AdminDisabled, Unauthorized, InternalError,
+
+	V2InvalidRequestBody, V2MethodNotAllowed, V2QuestionNotFound, V2UnknownUser, V2UserSuspended,
+	V2FailedToGetQuestions, V2FailedToGetQuestion, V2FailedToCreateQuestion, V2FailedToDeleteQuestion,
+	V2FailedToGetAnswers, V2FailedToGetAnswer, V2FailedToCreateAnswer, V2FailedToDeleteAnswer,
+
+	FailedToGetQuestions, FailedToGetQuestion, FailedToCreateQuestion, FailedToDeleteQuestion,
+	FailedToGetAnswers, FailedToGetAnswer, FailedToCreateAnswer, FailedToDeleteAnswer,
+	FailedToGetUser, FailedToGetUserStats, FailedToRegisterUser, FailedToUpdateUser,
+	FailedToGetReputation, FailedToUploadFile, FailedToGetAttachment, FailedToFlagQuestion, FailedToFlagAnswer,
+	FailedToGetModerationQueue, FailedToResolveCase, FailedToFollowQuestion, FailedToUnfollowQuestion,
+	FailedToGetNotifications, FailedToMarkNotificationRead, FailedToMarkNotificationsRead,
+	FailedToGetWebhooks, FailedToGetWebhook, FailedToCreateWebhook, FailedToDeleteWebhook,
+	FailedToGetDeliveries, FailedToRedeliver,
+
+	FilterBannedWord, FilterLinks, FilterCaps, FilterRepeatedChars,
+}
+
+// russianCount picks the form of a noun agreeing with the count in
+// parameter arg of format: "1 символа", "5 символов".
+func russianCount(arg int, format, one, other string) catalog.Message {
+	return plural.Selectf(arg, "%d",
+		"one", fmt.Sprintf(format, one),
+		"other", fmt.Sprintf(format, other),
+	)
+}
+
+// characters is russianCount for lengths in the first parameter.
+func characters(format string) catalog.Message {
+	return russianCount(1, format, "символа", "символов")
+}
+
+// messages holds the message of each code in each of Locales.
+var messages = map[language.Tag]map[string]catalog.Message{
+	Locales[0]: {
+		TextRequired:          catalog.String("text is required"),
+		TextTooShort:          catalog.String("text must be at least %d characters"),
+		TextTooLong:           catalog.String("text cannot exceed %d characters"),
+		UserIDRequired:        catalog.String("user id required"),
+		DisplayNameRequired:   catalog.String("display name is required"),
+		DisplayNameTooShort:   catalog.String("display name must be at least %d characters"),
+		DisplayNameTooLong:    catalog.String("display name cannot exceed %d characters"),
+		EmailRequired:         catalog.String("email is required"),
+		EmailInvalid:          catalog.String("email is not a valid address"),
+		EmailTooLong:          catalog.String("email cannot exceed %d characters"),
+		WebhookURLInvalid:     catalog.String("url must be an absolute http or https URL"),
+		WebhookEventsRequired: catalog.String("at least one event is required"),
+		WebhookEventUnknown:   catalog.String("unknown event %s"),
+
+		MustBeOneOf:              catalog.String("%s must be one of %s"),
+		MustBeUUID:               catalog.String("%s must be a UUID"),
+		MustBeBool:               catalog.String("%s must be true or false"),
+		MustBeNonNegative:        catalog.String("%s must be a non-negative integer"),
+		MustBePositive:           catalog.String("%s must be positive"),
+		LimitOutOfRange:          catalog.String("%s must be an integer between 1 and %d"),
+		PageSizeOutOfRange:       catalog.String("page_size must be between 0 and %d"),
+		InvalidCursor:            catalog.String("after must be a cursor returned by a previous page"),
+		InvalidPageToken:         catalog.String("invalid page_token"),
+		UnknownQueryParam:        catalog.String("unknown query parameter %q, expected one of %s"),
+		RepeatedQueryParam:       catalog.String("query parameter %q must be given once"),
+		InvalidQueryTime:         catalog.String("%s must be an RFC 3339 time or a YYYY-MM-DD date"),
+		CreatedAfterNotBefore:    catalog.String("created_after must be before created_before"),
+		MinAnswersWithUnanswered: catalog.String("min_answers cannot be combined with unanswered=true"),
+		IncludeMustBeAnswers:     catalog.String(`include must be "answers"`),
+		AnswersLimitNeedsInclude: catalog.String("answers_limit requires include=answers"),
+		InvalidLastEventID:       catalog.String("Invalid Last-Event-ID"),
+		InboxLimitOutOfRange:     catalog.String("%s must be between 1 and %d"),
+
+		BadRequest:               catalog.String("Bad request"),
+		InvalidRequestBody:       catalog.String("Invalid request body"),
+		InvalidQuestionID:        catalog.String("invalid question id"),
+		InvalidAnswerID:          catalog.String("invalid answer id"),
+		MethodNotAllowed:         catalog.String("Method not allowed"),
+		NotFound:                 catalog.String("Not found"),
+		RouteNotFound:            catalog.String("route not found"),
+		QuestionNotFound:         catalog.String("Question not found"),
+		AnswerNotFound:           catalog.String("answer not found"),
+		QuestionsNotFound:        catalog.String("questions not found"),
+		AnswersNotFound:          catalog.String("answers not found"),
+		UnknownUser:              catalog.String("Unknown user"),
+		UserIDMissing:            catalog.String("user_id is required"),
+		UserSuspended:            catalog.String("User is suspended"),
+		UnknownModerator:         catalog.String("Unknown moderator"),
+		ModeratorIDRequired:      catalog.String("moderator id required"),
+		ModeratorSuspended:       catalog.String("Moderator is suspended"),
+		AlreadyFlagged:           catalog.String("Already flagged by this user"),
+		CaseResolved:             catalog.String("Case is already resolved"),
+		EmailTaken:               catalog.String("Email is already registered"),
+		MultipartRequired:        catalog.String("multipart/form-data body required"),
+		FileRequired:             catalog.String("file field required"),
+		FileTooLarge:             catalog.String("File too large"),
+		AttachmentTypeNotAllowed: catalog.String("attachment type not allowed: %s"),
+		ContentRejected:          catalog.String("content rejected: text %s"),
+		StreamingUnsupported:     catalog.String("Streaming unsupported"),
+		StreamFellBehind:         catalog.String("stream fell behind, resume with the last event id"),
+		TooManyConnections:       catalog.String("Too many connections"),
+		GRPCDisabled:             catalog.String("gRPC API is disabled"),
+		InvalidToken:             catalog.String("missing or invalid token"),
+		AdminDisabled:            catalog.String("Admin API is disabled"),
+		Unauthorized:             catalog.String("Unauthorized"),
+		InternalError:            catalog.String("internal error"),
+
+		V2InvalidRequestBody:     catalog.String("invalid request body"),
+		V2MethodNotAllowed:       catalog.String("method not allowed"),
+		V2QuestionNotFound:       catalog.String("question not found"),
+		V2UnknownUser:            catalog.String("user_id is not a registered user"),
+		V2UserSuspended:          catalog.String("user is suspended"),
+		V2FailedToGetQuestions:   catalog.String("failed to get questions"),
+		V2FailedToGetQuestion:    catalog.String("failed to get question"),
+		V2FailedToCreateQuestion: catalog.String("failed to create question"),
+		V2FailedToDeleteQuestion: catalog.String("failed to delete question"),
+		V2FailedToGetAnswers:     catalog.String("failed to get answers"),
+		V2FailedToGetAnswer:      catalog.String("failed to get answer"),
+		V2FailedToCreateAnswer:   catalog.String("failed to create answer"),
+		V2FailedToDeleteAnswer:   catalog.String("failed to delete answer"),
+
+		FailedToGetQuestions:          catalog.String("Failed to get questions"),
+		FailedToGetQuestion:           catalog.String("Failed to get question"),
+		FailedToCreateQuestion:        catalog.String("Failed to create question"),
+		FailedToDeleteQuestion:        catalog.String("Failed to deleted question"),
+		FailedToGetAnswers:            catalog.String("Failed to get answers"),
+		FailedToGetAnswer:             catalog.String("Failed to get answer"),
+		FailedToCreateAnswer:          catalog.String("Failed to create answer"),
+		FailedToDeleteAnswer:          catalog.String("Failed to deleted answer"),
+		FailedToGetUser:               catalog.String("Failed to get user"),
+		FailedToGetUserStats:          catalog.String("Failed to get user stats"),
+		FailedToRegisterUser:          catalog.String("Failed to register user"),
+		FailedToUpdateUser:            catalog.String("Failed to update user"),
+		FailedToGetReputation:         catalog.String("Failed to get reputation"),
+		FailedToUploadFile:            catalog.String("Failed to upload file"),
+		FailedToGetAttachment:         catalog.String("Failed to get attachment"),
+		FailedToFlagQuestion:          catalog.String("Failed to flag question"),
+		FailedToFlagAnswer:            catalog.String("Failed to flag answer"),
+		FailedToGetModerationQueue:    catalog.String("Failed to get moderation queue"),
+		FailedToResolveCase:           catalog.String("Failed to resolve moderation case"),
+		FailedToFollowQuestion:        catalog.String("Failed to follow question"),
+		FailedToUnfollowQuestion:      catalog.String("Failed to unfollow question"),
+		FailedToGetNotifications:      catalog.String("Failed to get notifications"),
+		FailedToMarkNotificationRead:  catalog.String("Failed to mark notification as read"),
+		FailedToMarkNotificationsRead: catalog.String("Failed to mark notifications as read"),
+		FailedToGetWebhooks:           catalog.String("Failed to get webhooks"),
+		FailedToGetWebhook:            catalog.String("Failed to get webhook"),
+		FailedToCreateWebhook:         catalog.String("Failed to create webhook"),
+		FailedToDeleteWebhook:         catalog.String("Failed to delete webhook"),
+		FailedToGetDeliveries:         catalog.String("Failed to get deliveries"),
+		FailedToRedeliver:             catalog.String("Failed to redeliver"),
+
+		FilterBannedWord:    catalog.String("contains the banned word %q"),
+		FilterLinks:         catalog.String("contains more than %d links"),
+		FilterCaps:          catalog.String("is written in capital letters"),
+		FilterRepeatedChars: catalog.String("repeats %q more than %d times"),
+	},
+	Locales[1]: {
+		TextRequired:          catalog.String("текст обязателен"),
+		TextTooShort:          characters("текст должен быть не короче %%d %s"),
+		TextTooLong:           characters("текст должен быть не длиннее %%d %s"),
+		UserIDRequired:        catalog.String("не указан id пользователя"),
+		DisplayNameRequired:   catalog.String("имя обязательно"),
+		DisplayNameTooShort:   characters("имя должно быть не короче %%d %s"),
+		DisplayNameTooLong:    characters("имя должно быть не длиннее %%d %s"),
+		EmailRequired:         catalog.String("email обязателен"),
+		EmailInvalid:          catalog.String("email не является корректным адресом"),
+		EmailTooLong:          characters("email должен быть не длиннее %%d %s"),
+		WebhookURLInvalid:     catalog.String("url должен быть абсолютным http- или https-адресом"),
+		WebhookEventsRequired: catalog.String("нужно указать хотя бы одно событие"),
+		WebhookEventUnknown:   catalog.String("неизвестное событие %s"),
+
+		MustBeOneOf:              catalog.String("%s должен быть одним из: %s"),
+		MustBeUUID:               catalog.String("%s должен быть UUID"),
+		MustBeBool:               catalog.String("%s должен быть true или false"),
+		MustBeNonNegative:        catalog.String("%s должен быть неотрицательным целым числом"),
+		MustBePositive:           catalog.String("%s должен быть положительным"),
+		LimitOutOfRange:          catalog.String("%s должен быть целым числом от 1 до %d"),
+		PageSizeOutOfRange:       catalog.String("page_size должен быть от 0 до %d"),
+		InvalidCursor:            catalog.String("after должен быть курсором, полученным с предыдущей страницей"),
+		InvalidPageToken:         catalog.String("некорректный page_token"),
+		UnknownQueryParam:        catalog.String("неизвестный параметр запроса %q, ожидается один из: %s"),
+		RepeatedQueryParam:       catalog.String("параметр запроса %q можно указать только один раз"),
+		InvalidQueryTime:         catalog.String("%s должен быть временем в формате RFC 3339 или датой ГГГГ-ММ-ДД"),
+		CreatedAfterNotBefore:    catalog.String("created_after должен быть раньше created_before"),
+		MinAnswersWithUnanswered: catalog.String("min_answers нельзя сочетать с unanswered=true"),
+		IncludeMustBeAnswers:     catalog.String(`include должен быть "answers"`),
+		AnswersLimitNeedsInclude: catalog.String("answers_limit требует include=answers"),
+		InvalidLastEventID:       catalog.String("некорректный Last-Event-ID"),
+		InboxLimitOutOfRange:     catalog.String("%s должен быть от 1 до %d"),
+
+		BadRequest:               catalog.String("некорректный запрос"),
+		InvalidRequestBody:       catalog.String("некорректное тело запроса"),
+		InvalidQuestionID:        catalog.String("некорректный id вопроса"),
+		InvalidAnswerID:          catalog.String("некорректный id ответа"),
+		MethodNotAllowed:         catalog.String("метод не поддерживается"),
+		NotFound:                 catalog.String("не найдено"),
+		RouteNotFound:            catalog.String("маршрут не найден"),
+		QuestionNotFound:         catalog.String("вопрос не найден"),
+		AnswerNotFound:           catalog.String("ответ не найден"),
+		QuestionsNotFound:        catalog.String("вопросы не найдены"),
+		AnswersNotFound:          catalog.String("ответы не найдены"),
+		UnknownUser:              catalog.String("неизвестный пользователь"),
+		UserIDMissing:            catalog.String("user_id обязателен"),
+		UserSuspended:            catalog.String("пользователь заблокирован"),
+		UnknownModerator:         catalog.String("неизвестный модератор"),
+		ModeratorIDRequired:      catalog.String("не указан id модератора"),
+		ModeratorSuspended:       catalog.String("модератор заблокирован"),
+		AlreadyFlagged:           catalog.String("пользователь уже пожаловался на это"),
+		CaseResolved:             catalog.String("жалоба уже рассмотрена"),
+		EmailTaken:               catalog.String("email уже зарегистрирован"),
+		MultipartRequired:        catalog.String("нужно тело multipart/form-data"),
+		FileRequired:             catalog.String("нужно поле file"),
+		FileTooLarge:             catalog.String("файл слишком большой"),
+		AttachmentTypeNotAllowed: catalog.String("тип вложения не разрешён: %s"),
+		ContentRejected:          catalog.String("содержимое отклонено: текст %s"),
+		StreamingUnsupported:     catalog.String("потоковая передача не поддерживается"),
+		StreamFellBehind:         catalog.String("поток отстал, продолжите с id последнего события"),
+		TooManyConnections:       catalog.String("слишком много соединений"),
+		GRPCDisabled:             catalog.String("gRPC API отключён"),
+		InvalidToken:             catalog.String("токен не указан или неверен"),
+		AdminDisabled:            catalog.String("админ-API отключён"),
+		Unauthorized:             catalog.String("требуется авторизация"),
+		InternalError:            catalog.String("внутренняя ошибка"),
+
+		V2InvalidRequestBody:     catalog.String("некорректное тело запроса"),
+		V2MethodNotAllowed:       catalog.String("метод не поддерживается"),
+		V2QuestionNotFound:       catalog.String("вопрос не найден"),
+		V2UnknownUser:            catalog.String("user_id не принадлежит зарегистрированному пользователю"),
+		V2UserSuspended:          catalog.String("пользователь заблокирован"),
+		V2FailedToGetQuestions:   catalog.String("не удалось получить вопросы"),
+		V2FailedToGetQuestion:    catalog.String("не удалось получить вопрос"),
+		V2FailedToCreateQuestion: catalog.String("не удалось создать вопрос"),
+		V2FailedToDeleteQuestion: catalog.String("не удалось удалить вопрос"),
+		V2FailedToGetAnswers:     catalog.String("не удалось получить ответы"),
+		V2FailedToGetAnswer:      catalog.String("не удалось получить ответ"),
+		V2FailedToCreateAnswer:   catalog.String("не удалось создать ответ"),
+		V2FailedToDeleteAnswer:   catalog.String("не удалось удалить ответ"),
+
+		FailedToGetQuestions:          catalog.String("не удалось получить вопросы"),
+		FailedToGetQuestion:           catalog.String("не удалось получить вопрос"),
+		FailedToCreateQuestion:        catalog.String("не удалось создать вопрос"),
+		FailedToDeleteQuestion:        catalog.String("не удалось удалить вопрос"),
+		FailedToGetAnswers:            catalog.String("не удалось получить ответы"),
+		FailedToGetAnswer:             catalog.String("не удалось получить ответ"),
+		FailedToCreateAnswer:          catalog.String("не удалось создать ответ"),
+		FailedToDeleteAnswer:          catalog.String("не удалось удалить ответ"),
+		FailedToGetUser:               catalog.String("не удалось получить пользователя"),
+		FailedToGetUserStats:          catalog.String("не удалось получить статистику пользователя"),
+		FailedToRegisterUser:          catalog.String("не удалось зарегистрировать пользователя"),
+		FailedToUpdateUser:            catalog.String("не удалось обновить пользователя"),
+		FailedToGetReputation:         catalog.String("не удалось получить репутацию"),
+		FailedToUploadFile:            catalog.String("не удалось загрузить файл"),
+		FailedToGetAttachment:         catalog.String("не удалось получить вложение"),
+		FailedToFlagQuestion:          catalog.String("не удалось отправить жалобу на вопрос"),
+		FailedToFlagAnswer:            catalog.String("не удалось отправить жалобу на ответ"),
+		FailedToGetModerationQueue:    catalog.String("не удалось получить очередь модерации"),
+		FailedToResolveCase:           catalog.String("не удалось рассмотреть жалобу"),
+		FailedToFollowQuestion:        catalog.String("не удалось подписаться на вопрос"),
+		FailedToUnfollowQuestion:      catalog.String("не удалось отписаться от вопроса"),
+		FailedToGetNotifications:      catalog.String("не удалось получить уведомления"),
+		FailedToMarkNotificationRead:  catalog.String("не удалось отметить уведомление прочитанным"),
+		FailedToMarkNotificationsRead: catalog.String("не удалось отметить уведомления прочитанными"),
+		FailedToGetWebhooks:           catalog.String("не удалось получить вебхуки"),
+		FailedToGetWebhook:            catalog.String("не удалось получить вебхук"),
+		FailedToCreateWebhook:         catalog.String("не удалось создать вебхук"),
+		FailedToDeleteWebhook:         catalog.String("не удалось удалить вебхук"),
+		FailedToGetDeliveries:         catalog.String("не удалось получить доставки"),
+		FailedToRedeliver:             catalog.String("не удалось повторить доставку"),
+
+		FilterBannedWord:    catalog.String("содержит запрещённое слово %q"),
+		FilterLinks:         russianCount(1, "содержит больше %%d %s", "ссылки", "ссылок"),
+		FilterCaps:          catalog.String("написан заглавными буквами"),
+		FilterRepeatedChars: russianCount(2, "повторяет %%q больше %%d %s", "раза", "раз"),
+	},
+}
+
+var messageCatalog = newCatalog()
+
+func newCatalog() *catalog.Builder {
+	builder := catalog.NewBuilder(catalog.Fallback(Locales[0]))
+	for locale, codes := range messages {
+		for code, message := range codes {
+			if err := builder.Set(locale, code, message); err != nil {
+				panic(fmt.Sprintf("i18n: message %s of %s: %v", code, locale, err))
+			}
+		}
+	}
+	return builder
+}
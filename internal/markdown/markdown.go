@@ -0,0 +1,37 @@
+// Package markdown renders the Markdown text of questions and answers to
+// HTML that is safe to embed in a page.
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+)
+
+var (
+	// The CommonMark renderer leaves raw HTML out of its output; the
+	// sanitiser still checks everything it produces.
+	renderer = goldmark.New()
+	policy   = newPolicy()
+)
+
+// newPolicy allows the elements user content may use, keeps the language
+// class of fenced code blocks and adds rel="nofollow" to links.
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	return policy
+}
+
+// Render converts text from CommonMark to sanitised HTML.
+func Render(text string) string {
+	var out bytes.Buffer
+	err := renderer.Convert([]byte(text), &out)
+	if err != nil {
+		return "<p>" + html.EscapeString(text) + "</p>"
+	}
+	return policy.Sanitize(out.String())
+}
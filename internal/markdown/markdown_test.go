@@ -0,0 +1,29 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"paragraph", "How do I *stop* a goroutine?", "<p>How do I <em>stop</em> a goroutine?</p>\n"},
+		{"code block", "```go\nclose(done)\n```", "<pre><code class=\"language-go\">close(done)\n</code></pre>\n"},
+		{"inline code", "Use `context.Context`", "<p>Use <code>context.Context</code></p>\n"},
+		{"link", "[Go](https://go.dev)", "<p><a href=\"https://go.dev\" rel=\"nofollow\">Go</a></p>\n"},
+		{"raw html", "<script>alert(1)</script>\n\nHi <b onclick=\"x\">there</b>", "\n<p>Hi there</p>\n"},
+		{"script link", "[click](javascript:alert(1))", "<p>click</p>\n"},
+		{"escaped text", "a < b && c > d", "<p>a &lt; b &amp;&amp; c &gt; d</p>\n"},
+		{"bad code class", "```go\" onclick=\"x\nx\n```", "<pre><code>x\n</code></pre>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.text))
+		})
+	}
+}
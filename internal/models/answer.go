@@ -1,9 +1,10 @@
 package models
 
 import (
-	"errors"
+	"api_service_questions_and_answers/internal/i18n"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -12,23 +13,30 @@ type Answer struct {
 	ID         int       `json:"id" gorm:"primary_key"`
 	QuestionID uint      `json:"question_id" gorm:"not null"`
 	UserID     uuid.UUID `json:"user_id" gorm:"not null"`
-	Text       string    `json:"text" gorm:"not null"`
-	CreatedAt  time.Time `json:"created_at"`
+	// Text is Markdown. TextHTML is Text rendered to sanitised HTML when
+	// the answer is created.
+	Text      string    `json:"text" gorm:"not null"`
+	TextHTML  string    `json:"text_html,omitempty" gorm:"not null;default:''"`
+	CreatedAt time.Time `json:"created_at"`
+	// Hidden is set when flags or a content filter hide the answer until a
+	// moderator decides. Hidden answers are left out of public reads.
+	Hidden bool `json:"hidden,omitempty" gorm:"not null;default:false"`
 }
 
+// Validate returns an *i18n.Error for invalid answers.
 func (r *Answer) Validate() error {
 	text := strings.TrimSpace(r.Text)
 	if text == "" {
-		return errors.New("text is required")
+		return i18n.NewError(i18n.TextRequired)
 	}
-	if len(text) < 5 {
-		return errors.New("text must be at least 5 characters")
+	if utf8.RuneCountInString(text) < minTextLength {
+		return i18n.NewError(i18n.TextTooShort, minTextLength)
 	}
-	if len(text) > 1000 {
-		return errors.New("text cannot exceed 1000 characters")
+	if utf8.RuneCountInString(text) > maxTextLength {
+		return i18n.NewError(i18n.TextTooLong, maxTextLength)
 	}
 	if r.UserID == uuid.Nil {
-		return errors.New("user id required")
+		return i18n.NewError(i18n.UserIDRequired)
 	}
 	return nil
 }
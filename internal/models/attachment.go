@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a question or to one of its answers.
+// The file itself is kept in the blob store under BlobKey.
+type Attachment struct {
+	ID         int  `json:"id" gorm:"primary_key"`
+	QuestionID uint `json:"question_id" gorm:"not null"`
+	// AnswerID is set when the file belongs to an answer of the question.
+	AnswerID *uint  `json:"answer_id,omitempty"`
+	Filename string `json:"filename" gorm:"not null"`
+	// ContentType is sniffed from the file when it is uploaded.
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	SHA256      string    `json:"sha256" gorm:"column:sha256;not null"`
+	BlobKey     string    `json:"-" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	// URL is where the file is served, set by the handlers.
+	URL string `json:"url,omitempty" gorm:"-"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of content that can be flagged.
+const (
+	ContentQuestion = "question"
+	ContentAnswer   = "answer"
+)
+
+// Flag reasons.
+const (
+	FlagSpam     = "spam"
+	FlagAbusive  = "abusive"
+	FlagOffTopic = "off_topic"
+	FlagOther    = "other"
+)
+
+// FlagReasons lists the reason codes a flag can have.
+var FlagReasons = []string{FlagSpam, FlagAbusive, FlagOffTopic, FlagOther}
+
+// Moderation case statuses. A case is open until a moderator approves the
+// content, removes it or dismisses the flags.
+const (
+	CaseOpen      = "open"
+	CaseApproved  = "approved"
+	CaseRemoved   = "removed"
+	CaseDismissed = "dismissed"
+)
+
+// Moderation actions, keyed to the status they resolve a case with.
+var ModerationActions = map[string]string{
+	"approve": CaseApproved,
+	"remove":  CaseRemoved,
+	"dismiss": CaseDismissed,
+}
+
+// ModerationCase collects the flags raised against a piece of content, or
+// holds content a content filter flagged, until a moderator resolves it.
+// Content has at most one open case; flags raised after a resolution open
+// a new one.
+type ModerationCase struct {
+	ID          int    `json:"id" gorm:"primary_key"`
+	ContentType string `json:"content_type" gorm:"not null"`
+	ContentID   uint   `json:"content_id" gorm:"not null"`
+	Status      string `json:"status" gorm:"not null"`
+	FlagCount   int    `json:"flag_count" gorm:"not null;default:0"`
+	// ReviewReason says why a content filter held new content for review.
+	// Such cases can have no flags.
+	ReviewReason string     `json:"review_reason,omitempty" gorm:"not null;default:''"`
+	ModeratorID  *uuid.UUID `json:"moderator_id,omitempty"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Flags        []Flag     `json:"flags,omitempty" gorm:"foreignKey:CaseID;constraint:OnDelete:CASCADE;"`
+	// Question or Answer is the flagged content, loaded for the moderation
+	// queue. Both are nil once the content has been deleted.
+	Question *Question `json:"question,omitempty" gorm:"-"`
+	Answer   *Answer   `json:"answer,omitempty" gorm:"-"`
+}
+
+// Flag is a report of a piece of content by a user. A user can flag
+// content once per case.
+type Flag struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	CaseID    int       `json:"case_id" gorm:"not null"`
+	UserID    uuid.UUID `json:"user_id" gorm:"not null"`
+	Reason    string    `json:"reason" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ValidFlagReason(reason string) bool {
+	return slices.Contains(FlagReasons, reason)
+}
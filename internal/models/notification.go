@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const NotificationNewAnswer = "answer.created"
+
+// Follow subscribes a user to the notifications of a question.
+type Follow struct {
+	QuestionID uint      `json:"question_id" gorm:"primaryKey"`
+	UserID     uuid.UUID `json:"user_id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (Follow) TableName() string {
+	return "question_follows"
+}
+
+type Notification struct {
+	ID         int        `json:"id" gorm:"primary_key"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"not null"`
+	Type       string     `json:"type" gorm:"not null"`
+	QuestionID uint       `json:"question_id" gorm:"not null"`
+	AnswerID   uint       `json:"answer_id" gorm:"not null"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutboxPending = "pending"
+	OutboxDone    = "done"
+	OutboxFailed  = "failed"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the
+// change it describes and dispatched later by the outbox relay.
+type OutboxEvent struct {
+	ID         int       `json:"id" gorm:"primary_key"`
+	Type       string    `json:"type" gorm:"not null"`
+	QuestionID uint      `json:"question_id" gorm:"not null"`
+	UserID     uuid.UUID `json:"user_id" gorm:"not null"`
+	Payload    string    `json:"payload" gorm:"not null"`
+	Status     string    `json:"status" gorm:"not null"`
+	Attempts   int       `json:"attempts" gorm:"not null"`
+	LastError  string    `json:"last_error,omitempty"`
+	// DeliveredTo lists the sinks that already accepted the event, so a
+	// retry only goes to the ones that failed.
+	DeliveredTo []string   `json:"delivered_to" gorm:"serializer:json;not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	// ClaimedUntil hides the event from other relays while one of them
+	// dispatches it.
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+}
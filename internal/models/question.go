@@ -1,28 +1,52 @@
 package models
 
 import (
-	"errors"
+	"api_service_questions_and_answers/internal/i18n"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
 )
 
 type Question struct {
-	ID        int       `json:"id" gorm:"primary_key"`
-	Text      string    `json:"text" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	Answers   []Answer  `json:"answers,omitempty" gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE;"`
+	ID int `json:"id" gorm:"primary_key"`
+	// Text is Markdown. TextHTML is Text rendered to sanitised HTML when
+	// the question is created.
+	Text     string `json:"text" gorm:"not null"`
+	TextHTML string `json:"text_html,omitempty" gorm:"not null;default:''"`
+	// UserID is the asker. Questions created before authors were recorded
+	// have none.
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	// AnswerCount and LastActivityAt are kept up to date by AnswerService.
+	// LastActivityAt is the time of the newest answer, or CreatedAt while
+	// the question has none.
+	AnswerCount    int       `json:"answer_count" gorm:"not null;default:0"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	// Hidden is set when flags or a content filter hide the question until
+	// a moderator decides. Hidden questions are left out of public reads.
+	Hidden  bool     `json:"hidden,omitempty" gorm:"not null;default:false"`
+	Answers []Answer `json:"answers,omitempty" gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE;"`
 }
 
+// Lengths of the text of questions and answers.
+const (
+	minTextLength = 5
+	maxTextLength = 1000
+)
+
+// Validate returns an *i18n.Error for invalid questions.
 func (r *Question) Validate() error {
 	text := strings.TrimSpace(r.Text)
 	if text == "" {
-		return errors.New("text is required")
+		return i18n.NewError(i18n.TextRequired)
 	}
-	if len(text) < 5 {
-		return errors.New("text must be at least 5 characters")
+	if utf8.RuneCountInString(text) < minTextLength {
+		return i18n.NewError(i18n.TextTooShort, minTextLength)
 	}
-	if len(text) > 1000 {
-		return errors.New("text cannot exceed 1000 characters")
+	if utf8.RuneCountInString(text) > maxTextLength {
+		return i18n.NewError(i18n.TextTooLong, maxTextLength)
 	}
 	return nil
 }
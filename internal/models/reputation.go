@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reputation reasons. The points of answer_posted come from the reputation
+// rules in the config; answer_deleted takes back what the answer earned.
+const (
+	ReputationAnswerPosted  = "answer_posted"
+	ReputationAnswerDeleted = "answer_deleted"
+)
+
+// ReputationEvent is an entry of the reputation ledger. A user's
+// reputation is the sum of the points of their entries. Entries are never
+// changed or removed, so they keep the ids of deleted answers.
+type ReputationEvent struct {
+	ID         int       `json:"id" gorm:"primary_key"`
+	UserID     uuid.UUID `json:"user_id" gorm:"not null"`
+	Reason     string    `json:"reason" gorm:"not null"`
+	Points     int       `json:"points" gorm:"not null"`
+	QuestionID uint      `json:"question_id" gorm:"not null"`
+	AnswerID   uint      `json:"answer_id" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
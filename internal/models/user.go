@@ -0,0 +1,71 @@
+package models
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserActive    = "active"
+	UserSuspended = "suspended"
+)
+
+// UserStatuses lists the statuses a user can have. Suspended users cannot
+// answer questions.
+var UserStatuses = []string{UserActive, UserSuspended}
+
+type User struct {
+	ID          uuid.UUID `json:"id" gorm:"primaryKey"`
+	DisplayName string    `json:"display_name" gorm:"not null"`
+	// Email is unique and stored lower-cased. It is only returned on
+	// registration. Users created for answers written before registration
+	// existed have none.
+	Email  *string `json:"-" gorm:"unique"`
+	Status string  `json:"status" gorm:"not null"`
+	// Reputation is the sum of the user's reputation ledger, kept up to
+	// date with every entry.
+	Reputation int       `json:"reputation" gorm:"not null;default:0"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Lengths of display names and emails.
+const (
+	minDisplayNameLength = 2
+	maxDisplayNameLength = 50
+	maxEmailLength       = 254
+)
+
+// Validate returns an *i18n.Error for invalid users.
+func (r *User) Validate() error {
+	name := strings.TrimSpace(r.DisplayName)
+	if name == "" {
+		return i18n.NewError(i18n.DisplayNameRequired)
+	}
+	if utf8.RuneCountInString(name) < minDisplayNameLength {
+		return i18n.NewError(i18n.DisplayNameTooShort, minDisplayNameLength)
+	}
+	if utf8.RuneCountInString(name) > maxDisplayNameLength {
+		return i18n.NewError(i18n.DisplayNameTooLong, maxDisplayNameLength)
+	}
+	if r.Email == nil || strings.TrimSpace(*r.Email) == "" {
+		return i18n.NewError(i18n.EmailRequired)
+	}
+	email := strings.TrimSpace(*r.Email)
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return i18n.NewError(i18n.EmailInvalid)
+	}
+	if utf8.RuneCountInString(email) > maxEmailLength {
+		return i18n.NewError(i18n.EmailTooLong, maxEmailLength)
+	}
+	return nil
+}
+
+func ValidUserStatus(status string) bool {
+	return slices.Contains(UserStatuses, status)
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"net/url"
+	"time"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookEvents lists the events a webhook can subscribe to.
+var WebhookEvents = []string{"question.created", "question.deleted", "answer.created", "answer.deleted"}
+
+type Webhook struct {
+	ID        int       `json:"id" gorm:"primary_key"`
+	URL       string    `json:"url" gorm:"not null"`
+	Secret    string    `json:"-" gorm:"not null"`
+	Events    []string  `json:"events" gorm:"serializer:json;not null"`
+	Active    bool      `json:"active" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate returns an *i18n.Error for invalid webhooks.
+func (r *Webhook) Validate() error {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return i18n.NewError(i18n.WebhookURLInvalid)
+	}
+	if len(r.Events) == 0 {
+		return i18n.NewError(i18n.WebhookEventsRequired)
+	}
+	for _, event := range r.Events {
+		if !r.knownEvent(event) {
+			return i18n.NewError(i18n.WebhookEventUnknown, event)
+		}
+	}
+	return nil
+}
+
+func (r *Webhook) knownEvent(event string) bool {
+	for _, known := range WebhookEvents {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts reports whether the webhook subscribed to the event.
+func (r *Webhook) Accepts(event string) bool {
+	for _, e := range r.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	ID             int        `json:"id" gorm:"primary_key"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null"`
+	Event          string     `json:"event" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null"`
+	Attempts       int        `json:"attempts" gorm:"not null"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+// Package outbox makes domain events durable: services record them in the
+// same transaction as the change, and a relay dispatches them to sinks.
+package outbox
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Record adds the event to the outbox of the current transaction.
+func Record(tx repositories.Repositories, event events.Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	createdAt := event.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return tx.Outbox.Add(&models.OutboxEvent{
+		Type:        event.Type,
+		QuestionID:  event.QuestionID,
+		UserID:      event.UserID,
+		Payload:     string(payload),
+		Status:      models.OutboxPending,
+		DeliveredTo: []string{},
+		CreatedAt:   createdAt,
+	})
+}
+
+// toEvent restores the event recorded by Record. Data stays encoded, so
+// sinks pass it on without knowing its type.
+func toEvent(row *models.OutboxEvent) events.Event {
+	return events.Event{
+		Type:       row.Type,
+		QuestionID: row.QuestionID,
+		UserID:     row.UserID,
+		Data:       json.RawMessage(row.Payload),
+		CreatedAt:  row.CreatedAt,
+	}
+}
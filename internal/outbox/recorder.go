@@ -0,0 +1,16 @@
+package outbox
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/repositories"
+)
+
+// Recorder turns events into rows of its own inside the transaction that
+// claims them from the outbox, so an event is never marked done before its
+// rows exist. Unlike a sink it never runs twice for the same event.
+type Recorder interface {
+	Name() string
+	Record(tx repositories.Repositories, id int, event events.Event) error
+	// Recorded is called once the rows of a batch are committed.
+	Recorded()
+}
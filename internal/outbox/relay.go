@@ -0,0 +1,172 @@
+package outbox
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Relay dispatches pending outbox events to every recorder and sink and
+// marks them done once all sinks accepted them.
+type Relay struct {
+	repository   repositories.OutboxRepository
+	sinks        []Sink
+	recorders    []Recorder
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	// lease is how long a claimed batch stays hidden from other relays;
+	// every event in it may wait up to the HTTP timeout.
+	lease time.Duration
+	wake  chan struct{}
+}
+
+func NewRelay(repository repositories.OutboxRepository, sinks []Sink, recorders []Recorder, cfg config.OutboxConfig) *Relay {
+	return &Relay{
+		repository:   repository,
+		sinks:        sinks,
+		recorders:    recorders,
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		maxAttempts:  cfg.MaxAttempts,
+		lease:        time.Duration(cfg.BatchSize) * cfg.HTTPTimeout,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Wake makes Run check the outbox without waiting for the next poll.
+func (r *Relay) Wake() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.ProcessPending(ctx); err != nil {
+			log.Printf("Outbox relay error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// ProcessPending dispatches batches until no pending event is left. Events
+// that failed stay pending and are retried on the next call.
+func (r *Relay) ProcessPending(ctx context.Context) error {
+	lastID := 0
+	for {
+		recorded := false
+		claimed := func(tx repositories.Repositories, row *models.OutboxEvent) error {
+			added, err := r.record(tx, row)
+			recorded = recorded || added
+			return err
+		}
+		processed, err := r.repository.ProcessPending(lastID, r.batchSize, time.Now().Add(r.lease), claimed, func(row *models.OutboxEvent) bool {
+			lastID = row.ID
+			return r.dispatch(ctx, row)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to process outbox: %w", err)
+		}
+
+		if recorded {
+			for _, recorder := range r.recorders {
+				recorder.Recorded()
+			}
+		}
+
+		if processed < r.batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// record hands a claimed event to the recorders that have not seen it yet.
+// It reports whether any of them did.
+func (r *Relay) record(tx repositories.Repositories, row *models.OutboxEvent) (bool, error) {
+	recorded := false
+	for _, recorder := range r.recorders {
+		if slices.Contains(row.DeliveredTo, recorder.Name()) {
+			continue
+		}
+		if err := recorder.Record(tx, row.ID, toEvent(row)); err != nil {
+			return false, fmt.Errorf("failed to record outbox event %d in %s: %w", row.ID, recorder.Name(), err)
+		}
+		row.DeliveredTo = append(row.DeliveredTo, recorder.Name())
+		recorded = true
+	}
+	return recorded, nil
+}
+
+// dispatch sends the event to the sinks that have not accepted it yet. It
+// reports false, leaving the event to the next poll without counting an
+// attempt, once ctx is cancelled.
+func (r *Relay) dispatch(ctx context.Context, row *models.OutboxEvent) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	event := toEvent(row)
+
+	var errs []string
+	for _, sink := range r.sinks {
+		if slices.Contains(row.DeliveredTo, sink.Name()) {
+			continue
+		}
+		if err := sink.Send(ctx, row.ID, event); err != nil {
+			errs = append(errs, sink.Name()+": "+err.Error())
+			continue
+		}
+		row.DeliveredTo = append(row.DeliveredTo, sink.Name())
+	}
+
+	row.Attempts++
+	if len(errs) == 0 {
+		now := time.Now()
+		row.Status = models.OutboxDone
+		row.LastError = ""
+		row.ProcessedAt = &now
+		return true
+	}
+
+	row.LastError = strings.Join(errs, "; ")
+	if row.Attempts >= r.maxAttempts {
+		row.Status = models.OutboxFailed
+		log.Printf("Outbox event %d failed after %d attempts: %s", row.ID, row.Attempts, row.LastError)
+	}
+	return true
+}
+
+// Sinks builds the sinks named in the config; the bus sink forwards to
+// publisher.
+func Sinks(cfg config.OutboxConfig, publisher events.Publisher) []Sink {
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case config.OutboxSinkLog:
+			sinks = append(sinks, LogSink())
+		case config.OutboxSinkBus:
+			sinks = append(sinks, BusSink(publisher))
+		case config.OutboxSinkHTTP:
+			sinks = append(sinks, HTTPSink(cfg.HTTPURL, cfg.HTTPTimeout))
+		}
+	}
+	return sinks
+}
@@ -0,0 +1,204 @@
+package outbox
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	name   string
+	fail   bool
+	events []events.Event
+}
+
+func (s *recordingSink) Name() string {
+	return s.name
+}
+
+func (s *recordingSink) Send(_ context.Context, _ int, event events.Event) error {
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+type countingRecorder struct {
+	recorded []int
+	batches  int
+}
+
+func (r *countingRecorder) Name() string {
+	return "webhooks"
+}
+
+func (r *countingRecorder) Record(_ repositories.Repositories, id int, _ events.Event) error {
+	r.recorded = append(r.recorded, id)
+	return nil
+}
+
+func (r *countingRecorder) Recorded() {
+	r.batches++
+}
+
+func newRelay(t *testing.T, maxAttempts int, sinks ...Sink) (*Relay, repositories.Transactor, repositories.OutboxRepository) {
+	t.Helper()
+
+	return newRecordingRelay(t, maxAttempts, nil, sinks...)
+}
+
+func newRecordingRelay(t *testing.T, maxAttempts int, recorders []Recorder, sinks ...Sink) (*Relay, repositories.Transactor, repositories.OutboxRepository) {
+	t.Helper()
+
+	store := memory.NewStore()
+	repo := memory.NewOutboxRepository(store)
+	relay := NewRelay(repo, sinks, recorders, config.OutboxConfig{PollInterval: time.Second, BatchSize: 2, MaxAttempts: maxAttempts})
+	return relay, memory.NewTransactor(store, func() {}), repo
+}
+
+func record(t *testing.T, transactor repositories.Transactor, event events.Event) {
+	t.Helper()
+
+	require.NoError(t, transactor.Transaction(func(tx repositories.Repositories) error {
+		return Record(tx, event)
+	}))
+}
+
+func TestRelay_DispatchesRecordedEvents(t *testing.T) {
+	sink := &recordingSink{name: "bus"}
+	relay, transactor, repo := newRelay(t, 3, sink)
+
+	userID := uuid.New()
+	for i := 1; i <= 3; i++ {
+		record(t, transactor, events.Event{Type: events.AnswerCreated, QuestionID: uint(i), UserID: userID, Data: map[string]int{"id": i}})
+	}
+
+	require.NoError(t, relay.ProcessPending(context.Background()))
+
+	require.Len(t, sink.events, 3, "all batches are drained")
+	assert.Equal(t, events.AnswerCreated, sink.events[0].Type)
+	assert.Equal(t, userID, sink.events[0].UserID)
+	data, err := json.Marshal(sink.events[2].Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":3}`, string(data))
+
+	row, err := repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxDone, row.Status)
+	assert.NotNil(t, row.ProcessedAt)
+}
+
+func TestRelay_RetriesOnlyFailedSinks(t *testing.T) {
+	healthy := &recordingSink{name: "bus"}
+	flaky := &recordingSink{name: "http", fail: true}
+	relay, transactor, repo := newRelay(t, 3, healthy, flaky)
+
+	record(t, transactor, events.Event{Type: events.QuestionCreated, QuestionID: 1})
+
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	row, err := repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxPending, row.Status)
+	assert.Equal(t, []string{"bus"}, row.DeliveredTo)
+	assert.Contains(t, row.LastError, "http: unavailable")
+
+	flaky.fail = false
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	row, err = repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxDone, row.Status)
+	assert.Len(t, healthy.events, 1)
+	assert.Len(t, flaky.events, 1)
+}
+
+func TestRelay_FailsAfterMaxAttempts(t *testing.T) {
+	relay, transactor, repo := newRelay(t, 2, &recordingSink{name: "http", fail: true})
+
+	record(t, transactor, events.Event{Type: events.QuestionCreated, QuestionID: 1})
+
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	require.NoError(t, relay.ProcessPending(context.Background()))
+
+	row, err := repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxFailed, row.Status)
+	assert.Equal(t, 2, row.Attempts)
+}
+
+func TestRelay_LeavesEventsWhenCancelled(t *testing.T) {
+	sink := &recordingSink{name: "bus"}
+	relay, transactor, repo := newRelay(t, 3, sink)
+
+	record(t, transactor, events.Event{Type: events.QuestionCreated, QuestionID: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, relay.ProcessPending(ctx))
+	row, err := repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxPending, row.Status)
+	assert.Zero(t, row.Attempts, "a stopping relay does not count an attempt")
+	assert.Empty(t, sink.events)
+
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	assert.Len(t, sink.events, 1)
+}
+
+func TestRelay_RecordsEventsOnce(t *testing.T) {
+	recorder := &countingRecorder{}
+	flaky := &recordingSink{name: "http", fail: true}
+	relay, transactor, repo := newRecordingRelay(t, 3, []Recorder{recorder}, flaky)
+
+	record(t, transactor, events.Event{Type: events.AnswerCreated, QuestionID: 1})
+
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	row, err := repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxPending, row.Status)
+	assert.Equal(t, []string{"webhooks"}, row.DeliveredTo)
+	assert.Equal(t, 1, recorder.batches)
+
+	flaky.fail = false
+	require.NoError(t, relay.ProcessPending(context.Background()))
+	row, err = repo.FindByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxDone, row.Status)
+	assert.Equal(t, []int{1}, recorder.recorded, "a retry does not record again")
+	assert.Equal(t, 1, recorder.batches)
+}
+
+func TestHTTPSink_PostsEvent(t *testing.T) {
+	var header http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	sink := HTTPSink(server.URL, time.Second)
+	err := sink.Send(context.Background(), 42, events.Event{Type: events.AnswerCreated, QuestionID: 7, Data: json.RawMessage(`{"id":1}`)})
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", header.Get(HeaderEventID))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, events.AnswerCreated, decoded["type"])
+	assert.Equal(t, map[string]any{"id": float64(1)}, decoded["data"])
+}
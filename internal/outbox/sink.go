@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sink receives events from the relay. An error makes the relay retry the
+// event for this sink on its next poll, so Send must tolerate duplicates.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, id int, event events.Event) error
+}
+
+type logSink struct{}
+
+// LogSink writes every event to the standard logger.
+func LogSink() Sink {
+	return logSink{}
+}
+
+func (logSink) Name() string {
+	return "log"
+}
+
+func (logSink) Send(_ context.Context, id int, event events.Event) error {
+	log.Printf("Outbox event %d: %s question=%d", id, event.Type, event.QuestionID)
+	return nil
+}
+
+type busSink struct {
+	publisher events.Publisher
+}
+
+// BusSink hands events to in-process publishers such as the SSE
+// broadcaster, the WebSocket hub and notifications.
+func BusSink(publisher events.Publisher) Sink {
+	return busSink{publisher}
+}
+
+func (busSink) Name() string {
+	return "bus"
+}
+
+func (b busSink) Send(_ context.Context, _ int, event events.Event) error {
+	b.publisher.Publish(event)
+	return nil
+}
+
+// HeaderEventID carries the outbox id, which receivers can use to drop
+// duplicates.
+const HeaderEventID = "X-Outbox-Event-ID"
+
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+// HTTPSink POSTs each event as JSON to url.
+func HTTPSink(url string, timeout time.Duration) Sink {
+	return httpSink{url, &http.Client{Timeout: timeout}}
+}
+
+func (httpSink) Name() string {
+	return "http"
+}
+
+func (h httpSink) Send(ctx context.Context, id int, event events.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, strconv.Itoa(id))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return nil
+}
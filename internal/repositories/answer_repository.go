@@ -3,12 +3,28 @@ package repositories
 import (
 	"api_service_questions_and_answers/internal/models"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type AnswerRepository interface {
 	Create(answer *models.Answer) error
+	// FindByID returns the answer, hidden or not.
 	FindByID(id uint) (*models.Answer, error)
+	// FindByQuestionIDs returns the visible answers of all given questions
+	// in one query, ordered by id.
+	FindByQuestionIDs(questionIDs []uint) ([]*models.Answer, error)
+	// FindByUser returns one page of the visible answers written by the
+	// user.
+	FindByUser(userID uuid.UUID, page Page) ([]*models.Answer, error)
+	// ActivityOf summarises the visible answers written by the user.
+	ActivityOf(userID uuid.UUID) (Activity, error)
+	// RenderText stores render(text) as the text_html of every answer where
+	// it differs and returns how many were updated.
+	RenderText(render func(string) string) (int64, error)
+	SetHidden(id uint, hidden bool) error
+	// DeleteByID returns gorm.ErrRecordNotFound when there is no answer to
+	// delete, so that a caller racing another delete can tell it lost.
 	DeleteByID(id uint) error
 }
 
@@ -35,6 +51,40 @@ func (a answerRepository) FindByID(id uint) (*models.Answer, error) {
 	return &answer, nil
 }
 
+func (a answerRepository) FindByQuestionIDs(questionIDs []uint) ([]*models.Answer, error) {
+	var answers []*models.Answer
+	err := a.database.Where("question_id IN ? AND hidden = ?", questionIDs, false).Order("id").Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (a answerRepository) FindByUser(userID uuid.UUID, page Page) ([]*models.Answer, error) {
+	var answers []*models.Answer
+	err := a.database.Where("user_id = ? AND hidden = ?", userID, false).Scopes(page.scope).Find(&answers).Error
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (a answerRepository) ActivityOf(userID uuid.UUID) (Activity, error) {
+	return activityOf(a.database, &models.Answer{}, userID)
+}
+
+func (a answerRepository) RenderText(render func(string) string) (int64, error) {
+	return renderText(a.database, "answers", render)
+}
+
+func (a answerRepository) SetHidden(id uint, hidden bool) error {
+	return a.database.Model(&models.Answer{}).Where("id = ?", id).Update("hidden", hidden).Error
+}
+
 func (a answerRepository) DeleteByID(id uint) error {
-	return a.database.Delete(&models.Answer{}, id).Error
+	result := a.database.Delete(&models.Answer{}, id)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
 }
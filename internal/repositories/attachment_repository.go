@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository keeps the metadata of attachments. Their rows are
+// deleted together with their question or answer; the files in the blob
+// store are not.
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error
+	FindByID(id uint) (*models.Attachment, error)
+	// FindByQuestion returns the attachments of the question and of its
+	// answers.
+	FindByQuestion(questionID uint) ([]*models.Attachment, error)
+	FindByAnswer(answerID uint) ([]*models.Attachment, error)
+}
+
+type attachmentRepository struct {
+	database *gorm.DB
+}
+
+func NewAttachmentRepository(database *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{
+		database,
+	}
+}
+
+func (a attachmentRepository) Create(attachment *models.Attachment) error {
+	return a.database.Create(attachment).Error
+}
+
+func (a attachmentRepository) FindByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := a.database.First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (a attachmentRepository) FindByQuestion(questionID uint) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := a.database.Where("question_id = ?", questionID).Order("id").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (a attachmentRepository) FindByAnswer(answerID uint) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := a.database.Where("answer_id = ?", answerID).Order("id").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
@@ -0,0 +1,89 @@
+package cached
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+type answerRepository struct {
+	next  repositories.AnswerRepository
+	cache cache.Cache
+}
+
+func NewAnswerRepository(next repositories.AnswerRepository, cache cache.Cache) repositories.AnswerRepository {
+	return &answerRepository{
+		next,
+		cache,
+	}
+}
+
+// Create invalidates the question, whose cached copy embeds its answers.
+func (a answerRepository) Create(answer *models.Answer) error {
+	err := a.next.Create(answer)
+	a.cache.Delete(questionKey(answer.QuestionID))
+	return err
+}
+
+func (a answerRepository) FindByID(id uint) (*models.Answer, error) {
+	var answer models.Answer
+	if load(a.cache, answerKey(id), &answer) {
+		return &answer, nil
+	}
+
+	found, err := a.next.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	store(a.cache, answerKey(id), found)
+	return found, nil
+}
+
+// FindByQuestionIDs is not cached: it serves batch reads whose key sets
+// rarely repeat.
+func (a answerRepository) FindByQuestionIDs(questionIDs []uint) ([]*models.Answer, error) {
+	return a.next.FindByQuestionIDs(questionIDs)
+}
+
+// FindByUser and ActivityOf are not cached: they change with every answer
+// the user writes.
+func (a answerRepository) FindByUser(userID uuid.UUID, page repositories.Page) ([]*models.Answer, error) {
+	return a.next.FindByUser(userID, page)
+}
+
+func (a answerRepository) ActivityOf(userID uuid.UUID) (repositories.Activity, error) {
+	return a.next.ActivityOf(userID)
+}
+
+// RenderText leaves stale answers and questions in the cache until they
+// expire; it is meant to run from the command line, without a cache.
+func (a answerRepository) RenderText(render func(string) string) (int64, error) {
+	return a.next.RenderText(render)
+}
+
+// SetHidden also invalidates the question, whose cached copy embeds its
+// visible answers.
+func (a answerRepository) SetHidden(id uint, hidden bool) error {
+	keys := []string{answerKey(id)}
+	if answer, err := a.FindByID(id); err == nil {
+		keys = append(keys, questionKey(answer.QuestionID))
+	}
+
+	err := a.next.SetHidden(id, hidden)
+	a.cache.Delete(keys...)
+	return err
+}
+
+func (a answerRepository) DeleteByID(id uint) error {
+	keys := []string{answerKey(id)}
+	if answer, err := a.FindByID(id); err == nil {
+		keys = append(keys, questionKey(answer.QuestionID))
+	}
+
+	err := a.next.DeleteByID(id)
+	a.cache.Delete(keys...)
+	return err
+}
@@ -0,0 +1,127 @@
+package cached_test
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories/cached"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"api_service_questions_and_answers/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// author is a registered user of the stores built by newCachedServices.
+var author = uuid.MustParse("0b7c2f5e-6d0a-4f43-9a57-2f1f3c5d8e21")
+
+func newCachedServices() (services.QuestionService, services.AnswerService, cache.Cache) {
+	c := cache.NewLRU(100, time.Minute)
+	store := memory.NewStore()
+	questionRepo := cached.NewQuestionRepository(memory.NewQuestionRepository(store), c)
+	answerRepo := cached.NewAnswerRepository(memory.NewAnswerRepository(store), c)
+	userRepo := memory.NewUserRepository(store)
+	if err := userRepo.Create(&models.User{ID: author, DisplayName: "Author", Status: models.UserActive}); err != nil {
+		panic(err)
+	}
+	transactor := cached.NewTransactor(memory.NewTransactor(store, func() {}), c)
+	return services.NewQuestionService(questionRepo, userRepo, transactor, nil), services.NewAnswerService(questionRepo, answerRepo, userRepo, transactor, nil, nil), c
+}
+
+func TestCached_GetQuestionHitsCache(t *testing.T) {
+	questionService, _, c := newCachedServices()
+
+	question, err := questionService.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	_, err = questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	found, err := questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+
+	assert.Equal(t, "What is Go?", found.Text)
+	assert.Equal(t, uint64(1), c.Stats().Misses)
+	assert.Equal(t, uint64(1), c.Stats().Hits)
+}
+
+func TestCached_CreateAndDeleteAnswerInvalidateQuestion(t *testing.T) {
+	questionService, answerService, _ := newCachedServices()
+
+	question, err := questionService.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	_, err = questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+
+	answer, err := answerService.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "A language"})
+	require.NoError(t, err)
+
+	found, err := questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Len(t, found.Answers, 1)
+
+	require.NoError(t, answerService.DeleteAnswer(uint(answer.ID)))
+
+	found, err = questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Empty(t, found.Answers)
+
+	_, err = answerService.GetAnswer(uint(answer.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCached_DeleteQuestionInvalidatesQuestionAndAnswers(t *testing.T) {
+	questionService, answerService, _ := newCachedServices()
+
+	question, err := questionService.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	answer, err := answerService.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "A language"})
+	require.NoError(t, err)
+
+	_, err = questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	_, err = answerService.GetAnswer(uint(answer.ID))
+	require.NoError(t, err)
+
+	require.NoError(t, questionService.DeleteQuestion(uint(question.ID)))
+
+	_, err = questionService.GetQuestion(uint(question.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = answerService.GetAnswer(uint(answer.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestCached_HidingAnswerInvalidatesQuestion(t *testing.T) {
+	c := cache.NewLRU(100, time.Minute)
+	store := memory.NewStore()
+	questionRepo := cached.NewQuestionRepository(memory.NewQuestionRepository(store), c)
+	answerRepo := cached.NewAnswerRepository(memory.NewAnswerRepository(store), c)
+	userRepo := memory.NewUserRepository(store)
+	require.NoError(t, userRepo.Create(&models.User{ID: author, DisplayName: "Author", Status: models.UserActive}))
+	transactor := cached.NewTransactor(memory.NewTransactor(store, func() {}), c)
+	questionService := services.NewQuestionService(questionRepo, userRepo, transactor, nil)
+	answerService := services.NewAnswerService(questionRepo, answerRepo, userRepo, transactor, nil, nil)
+	moderationService := services.NewModerationService(questionRepo, answerRepo, userRepo, memory.NewModerationRepository(store),
+		transactor, questionService, answerService, nil, 1)
+
+	question, err := questionService.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	answer, err := answerService.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "Buy cheap watches"})
+	require.NoError(t, err)
+	found, err := questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	require.Len(t, found.Answers, 1)
+	_, err = answerService.GetAnswer(uint(answer.ID))
+	require.NoError(t, err)
+
+	_, err = moderationService.FlagContent(models.ContentAnswer, uint(answer.ID), &models.Flag{UserID: author, Reason: models.FlagSpam})
+	require.NoError(t, err)
+
+	found, err = questionService.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Empty(t, found.Answers)
+	_, err = answerService.GetAnswer(uint(answer.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,41 @@
+// Package cached wraps the repositories with a read-through cache.
+// Writes that go through the wrappers invalidate the affected entries.
+package cached
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+func questionKey(id uint) string {
+	return fmt.Sprintf("question:%d", id)
+}
+
+func answerKey(id uint) string {
+	return fmt.Sprintf("answer:%d", id)
+}
+
+// load decodes a cached value into dst and reports whether it was found.
+func load(c cache.Cache, key string, dst any) bool {
+	data, ok := c.Get(key)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		log.Printf("Error decoding cached %s: %v", key, err)
+		c.Delete(key)
+		return false
+	}
+	return true
+}
+
+func store(c cache.Cache, key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Error encoding %s for cache: %v", key, err)
+		return
+	}
+	c.Set(key, data)
+}
@@ -0,0 +1,116 @@
+package cached
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+type questionRepository struct {
+	next  repositories.QuestionRepository
+	cache cache.Cache
+}
+
+func NewQuestionRepository(next repositories.QuestionRepository, cache cache.Cache) repositories.QuestionRepository {
+	return &questionRepository{
+		next,
+		cache,
+	}
+}
+
+func (q questionRepository) FindAll() ([]*models.Question, error) {
+	return q.next.FindAll()
+}
+
+func (q questionRepository) Find(query repositories.QuestionQuery) ([]*models.Question, error) {
+	return q.next.Find(query)
+}
+
+func (q questionRepository) Create(question *models.Question) error {
+	return q.next.Create(question)
+}
+
+func (q questionRepository) FindByID(id uint) (*models.Question, error) {
+	var question models.Question
+	if load(q.cache, questionKey(id), &question) {
+		return &question, nil
+	}
+
+	found, err := q.next.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	store(q.cache, questionKey(id), found)
+	return found, nil
+}
+
+// FindByIDs, FindPage and CountVisible are not cached: they change with every
+// new question.
+func (q questionRepository) FindByIDs(ids []uint) ([]*models.Question, error) {
+	return q.next.FindByIDs(ids)
+}
+
+func (q questionRepository) FindPage(page repositories.Page) ([]*models.Question, error) {
+	return q.next.FindPage(page)
+}
+
+func (q questionRepository) CountVisible() (int64, error) {
+	return q.next.CountVisible()
+}
+
+// FindByIDWithAnswers is not cached: pages of answers go stale with every
+// new answer.
+func (q questionRepository) FindByIDWithAnswers(id uint, answers repositories.Page) (*models.Question, error) {
+	return q.next.FindByIDWithAnswers(id, answers)
+}
+
+// FindByUser and ActivityOf are not cached: they change with every question
+// the user asks.
+func (q questionRepository) FindByUser(userID uuid.UUID, page repositories.Page) ([]*models.Question, error) {
+	return q.next.FindByUser(userID, page)
+}
+
+func (q questionRepository) ActivityOf(userID uuid.UUID) (repositories.Activity, error) {
+	return q.next.ActivityOf(userID)
+}
+
+func (q questionRepository) UpdateAnswerStats(id uint, delta int) error {
+	err := q.next.UpdateAnswerStats(id, delta)
+	q.cache.Delete(questionKey(id))
+	return err
+}
+
+// RepairAnswerStats leaves stale questions in the cache until they expire;
+// it is meant to run from the command line, without a cache.
+func (q questionRepository) RepairAnswerStats() (int64, error) {
+	return q.next.RepairAnswerStats()
+}
+
+// RenderText leaves stale questions in the cache until they expire, like
+// RepairAnswerStats.
+func (q questionRepository) RenderText(render func(string) string) (int64, error) {
+	return q.next.RenderText(render)
+}
+
+func (q questionRepository) SetHidden(id uint, hidden bool) error {
+	err := q.next.SetHidden(id, hidden)
+	q.cache.Delete(questionKey(id))
+	return err
+}
+
+// Delete also drops the cached answers, which the database removes by cascade.
+func (q questionRepository) Delete(id uint) error {
+	keys := []string{questionKey(id)}
+	if question, err := q.FindByID(id); err == nil {
+		for _, answer := range question.Answers {
+			keys = append(keys, answerKey(uint(answer.ID)))
+		}
+	}
+
+	err := q.next.Delete(id)
+	q.cache.Delete(keys...)
+	return err
+}
@@ -0,0 +1,46 @@
+package cached
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"api_service_questions_and_answers/internal/repositories"
+)
+
+type transactor struct {
+	next  repositories.Transactor
+	cache cache.Cache
+}
+
+// NewTransactor wraps the repositories of each transaction so that writes
+// made in it invalidate the cache like writes made outside.
+func NewTransactor(next repositories.Transactor, cache cache.Cache) repositories.Transactor {
+	return &transactor{
+		next,
+		cache,
+	}
+}
+
+func (t transactor) Transaction(fn func(tx repositories.Repositories) error) error {
+	tracked := &trackingCache{Cache: t.cache}
+
+	err := t.next.Transaction(func(tx repositories.Repositories) error {
+		tx.Questions = NewQuestionRepository(tx.Questions, tracked)
+		tx.Answers = NewAnswerRepository(tx.Answers, tracked)
+		return fn(tx)
+	})
+
+	// A read between the invalidation and the commit may have cached the
+	// old state again.
+	t.cache.Delete(tracked.deleted...)
+	return err
+}
+
+// trackingCache remembers the keys deleted during a transaction.
+type trackingCache struct {
+	cache.Cache
+	deleted []string
+}
+
+func (c *trackingCache) Delete(keys ...string) {
+	c.deleted = append(c.deleted, keys...)
+	c.Cache.Delete(keys...)
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FollowRepository interface {
+	// Follow is idempotent: following a question twice keeps one follow.
+	Follow(follow *models.Follow) error
+	Unfollow(questionID uint, userID uuid.UUID) error
+	FindFollowers(questionID uint) ([]uuid.UUID, error)
+}
+
+type followRepository struct {
+	database *gorm.DB
+}
+
+func NewFollowRepository(database *gorm.DB) FollowRepository {
+	return &followRepository{
+		database,
+	}
+}
+
+func (f followRepository) Follow(follow *models.Follow) error {
+	return f.database.Clauses(clause.OnConflict{DoNothing: true}).Create(follow).Error
+}
+
+func (f followRepository) Unfollow(questionID uint, userID uuid.UUID) error {
+	return f.database.Where("question_id = ? AND user_id = ?", questionID, userID).Delete(&models.Follow{}).Error
+}
+
+func (f followRepository) FindFollowers(questionID uint) ([]uuid.UUID, error) {
+	var followers []uuid.UUID
+	err := f.database.Model(&models.Follow{}).Where("question_id = ?", questionID).Order("created_at").Pluck("user_id", &followers).Error
+	if err != nil {
+		return nil, err
+	}
+	return followers, nil
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type answerRepository struct {
+	store *Store
+}
+
+func NewAnswerRepository(store *Store) repositories.AnswerRepository {
+	return &answerRepository{
+		store,
+	}
+}
+
+func (a answerRepository) Create(answer *models.Answer) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.questions[int(answer.QuestionID)]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if _, ok := a.store.users[answer.UserID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	a.store.nextAnswerID++
+	answer.ID = a.store.nextAnswerID
+	if answer.CreatedAt.IsZero() {
+		answer.CreatedAt = time.Now()
+	}
+
+	a.store.answers[answer.ID] = *answer
+	return nil
+}
+
+func (a answerRepository) FindByID(id uint) (*models.Answer, error) {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	answer, ok := a.store.answers[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &answer, nil
+}
+
+func (a answerRepository) FindByQuestionIDs(questionIDs []uint) ([]*models.Answer, error) {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	answers := []*models.Answer{}
+	for _, questionID := range questionIDs {
+		for _, answer := range a.store.visibleAnswersOf(int(questionID)) {
+			answers = append(answers, &answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].ID < answers[j].ID
+	})
+	return answers, nil
+}
+
+func (a answerRepository) FindByUser(userID uuid.UUID, page repositories.Page) ([]*models.Answer, error) {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	answers := []*models.Answer{}
+	for _, answer := range repositories.SelectPage(page, a.store.answersBy(userID), answerID) {
+		answers = append(answers, &answer)
+	}
+	return answers, nil
+}
+
+func (a answerRepository) ActivityOf(userID uuid.UUID) (repositories.Activity, error) {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	answers := a.store.answersBy(userID)
+	if len(answers) == 0 {
+		return repositories.Activity{}, nil
+	}
+	return repositories.Activity{
+		Count:   int64(len(answers)),
+		FirstAt: &answers[0].CreatedAt,
+		LastAt:  &answers[len(answers)-1].CreatedAt,
+	}, nil
+}
+
+func (a answerRepository) RenderText(render func(string) string) (int64, error) {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	var updated int64
+	for id, answer := range a.store.answers {
+		if html := render(answer.Text); html != answer.TextHTML {
+			answer.TextHTML = html
+			a.store.answers[id] = answer
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (a answerRepository) SetHidden(id uint, hidden bool) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if answer, ok := a.store.answers[int(id)]; ok {
+		answer.Hidden = hidden
+		a.store.answers[answer.ID] = answer
+	}
+	return nil
+}
+
+func (a answerRepository) DeleteByID(id uint) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.answers[int(id)]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+
+	delete(a.store.answers, int(id))
+	a.store.deleteAnswerRelations(id)
+	return nil
+}
+
+// answersBy returns the visible answers written by the user ordered by ID.
+// The caller must hold the store lock.
+func (s *Store) answersBy(userID uuid.UUID) []models.Answer {
+	answers := []models.Answer{}
+	for _, answer := range s.answers {
+		if answer.UserID == userID && !answer.Hidden {
+			answers = append(answers, answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].ID < answers[j].ID
+	})
+	return answers
+}
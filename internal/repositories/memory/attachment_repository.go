@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	store *Store
+}
+
+func NewAttachmentRepository(store *Store) repositories.AttachmentRepository {
+	return &attachmentRepository{
+		store,
+	}
+}
+
+func (a attachmentRepository) Create(attachment *models.Attachment) error {
+	a.store.mu.Lock()
+	defer a.store.mu.Unlock()
+
+	if _, ok := a.store.questions[int(attachment.QuestionID)]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if attachment.AnswerID != nil {
+		if _, ok := a.store.answers[int(*attachment.AnswerID)]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	a.store.nextAttachmentID++
+	attachment.ID = a.store.nextAttachmentID
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+
+	a.store.attachments[attachment.ID] = *attachment
+	return nil
+}
+
+func (a attachmentRepository) FindByID(id uint) (*models.Attachment, error) {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	attachment, ok := a.store.attachments[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &attachment, nil
+}
+
+func (a attachmentRepository) FindByQuestion(questionID uint) ([]*models.Attachment, error) {
+	return a.find(func(attachment models.Attachment) bool {
+		return attachment.QuestionID == questionID
+	}), nil
+}
+
+func (a attachmentRepository) FindByAnswer(answerID uint) ([]*models.Attachment, error) {
+	return a.find(func(attachment models.Attachment) bool {
+		return attachment.AnswerID != nil && *attachment.AnswerID == answerID
+	}), nil
+}
+
+// find returns the attachments matching keep ordered by ID.
+func (a attachmentRepository) find(keep func(models.Attachment) bool) []*models.Attachment {
+	a.store.mu.RLock()
+	defer a.store.mu.RUnlock()
+
+	attachments := []*models.Attachment{}
+	for _, attachment := range a.store.attachments {
+		if keep(attachment) {
+			attachments = append(attachments, &attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].ID < attachments[j].ID
+	})
+	return attachments
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type followRepository struct {
+	store *Store
+}
+
+func NewFollowRepository(store *Store) repositories.FollowRepository {
+	return &followRepository{
+		store,
+	}
+}
+
+func (f followRepository) Follow(follow *models.Follow) error {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+
+	if _, ok := f.store.questions[int(follow.QuestionID)]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	key := followKey{follow.QuestionID, follow.UserID}
+	if _, ok := f.store.follows[key]; ok {
+		return nil
+	}
+	if follow.CreatedAt.IsZero() {
+		follow.CreatedAt = time.Now()
+	}
+	f.store.follows[key] = *follow
+	return nil
+}
+
+func (f followRepository) Unfollow(questionID uint, userID uuid.UUID) error {
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+
+	delete(f.store.follows, followKey{questionID, userID})
+	return nil
+}
+
+func (f followRepository) FindFollowers(questionID uint) ([]uuid.UUID, error) {
+	f.store.mu.RLock()
+	defer f.store.mu.RUnlock()
+
+	follows := []models.Follow{}
+	for key, follow := range f.store.follows {
+		if key.questionID == questionID {
+			follows = append(follows, follow)
+		}
+	}
+	sort.Slice(follows, func(i, j int) bool {
+		return follows[i].CreatedAt.Before(follows[j].CreatedAt)
+	})
+
+	followers := make([]uuid.UUID, 0, len(follows))
+	for _, follow := range follows {
+		followers = append(followers, follow.UserID)
+	}
+	return followers, nil
+}
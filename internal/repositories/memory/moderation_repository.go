@@ -0,0 +1,185 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type moderationRepository struct {
+	store *Store
+}
+
+func NewModerationRepository(store *Store) repositories.ModerationRepository {
+	return &moderationRepository{
+		store,
+	}
+}
+
+func (m moderationRepository) OpenCase(contentType string, contentID uint) (*models.ModerationCase, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	for _, moderationCase := range m.store.cases {
+		if moderationCase.ContentType == contentType && moderationCase.ContentID == contentID && moderationCase.Status == models.CaseOpen {
+			return &moderationCase, nil
+		}
+	}
+
+	m.store.nextCaseID++
+	moderationCase := models.ModerationCase{
+		ID:          m.store.nextCaseID,
+		ContentType: contentType,
+		ContentID:   contentID,
+		Status:      models.CaseOpen,
+		CreatedAt:   time.Now(),
+	}
+	m.store.cases[moderationCase.ID] = moderationCase
+	return &moderationCase, nil
+}
+
+func (m moderationRepository) HoldForReview(contentType string, contentID uint, reason string) (*models.ModerationCase, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	for _, moderationCase := range m.store.cases {
+		if moderationCase.ContentType == contentType && moderationCase.ContentID == contentID && moderationCase.Status == models.CaseOpen {
+			return nil, gorm.ErrDuplicatedKey
+		}
+	}
+
+	m.store.nextCaseID++
+	moderationCase := models.ModerationCase{
+		ID:           m.store.nextCaseID,
+		ContentType:  contentType,
+		ContentID:    contentID,
+		Status:       models.CaseOpen,
+		ReviewReason: reason,
+		CreatedAt:    time.Now(),
+	}
+	m.store.cases[moderationCase.ID] = moderationCase
+	return &moderationCase, nil
+}
+
+func (m moderationRepository) AddFlag(flag *models.Flag) (int, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	moderationCase, ok := m.store.cases[flag.CaseID]
+	if !ok {
+		return 0, gorm.ErrForeignKeyViolated
+	}
+	if _, ok := m.store.users[flag.UserID]; !ok {
+		return 0, gorm.ErrForeignKeyViolated
+	}
+	for _, existing := range m.store.flags {
+		if existing.CaseID == flag.CaseID && existing.UserID == flag.UserID {
+			return 0, gorm.ErrDuplicatedKey
+		}
+	}
+
+	m.store.nextFlagID++
+	flag.ID = m.store.nextFlagID
+	if flag.CreatedAt.IsZero() {
+		flag.CreatedAt = time.Now()
+	}
+	m.store.flags[flag.ID] = *flag
+
+	moderationCase.FlagCount++
+	m.store.cases[moderationCase.ID] = moderationCase
+	return moderationCase.FlagCount, nil
+}
+
+func (m moderationRepository) WasApproved(contentType string, contentID uint) (bool, error) {
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	for _, moderationCase := range m.store.cases {
+		if moderationCase.ContentType == contentType && moderationCase.ContentID == contentID && moderationCase.Status == models.CaseApproved {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m moderationRepository) FindCase(id uint) (*models.ModerationCase, error) {
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	moderationCase, ok := m.store.cases[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	moderationCase.Flags = m.store.flagsOf(moderationCase.ID)
+	return &moderationCase, nil
+}
+
+func (m moderationRepository) FindOpen(page repositories.Page) ([]*models.ModerationCase, error) {
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	cases := []*models.ModerationCase{}
+	for _, moderationCase := range repositories.SelectPage(page, m.store.openCases(), caseID) {
+		moderationCase.Flags = m.store.flagsOf(moderationCase.ID)
+		cases = append(cases, &moderationCase)
+	}
+	return cases, nil
+}
+
+func (m moderationRepository) CountOpen() (int64, error) {
+	m.store.mu.RLock()
+	defer m.store.mu.RUnlock()
+
+	return int64(len(m.store.openCases())), nil
+}
+
+func (m moderationRepository) Resolve(id uint, status string, moderatorID uuid.UUID, at time.Time) error {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	moderationCase, ok := m.store.cases[int(id)]
+	if !ok || moderationCase.Status != models.CaseOpen {
+		return gorm.ErrRecordNotFound
+	}
+
+	moderationCase.Status = status
+	moderationCase.ModeratorID = &moderatorID
+	moderationCase.ResolvedAt = &at
+	m.store.cases[moderationCase.ID] = moderationCase
+	return nil
+}
+
+// openCases returns the open moderation cases ordered by ID. The caller
+// must hold the store lock.
+func (s *Store) openCases() []models.ModerationCase {
+	cases := []models.ModerationCase{}
+	for _, moderationCase := range s.cases {
+		if moderationCase.Status == models.CaseOpen {
+			cases = append(cases, moderationCase)
+		}
+	}
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].ID < cases[j].ID
+	})
+	return cases
+}
+
+// flagsOf returns the flags of a case ordered by ID. The caller must hold
+// the store lock.
+func (s *Store) flagsOf(caseID int) []models.Flag {
+	flags := []models.Flag{}
+	for _, flag := range s.flags {
+		if flag.CaseID == caseID {
+			flags = append(flags, flag)
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].ID < flags[j].ID
+	})
+	return flags
+}
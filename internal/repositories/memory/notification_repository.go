@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	store *Store
+}
+
+func NewNotificationRepository(store *Store) repositories.NotificationRepository {
+	return &notificationRepository{
+		store,
+	}
+}
+
+func (n notificationRepository) Create(notification *models.Notification) error {
+	n.store.mu.Lock()
+	defer n.store.mu.Unlock()
+
+	if _, ok := n.store.answers[int(notification.AnswerID)]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, existing := range n.store.notifications {
+		if existing.UserID == notification.UserID && existing.Type == notification.Type && existing.AnswerID == notification.AnswerID {
+			return nil
+		}
+	}
+
+	n.store.nextNotificationID++
+	notification.ID = n.store.nextNotificationID
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	n.store.notifications[notification.ID] = *notification
+	return nil
+}
+
+func (n notificationRepository) FindByUser(userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	n.store.mu.RLock()
+	defer n.store.mu.RUnlock()
+
+	notifications := []*models.Notification{}
+	for _, notification := range n.store.notifications {
+		if notification.UserID != userID || (unreadOnly && notification.ReadAt != nil) {
+			continue
+		}
+		notifications = append(notifications, &notification)
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].ID > notifications[j].ID
+	})
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+func (n notificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	n.store.mu.RLock()
+	defer n.store.mu.RUnlock()
+
+	var count int64
+	for _, notification := range n.store.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (n notificationRepository) MarkRead(userID uuid.UUID, id uint, at time.Time) error {
+	n.store.mu.Lock()
+	defer n.store.mu.Unlock()
+
+	notification, ok := n.store.notifications[int(id)]
+	if !ok || notification.UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+	if notification.ReadAt == nil {
+		notification.ReadAt = &at
+		n.store.notifications[notification.ID] = notification
+	}
+	return nil
+}
+
+func (n notificationRepository) MarkAllRead(userID uuid.UUID, at time.Time) error {
+	n.store.mu.Lock()
+	defer n.store.mu.Unlock()
+
+	for id, notification := range n.store.notifications {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			notification.ReadAt = &at
+			n.store.notifications[id] = notification
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type outboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) repositories.OutboxRepository {
+	return &outboxRepository{
+		store,
+	}
+}
+
+func (o outboxRepository) Add(event *models.OutboxEvent) error {
+	o.store.mu.Lock()
+	defer o.store.mu.Unlock()
+
+	o.store.nextOutboxID++
+	event.ID = o.store.nextOutboxID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	o.store.outbox[event.ID] = copyOutboxEvent(*event)
+	return nil
+}
+
+// ProcessPending handles events without holding the store lock, because
+// sinks may write to the store themselves. relayMu keeps a second relay out
+// of the batch, so the memory store needs no lease.
+func (o outboxRepository) ProcessPending(
+	afterID, limit int,
+	_ time.Time,
+	claimed func(tx repositories.Repositories, event *models.OutboxEvent) error,
+	handle func(event *models.OutboxEvent) bool,
+) (int, error) {
+	o.store.relayMu.Lock()
+	defer o.store.relayMu.Unlock()
+
+	o.store.mu.RLock()
+	pending := []*models.OutboxEvent{}
+	for _, event := range o.store.outbox {
+		if event.Status == models.OutboxPending && event.ID > afterID {
+			event := copyOutboxEvent(event)
+			pending = append(pending, &event)
+		}
+	}
+	o.store.mu.RUnlock()
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ID < pending[j].ID
+	})
+	if len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	for _, event := range pending {
+		if err := claimed(bind(o.store), event); err != nil {
+			return 0, err
+		}
+		o.save(event)
+	}
+
+	for _, event := range pending {
+		if handle(event) {
+			o.save(event)
+		}
+	}
+	return len(pending), nil
+}
+
+func (o outboxRepository) save(event *models.OutboxEvent) {
+	o.store.mu.Lock()
+	o.store.outbox[event.ID] = copyOutboxEvent(*event)
+	o.store.mu.Unlock()
+}
+
+func (o outboxRepository) FindByID(id uint) (*models.OutboxEvent, error) {
+	o.store.mu.RLock()
+	defer o.store.mu.RUnlock()
+
+	event, ok := o.store.outbox[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	event = copyOutboxEvent(event)
+	return &event, nil
+}
+
+func copyOutboxEvent(event models.OutboxEvent) models.OutboxEvent {
+	event.DeliveredTo = append([]string(nil), event.DeliveredTo...)
+	return event
+}
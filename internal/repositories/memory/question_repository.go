@@ -0,0 +1,312 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type questionRepository struct {
+	store *Store
+}
+
+func NewQuestionRepository(store *Store) repositories.QuestionRepository {
+	return &questionRepository{
+		store,
+	}
+}
+
+func (q questionRepository) FindAll() ([]*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	questions := make([]*models.Question, 0, len(q.store.questions))
+	for _, question := range q.store.questions {
+		questions = append(questions, &question)
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].ID < questions[j].ID
+	})
+	return questions, nil
+}
+
+func (q questionRepository) Find(query repositories.QuestionQuery) ([]*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	var questions []*models.Question
+	for _, question := range q.store.questions {
+		if query.Matches(&question) {
+			questions = append(questions, &question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return query.Less(questions[i], questions[j])
+	})
+	return questions, nil
+}
+
+func (q questionRepository) Create(question *models.Question) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	if question.UserID != nil {
+		if _, ok := q.store.users[*question.UserID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+
+	q.store.nextQuestionID++
+	question.ID = q.store.nextQuestionID
+	if question.CreatedAt.IsZero() {
+		question.CreatedAt = time.Now()
+	}
+	if question.LastActivityAt.IsZero() {
+		question.LastActivityAt = question.CreatedAt
+	}
+
+	stored := *question
+	stored.Answers = nil
+	q.store.questions[stored.ID] = stored
+	return nil
+}
+
+func (q questionRepository) FindByID(id uint) (*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	question, ok := q.store.questions[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	question.Answers = q.store.visibleAnswersOf(question.ID)
+	return &question, nil
+}
+
+func (q questionRepository) FindByIDs(ids []uint) ([]*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	questions := []*models.Question{}
+	for _, id := range ids {
+		if question, ok := q.store.questions[int(id)]; ok {
+			questions = append(questions, &question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].ID < questions[j].ID
+	})
+	return questions, nil
+}
+
+func (q questionRepository) FindPage(page repositories.Page) ([]*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	questions := []*models.Question{}
+	for _, question := range repositories.SelectPage(page, q.store.visibleQuestions(), questionID) {
+		questions = append(questions, &question)
+	}
+	return questions, nil
+}
+
+func (q questionRepository) CountVisible() (int64, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	return int64(len(q.store.visibleQuestions())), nil
+}
+
+func (q questionRepository) FindByIDWithAnswers(id uint, answers repositories.Page) (*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	question, ok := q.store.questions[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	question.Answers = repositories.SelectPage(answers, q.store.visibleAnswersOf(question.ID), answerID)
+	return &question, nil
+}
+
+func (q questionRepository) FindByUser(userID uuid.UUID, page repositories.Page) ([]*models.Question, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	questions := []*models.Question{}
+	for _, question := range repositories.SelectPage(page, q.store.questionsBy(userID), questionID) {
+		questions = append(questions, &question)
+	}
+	return questions, nil
+}
+
+func (q questionRepository) ActivityOf(userID uuid.UUID) (repositories.Activity, error) {
+	q.store.mu.RLock()
+	defer q.store.mu.RUnlock()
+
+	questions := q.store.questionsBy(userID)
+	if len(questions) == 0 {
+		return repositories.Activity{}, nil
+	}
+	return repositories.Activity{
+		Count:   int64(len(questions)),
+		FirstAt: &questions[0].CreatedAt,
+		LastAt:  &questions[len(questions)-1].CreatedAt,
+	}, nil
+}
+
+func (q questionRepository) UpdateAnswerStats(id uint, delta int) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	question, ok := q.store.questions[int(id)]
+	if !ok {
+		return nil
+	}
+
+	question.AnswerCount += delta
+	question.LastActivityAt = q.store.lastActivity(question)
+	q.store.questions[question.ID] = question
+	return nil
+}
+
+func (q questionRepository) RepairAnswerStats() (int64, error) {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	var repaired int64
+	for id, question := range q.store.questions {
+		count, lastActivity := len(q.store.visibleAnswersOf(id)), q.store.lastActivity(question)
+		if question.AnswerCount == count && question.LastActivityAt.Equal(lastActivity) {
+			continue
+		}
+		question.AnswerCount, question.LastActivityAt = count, lastActivity
+		q.store.questions[id] = question
+		repaired++
+	}
+	return repaired, nil
+}
+
+func (q questionRepository) RenderText(render func(string) string) (int64, error) {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	var updated int64
+	for id, question := range q.store.questions {
+		if html := render(question.Text); html != question.TextHTML {
+			question.TextHTML = html
+			q.store.questions[id] = question
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (q questionRepository) SetHidden(id uint, hidden bool) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	if question, ok := q.store.questions[int(id)]; ok {
+		question.Hidden = hidden
+		q.store.questions[question.ID] = question
+	}
+	return nil
+}
+
+func (q questionRepository) Delete(id uint) error {
+	q.store.mu.Lock()
+	defer q.store.mu.Unlock()
+
+	delete(q.store.questions, int(id))
+	for answerID, answer := range q.store.answers {
+		if answer.QuestionID == id {
+			delete(q.store.answers, answerID)
+		}
+	}
+	q.store.deleteQuestionRelations(id)
+	return nil
+}
+
+// answersOf returns the answers of a question ordered by ID.
+// The caller must hold the store lock.
+func (s *Store) answersOf(questionID int) []models.Answer {
+	answers := []models.Answer{}
+	for _, answer := range s.answers {
+		if int(answer.QuestionID) == questionID {
+			answers = append(answers, answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].ID < answers[j].ID
+	})
+	return answers
+}
+
+// visibleAnswersOf returns the answers of a question that are not hidden,
+// ordered by ID. The caller must hold the store lock.
+func (s *Store) visibleAnswersOf(questionID int) []models.Answer {
+	answers := []models.Answer{}
+	for _, answer := range s.answersOf(questionID) {
+		if !answer.Hidden {
+			answers = append(answers, answer)
+		}
+	}
+	return answers
+}
+
+// visibleQuestions returns the questions that are not hidden ordered by ID.
+// The caller must hold the store lock.
+func (s *Store) visibleQuestions() []models.Question {
+	questions := []models.Question{}
+	for _, question := range s.questions {
+		if !question.Hidden {
+			questions = append(questions, question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].ID < questions[j].ID
+	})
+	return questions
+}
+
+// questionsBy returns the visible questions asked by the user ordered by ID.
+// The caller must hold the store lock.
+func (s *Store) questionsBy(userID uuid.UUID) []models.Question {
+	questions := []models.Question{}
+	for _, question := range s.questions {
+		if question.UserID != nil && *question.UserID == userID && !question.Hidden {
+			questions = append(questions, question)
+		}
+	}
+	sort.Slice(questions, func(i, j int) bool {
+		return questions[i].ID < questions[j].ID
+	})
+	return questions
+}
+
+// lastActivity returns the time of the newest visible answer of the
+// question, or its creation time without one. The caller must hold the
+// store lock.
+func (s *Store) lastActivity(question models.Question) time.Time {
+	last := question.CreatedAt
+	for _, answer := range s.visibleAnswersOf(question.ID) {
+		if answer.CreatedAt.After(last) {
+			last = answer.CreatedAt
+		}
+	}
+	return last
+}
+
+func questionID(question models.Question) int { return question.ID }
+
+func answerID(answer models.Answer) int { return answer.ID }
+
+func reputationEventID(entry models.ReputationEvent) int { return entry.ID }
+
+func caseID(moderationCase models.ModerationCase) int { return moderationCase.ID }
@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type reputationRepository struct {
+	store *Store
+}
+
+func NewReputationRepository(store *Store) repositories.ReputationRepository {
+	return &reputationRepository{
+		store,
+	}
+}
+
+func (r reputationRepository) Award(entry *models.ReputationEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.award(entry)
+}
+
+func (r reputationRepository) AnswerPoints(userID uuid.UUID, answerID uint) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	points := 0
+	for _, entry := range r.store.reputation {
+		if entry.UserID == userID && entry.AnswerID == answerID {
+			points += entry.Points
+		}
+	}
+	return points, nil
+}
+
+func (r reputationRepository) QuestionAnswerPoints(questionID uint) ([]*models.ReputationEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type key struct {
+		userID   uuid.UUID
+		answerID uint
+	}
+	points := map[key]int{}
+	for _, entry := range r.store.reputation {
+		if entry.QuestionID == questionID && entry.AnswerID != 0 {
+			points[key{entry.UserID, entry.AnswerID}] += entry.Points
+		}
+	}
+
+	sums := []*models.ReputationEvent{}
+	for k, sum := range points {
+		if sum != 0 {
+			sums = append(sums, &models.ReputationEvent{UserID: k.userID, Points: sum, QuestionID: questionID, AnswerID: k.answerID})
+		}
+	}
+	sort.Slice(sums, func(i, j int) bool {
+		return sums[i].AnswerID < sums[j].AnswerID
+	})
+	return sums, nil
+}
+
+func (r reputationRepository) FindByUser(userID uuid.UUID, page repositories.Page) ([]*models.ReputationEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entries := []*models.ReputationEvent{}
+	for _, entry := range repositories.SelectPage(page, r.store.reputationOf(userID), reputationEventID) {
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+func (r reputationRepository) CountByUser(userID uuid.UUID) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return int64(len(r.store.reputationOf(userID))), nil
+}
+
+func (r reputationRepository) AwardMissingAnswers(points int) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	awarded := map[uint]bool{}
+	for _, entry := range r.store.reputation {
+		if entry.Reason == models.ReputationAnswerPosted {
+			awarded[entry.AnswerID] = true
+		}
+	}
+
+	answers := make([]models.Answer, 0, len(r.store.answers))
+	for _, answer := range r.store.answers {
+		if !answer.Hidden && !awarded[uint(answer.ID)] {
+			answers = append(answers, answer)
+		}
+	}
+	sort.Slice(answers, func(i, j int) bool {
+		return answers[i].ID < answers[j].ID
+	})
+
+	for _, answer := range answers {
+		err := r.store.award(&models.ReputationEvent{
+			UserID:     answer.UserID,
+			Reason:     models.ReputationAnswerPosted,
+			Points:     points,
+			QuestionID: answer.QuestionID,
+			AnswerID:   uint(answer.ID),
+			CreatedAt:  answer.CreatedAt,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(answers)), nil
+}
+
+func (r reputationRepository) Rebuild() (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	totals := map[uuid.UUID]int{}
+	for _, entry := range r.store.reputation {
+		totals[entry.UserID] += entry.Points
+	}
+
+	var rebuilt int64
+	for id, user := range r.store.users {
+		if user.Reputation != totals[id] {
+			user.Reputation = totals[id]
+			r.store.users[id] = user
+			rebuilt++
+		}
+	}
+	return rebuilt, nil
+}
+
+// award records the entry and updates the user's total. The caller must
+// hold the store lock.
+func (s *Store) award(entry *models.ReputationEvent) error {
+	user, ok := s.users[entry.UserID]
+	if !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	s.nextReputationID++
+	entry.ID = s.nextReputationID
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	s.reputation[entry.ID] = *entry
+
+	user.Reputation += entry.Points
+	s.users[entry.UserID] = user
+	return nil
+}
+
+// reputationOf returns the user's ledger ordered by ID. The caller must
+// hold the store lock.
+func (s *Store) reputationOf(userID uuid.UUID) []models.ReputationEvent {
+	entries := []models.ReputationEvent{}
+	for _, entry := range s.reputation {
+		if entry.UserID == userID {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
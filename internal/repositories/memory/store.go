@@ -0,0 +1,99 @@
+// Package memory implements the repositories on top of process memory.
+// It is meant for demos and tests: nothing survives a restart.
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Store holds the data shared by the repositories, so that deleting a
+// question also removes its answers and answers can only be written by
+// known users.
+type Store struct {
+	mu                 sync.RWMutex
+	questions          map[int]models.Question
+	answers            map[int]models.Answer
+	webhooks           map[int]models.Webhook
+	deliveries         map[int]models.WebhookDelivery
+	outbox             map[int]models.OutboxEvent
+	follows            map[followKey]models.Follow
+	notifications      map[int]models.Notification
+	users              map[uuid.UUID]models.User
+	reputation         map[int]models.ReputationEvent
+	cases              map[int]models.ModerationCase
+	flags              map[int]models.Flag
+	attachments        map[int]models.Attachment
+	nextQuestionID     int
+	nextAnswerID       int
+	nextWebhookID      int
+	nextDeliveryID     int
+	nextOutboxID       int
+	nextNotificationID int
+	nextReputationID   int
+	nextCaseID         int
+	nextFlagID         int
+	nextAttachmentID   int
+
+	// relayMu keeps two relays from handling the same outbox events.
+	relayMu sync.Mutex
+}
+
+type followKey struct {
+	questionID uint
+	userID     uuid.UUID
+}
+
+func NewStore() *Store {
+	return &Store{
+		questions:     make(map[int]models.Question),
+		answers:       make(map[int]models.Answer),
+		webhooks:      make(map[int]models.Webhook),
+		deliveries:    make(map[int]models.WebhookDelivery),
+		outbox:        make(map[int]models.OutboxEvent),
+		follows:       make(map[followKey]models.Follow),
+		notifications: make(map[int]models.Notification),
+		users:         make(map[uuid.UUID]models.User),
+		reputation:    make(map[int]models.ReputationEvent),
+		cases:         make(map[int]models.ModerationCase),
+		flags:         make(map[int]models.Flag),
+		attachments:   make(map[int]models.Attachment),
+	}
+}
+
+// deleteQuestionRelations removes what the database cascades when a
+// question is deleted. The caller must hold the store lock.
+func (s *Store) deleteQuestionRelations(questionID uint) {
+	for key := range s.follows {
+		if key.questionID == questionID {
+			delete(s.follows, key)
+		}
+	}
+	for id, notification := range s.notifications {
+		if notification.QuestionID == questionID {
+			delete(s.notifications, id)
+		}
+	}
+	for id, attachment := range s.attachments {
+		if attachment.QuestionID == questionID {
+			delete(s.attachments, id)
+		}
+	}
+}
+
+// deleteAnswerRelations removes what the database cascades when an answer
+// is deleted. The caller must hold the store lock.
+func (s *Store) deleteAnswerRelations(answerID uint) {
+	for id, notification := range s.notifications {
+		if notification.AnswerID == answerID {
+			delete(s.notifications, id)
+		}
+	}
+	for id, attachment := range s.attachments {
+		if attachment.AnswerID != nil && *attachment.AnswerID == answerID {
+			delete(s.attachments, id)
+		}
+	}
+}
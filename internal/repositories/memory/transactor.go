@@ -0,0 +1,40 @@
+package memory
+
+import "api_service_questions_and_answers/internal/repositories"
+
+type transactor struct {
+	store    *Store
+	onCommit func()
+}
+
+// NewTransactor runs transactions against the store. There is no rollback:
+// changes made before fn fails are kept, which is acceptable for the demo
+// and test storage this package provides.
+func NewTransactor(store *Store, onCommit func()) repositories.Transactor {
+	return &transactor{
+		store,
+		onCommit,
+	}
+}
+
+func (t transactor) Transaction(fn func(tx repositories.Repositories) error) error {
+	err := fn(bind(t.store))
+	if err != nil {
+		return err
+	}
+
+	t.onCommit()
+	return nil
+}
+
+// bind builds the repositories of a transaction against store.
+func bind(store *Store) repositories.Repositories {
+	return repositories.Repositories{
+		Questions:  NewQuestionRepository(store),
+		Answers:    NewAnswerRepository(store),
+		Outbox:     NewOutboxRepository(store),
+		Moderation: NewModerationRepository(store),
+		Reputation: NewReputationRepository(store),
+		Webhooks:   NewWebhookRepository(store),
+	}
+}
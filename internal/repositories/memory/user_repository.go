@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) repositories.UserRepository {
+	return &userRepository{
+		store,
+	}
+}
+
+func (u userRepository) Create(user *models.User) error {
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	if _, ok := u.store.users[user.ID]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	if user.Email != nil {
+		for _, existing := range u.store.users {
+			if existing.Email != nil && *existing.Email == *user.Email {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+	}
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	u.store.users[user.ID] = *user
+	return nil
+}
+
+func (u userRepository) FindByID(id uuid.UUID) (*models.User, error) {
+	u.store.mu.RLock()
+	defer u.store.mu.RUnlock()
+
+	user, ok := u.store.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (u userRepository) UpdateStatus(id uuid.UUID, status string) error {
+	u.store.mu.Lock()
+	defer u.store.mu.Unlock()
+
+	user, ok := u.store.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.Status = status
+	u.store.users[id] = user
+	return nil
+}
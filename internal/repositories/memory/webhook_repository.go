@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) repositories.WebhookRepository {
+	return &webhookRepository{
+		store,
+	}
+}
+
+func (w webhookRepository) Create(webhook *models.Webhook) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	w.store.nextWebhookID++
+	webhook.ID = w.store.nextWebhookID
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now()
+	}
+
+	stored := *webhook
+	stored.Events = append([]string(nil), webhook.Events...)
+	w.store.webhooks[stored.ID] = stored
+	return nil
+}
+
+func (w webhookRepository) FindAll() ([]*models.Webhook, error) {
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	webhooks := make([]*models.Webhook, 0, len(w.store.webhooks))
+	for _, webhook := range w.store.webhooks {
+		webhooks = append(webhooks, &webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+	return webhooks, nil
+}
+
+func (w webhookRepository) FindByID(id uint) (*models.Webhook, error) {
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	webhook, ok := w.store.webhooks[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &webhook, nil
+}
+
+func (w webhookRepository) Delete(id uint) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	delete(w.store.webhooks, int(id))
+	for deliveryID, delivery := range w.store.deliveries {
+		if delivery.WebhookID == id {
+			delete(w.store.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (w webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if _, ok := w.store.webhooks[int(delivery.WebhookID)]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+
+	w.store.nextDeliveryID++
+	delivery.ID = w.store.nextDeliveryID
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+
+	w.store.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (w webhookRepository) FindDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	delivery, ok := w.store.deliveries[int(id)]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &delivery, nil
+}
+
+func (w webhookRepository) FindDeliveries(webhookID uint) ([]*models.WebhookDelivery, error) {
+	w.store.mu.RLock()
+	defer w.store.mu.RUnlock()
+
+	deliveries := []*models.WebhookDelivery{}
+	for _, delivery := range w.store.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ID > deliveries[j].ID
+	})
+	return deliveries, nil
+}
+
+func (w webhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	deliveries := []*models.WebhookDelivery{}
+	for _, delivery := range w.store.deliveries {
+		if delivery.Status == models.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt)
+	})
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	for _, delivery := range deliveries {
+		delivery.NextAttemptAt = leaseUntil
+		w.store.deliveries[delivery.ID] = *delivery
+	}
+	return deliveries, nil
+}
+
+func (w webhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if _, ok := w.store.deliveries[delivery.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	w.store.deliveries[delivery.ID] = *delivery
+	return nil
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModerationRepository interface {
+	// OpenCase returns the open case of the content, opening one if there
+	// is none.
+	OpenCase(contentType string, contentID uint) (*models.ModerationCase, error)
+	// HoldForReview opens a case for new content that a content filter held
+	// for review, recording why.
+	HoldForReview(contentType string, contentID uint, reason string) (*models.ModerationCase, error)
+	// AddFlag records the flag on its case and returns the number of flags
+	// the case now has. A second flag by the same user on the case fails
+	// with gorm.ErrDuplicatedKey.
+	AddFlag(flag *models.Flag) (int, error)
+	// WasApproved reports whether a moderator has ever approved the content.
+	WasApproved(contentType string, contentID uint) (bool, error)
+	// FindCase returns the case with its flags.
+	FindCase(id uint) (*models.ModerationCase, error)
+	// FindOpen returns one page of the open cases with their flags.
+	FindOpen(page Page) ([]*models.ModerationCase, error)
+	CountOpen() (int64, error)
+	// Resolve closes an open case with status, recording the moderator and
+	// the time. It returns gorm.ErrRecordNotFound when the case is not open.
+	Resolve(id uint, status string, moderatorID uuid.UUID, at time.Time) error
+}
+
+type moderationRepository struct {
+	database *gorm.DB
+}
+
+func NewModerationRepository(database *gorm.DB) ModerationRepository {
+	return &moderationRepository{
+		database,
+	}
+}
+
+func (m moderationRepository) OpenCase(contentType string, contentID uint) (*models.ModerationCase, error) {
+	// A unique index allows one open case per content, so of two flags
+	// racing to open it one inserts and the other finds it.
+	err := m.database.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ModerationCase{
+		ContentType: contentType,
+		ContentID:   contentID,
+		Status:      models.CaseOpen,
+		CreatedAt:   time.Now(),
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var moderationCase models.ModerationCase
+	err = m.database.
+		Where("content_type = ? AND content_id = ? AND status = ?", contentType, contentID, models.CaseOpen).
+		First(&moderationCase).Error
+	if err != nil {
+		return nil, err
+	}
+	return &moderationCase, nil
+}
+
+func (m moderationRepository) HoldForReview(contentType string, contentID uint, reason string) (*models.ModerationCase, error) {
+	moderationCase := &models.ModerationCase{
+		ContentType:  contentType,
+		ContentID:    contentID,
+		Status:       models.CaseOpen,
+		ReviewReason: reason,
+		CreatedAt:    time.Now(),
+	}
+	err := m.database.Create(moderationCase).Error
+	if err != nil {
+		return nil, err
+	}
+	return moderationCase, nil
+}
+
+func (m moderationRepository) AddFlag(flag *models.Flag) (int, error) {
+	var count int
+	// Inside a transaction this becomes a savepoint.
+	err := m.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(flag).Error
+		if err != nil {
+			return err
+		}
+
+		// The update locks the case, so concurrent flags count one by one.
+		err = tx.Model(&models.ModerationCase{}).Where("id = ?", flag.CaseID).
+			Update("flag_count", gorm.Expr("flag_count + 1")).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.ModerationCase{}).Where("id = ?", flag.CaseID).
+			Select("flag_count").Scan(&count).Error
+	})
+	return count, err
+}
+
+func (m moderationRepository) WasApproved(contentType string, contentID uint) (bool, error) {
+	var count int64
+	err := m.database.Model(&models.ModerationCase{}).
+		Where("content_type = ? AND content_id = ? AND status = ?", contentType, contentID, models.CaseApproved).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (m moderationRepository) FindCase(id uint) (*models.ModerationCase, error) {
+	var moderationCase models.ModerationCase
+	err := m.database.Preload("Flags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).First(&moderationCase, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &moderationCase, nil
+}
+
+func (m moderationRepository) FindOpen(page Page) ([]*models.ModerationCase, error) {
+	var cases []*models.ModerationCase
+	err := m.database.Preload("Flags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("status = ?", models.CaseOpen).Scopes(page.scope).Find(&cases).Error
+	if err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+func (m moderationRepository) CountOpen() (int64, error) {
+	var count int64
+	err := m.database.Model(&models.ModerationCase{}).Where("status = ?", models.CaseOpen).Count(&count).Error
+	return count, err
+}
+
+func (m moderationRepository) Resolve(id uint, status string, moderatorID uuid.UUID, at time.Time) error {
+	result := m.database.Model(&models.ModerationCase{}).
+		Where("id = ? AND status = ?", id, models.CaseOpen).
+		Updates(map[string]any{
+			"status":       status,
+			"moderator_id": moderatorID,
+			"resolved_at":  at,
+		})
+	if result.Error == nil && result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return result.Error
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	// Create ignores a notification the user already has for the same
+	// answer, so redelivered events do not notify twice.
+	Create(notification *models.Notification) error
+	// FindByUser returns up to limit notifications of the user, newest first.
+	FindByUser(userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	// MarkRead returns gorm.ErrRecordNotFound when the notification does not
+	// belong to the user.
+	MarkRead(userID uuid.UUID, id uint, at time.Time) error
+	MarkAllRead(userID uuid.UUID, at time.Time) error
+}
+
+type notificationRepository struct {
+	database *gorm.DB
+}
+
+func NewNotificationRepository(database *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		database,
+	}
+}
+
+func (n notificationRepository) Create(notification *models.Notification) error {
+	return n.database.Clauses(clause.OnConflict{DoNothing: true}).Create(notification).Error
+}
+
+func (n notificationRepository) FindByUser(userID uuid.UUID, unreadOnly bool, limit int) ([]*models.Notification, error) {
+	query := n.database.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []*models.Notification
+	err := query.Order("id DESC").Limit(limit).Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (n notificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := n.database.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+func (n notificationRepository) MarkRead(userID uuid.UUID, id uint, at time.Time) error {
+	var notification models.Notification
+	err := n.database.Where("user_id = ?", userID).First(&notification, id).Error
+	if err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	return n.database.Model(&notification).Update("read_at", at).Error
+}
+
+func (n notificationRepository) MarkAllRead(userID uuid.UUID, at time.Time) error {
+	return n.database.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at).Error
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Add(event *models.OutboxEvent) error
+	// ProcessPending claims up to limit pending events with an id above
+	// afterID until leaseUntil and passes them to handle oldest first. An
+	// event handle reports as handled is saved with whatever handle changed;
+	// the others are released for the next call. claimed sees each event
+	// inside the claim transaction, so what it writes to tx and to the event
+	// is committed together with the claim; an error rolls the claim back.
+	// It returns how many events were claimed.
+	ProcessPending(
+		afterID, limit int,
+		leaseUntil time.Time,
+		claimed func(tx Repositories, event *models.OutboxEvent) error,
+		handle func(event *models.OutboxEvent) bool,
+	) (int, error)
+	FindByID(id uint) (*models.OutboxEvent, error)
+}
+
+type outboxRepository struct {
+	database *gorm.DB
+}
+
+func NewOutboxRepository(database *gorm.DB) OutboxRepository {
+	return &outboxRepository{
+		database,
+	}
+}
+
+func (o outboxRepository) Add(event *models.OutboxEvent) error {
+	return o.database.Create(event).Error
+}
+
+// ProcessPending handles the claimed batch outside a transaction: sinks do
+// network I/O and may write to the database themselves. Each event is saved
+// on its own, so one failed save leaves the rest of the batch done. An
+// event whose relay died mid-batch is claimed again once its lease runs out.
+func (o outboxRepository) ProcessPending(
+	afterID, limit int,
+	leaseUntil time.Time,
+	claimed func(tx Repositories, event *models.OutboxEvent) error,
+	handle func(event *models.OutboxEvent) bool,
+) (int, error) {
+	pending, err := o.claim(afterID, limit, leaseUntil, claimed)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	var released []int
+	for _, event := range pending {
+		if !handle(event) {
+			released = append(released, event.ID)
+			continue
+		}
+
+		event.ClaimedUntil = nil
+		if err := o.database.Save(event).Error; err != nil {
+			errs = append(errs, fmt.Errorf("failed to save outbox event %d: %w", event.ID, err))
+			released = append(released, event.ID)
+		}
+	}
+
+	if len(released) > 0 {
+		err := o.database.Model(&models.OutboxEvent{}).Where("id IN ?", released).Update("claimed_until", nil).Error
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to release outbox events: %w", err))
+		}
+	}
+	return len(pending), errors.Join(errs...)
+}
+
+// claim locks the batch with FOR UPDATE SKIP LOCKED on postgres, so several
+// relays can run side by side without handling an event twice. SQLite
+// serialises writers, so there the transaction alone is enough.
+func (o outboxRepository) claim(
+	afterID, limit int,
+	leaseUntil time.Time,
+	claimed func(tx Repositories, event *models.OutboxEvent) error,
+) ([]*models.OutboxEvent, error) {
+	var pending []*models.OutboxEvent
+	err := o.database.Transaction(func(tx *gorm.DB) error {
+		query := tx
+		if tx.Dialector.Name() == "postgres" {
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		err := query.
+			Where("status = ? AND id > ? AND (claimed_until IS NULL OR claimed_until <= ?)", models.OutboxPending, afterID, time.Now()).
+			Order("id").
+			Limit(limit).
+			Find(&pending).Error
+		if err != nil {
+			return err
+		}
+
+		for _, event := range pending {
+			event.ClaimedUntil = &leaseUntil
+			if err := claimed(bind(tx), event); err != nil {
+				return err
+			}
+			if err := tx.Save(event).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+func (o outboxRepository) FindByID(id uint) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+	err := o.database.First(&event, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
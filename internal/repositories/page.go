@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PageSort orders a page of records by id.
+type PageSort string
+
+const (
+	OldestFirst PageSort = "oldest"
+	NewestFirst PageSort = "newest"
+)
+
+// PageSorts lists the accepted page orders.
+var PageSorts = []PageSort{OldestFirst, NewestFirst}
+
+// Page selects up to Limit records that come after the record with id
+// After in Sort order. Records are ordered by id, which follows creation,
+// so a page stays stable while records are added. A zero After starts from
+// the first record, a zero Limit means no limit and an empty Sort is
+// OldestFirst.
+type Page struct {
+	After uint
+	Limit int
+	Sort  PageSort
+}
+
+// SelectPage returns the page of items, which must be ordered by id.
+func SelectPage[T any](page Page, items []T, id func(T) int) []T {
+	selected := []T{}
+	for i := range items {
+		item := items[i]
+		if page.Sort == NewestFirst {
+			item = items[len(items)-1-i]
+		}
+		if page.After != 0 && !page.follows(uint(id(item))) {
+			continue
+		}
+		if page.Limit > 0 && len(selected) == page.Limit {
+			break
+		}
+		selected = append(selected, item)
+	}
+	return selected
+}
+
+func (p Page) follows(id uint) bool {
+	if p.Sort == NewestFirst {
+		return id < p.After
+	}
+	return id > p.After
+}
+
+func (p Page) scope(db *gorm.DB) *gorm.DB {
+	if p.Sort == NewestFirst {
+		db = db.Order("id DESC")
+		if p.After != 0 {
+			db = db.Where("id < ?", p.After)
+		}
+	} else {
+		db = db.Order("id")
+		if p.After != 0 {
+			db = db.Where("id > ?", p.After)
+		}
+	}
+	if p.Limit > 0 {
+		db = db.Limit(p.Limit)
+	}
+	return db
+}
+
+// Activity summarises the records a user created. FirstAt and LastAt are
+// nil when there are none.
+type Activity struct {
+	Count   int64
+	FirstAt *time.Time
+	LastAt  *time.Time
+}
+
+// activityOf summarises the visible rows of model whose user_id is userID.
+func activityOf(db *gorm.DB, model any, userID uuid.UUID) (Activity, error) {
+	var activity Activity
+	byUser := func() *gorm.DB {
+		return db.Model(model).Where("user_id = ? AND hidden = ?", userID, false)
+	}
+
+	err := byUser().Count(&activity.Count).Error
+	if err != nil || activity.Count == 0 {
+		return activity, err
+	}
+
+	var first, last struct{ CreatedAt time.Time }
+	err = byUser().Select("created_at").Order("id").Limit(1).Scan(&first).Error
+	if err != nil {
+		return Activity{}, err
+	}
+	err = byUser().Select("created_at").Order("id DESC").Limit(1).Scan(&last).Error
+	if err != nil {
+		return Activity{}, err
+	}
+
+	activity.FirstAt, activity.LastAt = &first.CreatedAt, &last.CreatedAt
+	return activity, nil
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuestionSort string
+
+const (
+	// SortByID is the default order, oldest id first.
+	SortByID           QuestionSort = ""
+	SortNewest         QuestionSort = "newest"
+	SortOldest         QuestionSort = "oldest"
+	SortMostAnswers    QuestionSort = "most_answers"
+	SortRecentActivity QuestionSort = "recent_activity"
+)
+
+// QuestionSorts lists the orders a client can ask for.
+var QuestionSorts = []QuestionSort{SortNewest, SortOldest, SortMostAnswers, SortRecentActivity}
+
+// QuestionQuery selects and orders questions. The zero value lists every
+// question by id; build others from QuestionSpecs with NewQuestionQuery.
+type QuestionQuery struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Unanswered    bool
+	MinAnswers    int
+	Author        uuid.UUID
+	Sort          QuestionSort
+}
+
+// QuestionSpec narrows or orders a QuestionQuery. Specs compose: each one
+// sets its own criterion and leaves the others alone.
+type QuestionSpec func(*QuestionQuery)
+
+func NewQuestionQuery(specs ...QuestionSpec) QuestionQuery {
+	var query QuestionQuery
+	for _, spec := range specs {
+		spec(&query)
+	}
+	return query
+}
+
+// CreatedAfter keeps questions created strictly after t.
+func CreatedAfter(t time.Time) QuestionSpec {
+	return func(q *QuestionQuery) { q.CreatedAfter = t }
+}
+
+// CreatedBefore keeps questions created strictly before t.
+func CreatedBefore(t time.Time) QuestionSpec {
+	return func(q *QuestionQuery) { q.CreatedBefore = t }
+}
+
+// Unanswered keeps questions without answers.
+func Unanswered() QuestionSpec {
+	return func(q *QuestionQuery) { q.Unanswered = true }
+}
+
+// MinAnswers keeps questions with at least n answers.
+func MinAnswers(n int) QuestionSpec {
+	return func(q *QuestionQuery) { q.MinAnswers = n }
+}
+
+// ByAuthor keeps questions asked by userID.
+func ByAuthor(userID uuid.UUID) QuestionSpec {
+	return func(q *QuestionQuery) { q.Author = userID }
+}
+
+func SortedBy(sort QuestionSort) QuestionSpec {
+	return func(q *QuestionQuery) { q.Sort = sort }
+}
+
+// Matches reports whether the question is selected. Repositories that
+// cannot push the query down to a database use it.
+func (q QuestionQuery) Matches(question *models.Question) bool {
+	if !q.CreatedAfter.IsZero() && !question.CreatedAt.After(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !question.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	if q.Unanswered && question.AnswerCount > 0 {
+		return false
+	}
+	if question.AnswerCount < q.MinAnswers {
+		return false
+	}
+	if q.Author != uuid.Nil && (question.UserID == nil || *question.UserID != q.Author) {
+		return false
+	}
+	return true
+}
+
+// Less orders two selected questions like the database does; ties are
+// broken by id.
+func (q QuestionQuery) Less(a, b *models.Question) bool {
+	switch q.Sort {
+	case SortNewest:
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	case SortOldest:
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+	case SortMostAnswers:
+		if a.AnswerCount != b.AnswerCount {
+			return a.AnswerCount > b.AnswerCount
+		}
+		return a.ID > b.ID
+	case SortRecentActivity:
+		if !a.LastActivityAt.Equal(b.LastActivityAt) {
+			return a.LastActivityAt.After(b.LastActivityAt)
+		}
+		return a.ID > b.ID
+	}
+	return a.ID < b.ID
+}
+
+// scopes translates the query into gorm scopes, one per criterion.
+func (q QuestionQuery) scopes() []func(*gorm.DB) *gorm.DB {
+	var scopes []func(*gorm.DB) *gorm.DB
+	where := func(query string, args ...any) {
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB { return db.Where(query, args...) })
+	}
+
+	if !q.CreatedAfter.IsZero() {
+		where("questions.created_at > ?", q.CreatedAfter)
+	}
+	if !q.CreatedBefore.IsZero() {
+		where("questions.created_at < ?", q.CreatedBefore)
+	}
+	if q.Unanswered {
+		where("questions.answer_count = 0")
+	}
+	if q.MinAnswers > 0 {
+		where("questions.answer_count >= ?", q.MinAnswers)
+	}
+	if q.Author != uuid.Nil {
+		where("questions.user_id = ?", q.Author)
+	}
+
+	order := "questions.id"
+	switch q.Sort {
+	case SortNewest:
+		order = "questions.created_at DESC, questions.id DESC"
+	case SortOldest:
+		order = "questions.created_at, questions.id"
+	case SortMostAnswers:
+		order = "questions.answer_count DESC, questions.id DESC"
+	case SortRecentActivity:
+		order = "questions.last_activity_at DESC, questions.id DESC"
+	}
+	scopes = append(scopes, func(db *gorm.DB) *gorm.DB { return db.Order(order) })
+
+	return scopes
+}
@@ -2,17 +2,55 @@ package repositories
 
 import (
 	"api_service_questions_and_answers/internal/models"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type QuestionRepository interface {
 	FindAll() ([]*models.Question, error)
+	// Find returns the questions selected by query, in its order.
+	Find(query QuestionQuery) ([]*models.Question, error)
 	Create(question *models.Question) error
+	// FindByID returns the question, hidden or not, with its visible
+	// answers.
 	FindByID(id uint) (*models.Question, error)
+	// FindByIDs returns the questions with the given ids, hidden or not,
+	// without their answers. Missing ids are skipped.
+	FindByIDs(ids []uint) ([]*models.Question, error)
+	// FindPage returns one page of the visible questions.
+	FindPage(page Page) ([]*models.Question, error)
+	// CountVisible counts the questions that are not hidden.
+	CountVisible() (int64, error)
+	// FindByIDWithAnswers returns the question with one page of its visible
+	// answers.
+	FindByIDWithAnswers(id uint, answers Page) (*models.Question, error)
+	// FindByUser returns one page of the visible questions asked by the
+	// user.
+	FindByUser(userID uuid.UUID, page Page) ([]*models.Question, error)
+	// ActivityOf summarises the visible questions asked by the user.
+	ActivityOf(userID uuid.UUID) (Activity, error)
+	// UpdateAnswerStats adds delta to the answer count of the question and
+	// recomputes its last activity from its visible answers. Hidden answers
+	// are not counted, so callers pass a delta only for visible ones.
+	UpdateAnswerStats(id uint, delta int) error
+	// RepairAnswerStats recomputes the answer count and last activity of
+	// every question from the visible answers and returns how many were
+	// wrong.
+	RepairAnswerStats() (int64, error)
+	// RenderText stores render(text) as the text_html of every question
+	// where it differs and returns how many were updated.
+	RenderText(render func(string) string) (int64, error)
+	SetHidden(id uint, hidden bool) error
 	Delete(id uint) error
 }
 
+const (
+	answerCountSQL  = "(SELECT COUNT(*) FROM answers WHERE answers.question_id = questions.id AND NOT answers.hidden)"
+	lastActivitySQL = "COALESCE((SELECT MAX(answers.created_at) FROM answers WHERE answers.question_id = questions.id AND NOT answers.hidden), questions.created_at)"
+)
+
 type questionRepository struct {
 	database *gorm.DB
 }
@@ -25,7 +63,16 @@ func NewQuestionRepository(database *gorm.DB) QuestionRepository {
 
 func (q questionRepository) FindAll() ([]*models.Question, error) {
 	var questions []*models.Question
-	err := q.database.Find(&questions).Error
+	err := q.database.Order("id").Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (q questionRepository) Find(query QuestionQuery) ([]*models.Question, error) {
+	var questions []*models.Question
+	err := q.database.Scopes(query.scopes()...).Find(&questions).Error
 	if err != nil {
 		return nil, err
 	}
@@ -33,18 +80,133 @@ func (q questionRepository) FindAll() ([]*models.Question, error) {
 }
 
 func (q questionRepository) Create(question *models.Question) error {
+	if question.CreatedAt.IsZero() {
+		question.CreatedAt = time.Now()
+	}
+	if question.LastActivityAt.IsZero() {
+		question.LastActivityAt = question.CreatedAt
+	}
 	return q.database.Create(question).Error
 }
 
 func (q questionRepository) FindByID(id uint) (*models.Question, error) {
 	var question models.Question
-	err := q.database.Preload("Answers").First(&question, id).Error
+	err := q.database.Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Where("hidden = ?", false).Order("id")
+	}).First(&question, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+func (q questionRepository) FindByIDs(ids []uint) ([]*models.Question, error) {
+	var questions []*models.Question
+	err := q.database.Where("id IN ?", ids).Order("id").Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (q questionRepository) FindPage(page Page) ([]*models.Question, error) {
+	var questions []*models.Question
+	err := q.database.Where("hidden = ?", false).Scopes(page.scope).Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (q questionRepository) CountVisible() (int64, error) {
+	var count int64
+	err := q.database.Model(&models.Question{}).Where("hidden = ?", false).Count(&count).Error
+	return count, err
+}
+
+func (q questionRepository) FindByIDWithAnswers(id uint, answers Page) (*models.Question, error) {
+	var question models.Question
+	err := q.database.Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Where("hidden = ?", false).Scopes(answers.scope)
+	}).First(&question, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &question, nil
 }
 
+func (q questionRepository) FindByUser(userID uuid.UUID, page Page) ([]*models.Question, error) {
+	var questions []*models.Question
+	err := q.database.Where("user_id = ? AND hidden = ?", userID, false).Scopes(page.scope).Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+func (q questionRepository) ActivityOf(userID uuid.UUID) (Activity, error) {
+	return activityOf(q.database, &models.Question{}, userID)
+}
+
+func (q questionRepository) UpdateAnswerStats(id uint, delta int) error {
+	return q.database.Model(&models.Question{}).Where("id = ?", id).Updates(map[string]any{
+		"answer_count":     gorm.Expr("answer_count + ?", delta),
+		"last_activity_at": gorm.Expr(lastActivitySQL),
+	}).Error
+}
+
+func (q questionRepository) RepairAnswerStats() (int64, error) {
+	result := q.database.Model(&models.Question{}).
+		Where("answer_count <> " + answerCountSQL + " OR last_activity_at IS NULL OR last_activity_at <> " + lastActivitySQL).
+		Updates(map[string]any{
+			"answer_count":     gorm.Expr(answerCountSQL),
+			"last_activity_at": gorm.Expr(lastActivitySQL),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (q questionRepository) RenderText(render func(string) string) (int64, error) {
+	return renderText(q.database, "questions", render)
+}
+
+func (q questionRepository) SetHidden(id uint, hidden bool) error {
+	return q.database.Model(&models.Question{}).Where("id = ?", id).Update("hidden", hidden).Error
+}
+
 func (q questionRepository) Delete(id uint) error {
 	return q.database.Delete(&models.Question{}, id).Error
 }
+
+// renderBatch is the number of rows renderText reads at a time.
+const renderBatch = 500
+
+// renderText re-renders the text_html of the rows of table, which is
+// questions or answers, in batches ordered by id.
+func renderText(database *gorm.DB, table string, render func(string) string) (int64, error) {
+	var updated int64
+	for after := 0; ; {
+		var rows []struct {
+			ID       int
+			Text     string
+			TextHTML string
+		}
+		err := database.Table(table).Select("id, text, text_html").
+			Where("id > ?", after).Order("id").Limit(renderBatch).Scan(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return updated, err
+		}
+
+		for _, row := range rows {
+			html := render(row.Text)
+			if html == row.TextHTML {
+				continue
+			}
+			err := database.Table(table).Where("id = ?", row.ID).Update("text_html", html).Error
+			if err != nil {
+				return updated, err
+			}
+			updated++
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
@@ -0,0 +1,1089 @@
+package repositories_test
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/database"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type backend struct {
+	name         string
+	question     repositories.QuestionRepository
+	answer       repositories.AnswerRepository
+	outbox       repositories.OutboxRepository
+	follow       repositories.FollowRepository
+	notification repositories.NotificationRepository
+	user         repositories.UserRepository
+	reputation   repositories.ReputationRepository
+	moderation   repositories.ModerationRepository
+	attachment   repositories.AttachmentRepository
+	webhook      repositories.WebhookRepository
+	transactor   repositories.Transactor
+}
+
+// backends returns every storage implementation the suite runs against.
+// Postgres is included when TEST_POSTGRES=1; it is configured through the
+// usual DB_* environment variables and must be a disposable database.
+func backends(t *testing.T) []backend {
+	t.Helper()
+
+	store := memory.NewStore()
+	result := []backend{{
+		name:         "memory",
+		question:     memory.NewQuestionRepository(store),
+		answer:       memory.NewAnswerRepository(store),
+		outbox:       memory.NewOutboxRepository(store),
+		follow:       memory.NewFollowRepository(store),
+		notification: memory.NewNotificationRepository(store),
+		user:         memory.NewUserRepository(store),
+		reputation:   memory.NewReputationRepository(store),
+		moderation:   memory.NewModerationRepository(store),
+		attachment:   memory.NewAttachmentRepository(store),
+		webhook:      memory.NewWebhookRepository(store),
+		transactor:   memory.NewTransactor(store, func() {}),
+	}}
+
+	sqliteDB := openDatabase(t, config.DatabaseConfig{
+		Driver: database.DialectSQLite,
+		Path:   filepath.Join(t.TempDir(), "qna.db"),
+	})
+	result = append(result, backend{
+		name:         "sqlite",
+		question:     repositories.NewQuestionRepository(sqliteDB.DB),
+		answer:       repositories.NewAnswerRepository(sqliteDB.DB),
+		outbox:       repositories.NewOutboxRepository(sqliteDB.DB),
+		follow:       repositories.NewFollowRepository(sqliteDB.DB),
+		notification: repositories.NewNotificationRepository(sqliteDB.DB),
+		user:         repositories.NewUserRepository(sqliteDB.DB),
+		reputation:   repositories.NewReputationRepository(sqliteDB.DB),
+		moderation:   repositories.NewModerationRepository(sqliteDB.DB),
+		attachment:   repositories.NewAttachmentRepository(sqliteDB.DB),
+		webhook:      repositories.NewWebhookRepository(sqliteDB.DB),
+		transactor:   repositories.NewTransactor(sqliteDB.DB, func() {}),
+	})
+
+	if os.Getenv("TEST_POSTGRES") == "1" {
+		t.Setenv("STORAGE_DRIVER", config.StoragePostgres)
+		cfg, err := config.Load()
+		require.NoError(t, err)
+
+		postgresDB := openDatabase(t, cfg.DB)
+		require.NoError(t, postgresDB.DB.Exec("TRUNCATE questions, answers, outbox_events, question_follows, notifications, users, reputation_events, moderation_cases, flags, attachments, webhooks, webhook_deliveries RESTART IDENTITY CASCADE").Error)
+		result = append(result, backend{
+			name:       "postgres",
+			question:   repositories.NewQuestionRepository(postgresDB.DB),
+			answer:     repositories.NewAnswerRepository(postgresDB.DB),
+			outbox:     repositories.NewOutboxRepository(postgresDB.DB),
+			user:       repositories.NewUserRepository(postgresDB.DB),
+			reputation: repositories.NewReputationRepository(postgresDB.DB),
+			moderation: repositories.NewModerationRepository(postgresDB.DB),
+			attachment: repositories.NewAttachmentRepository(postgresDB.DB),
+			webhook:    repositories.NewWebhookRepository(postgresDB.DB),
+		})
+	}
+
+	return result
+}
+
+// newUser registers an active user, which answers require.
+func (b backend) newUser(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	user := &models.User{ID: uuid.New(), DisplayName: "Tester", Status: models.UserActive}
+	require.NoError(t, b.user.Create(user))
+	return user.ID
+}
+
+func openDatabase(t *testing.T, cfg config.DatabaseConfig) *database.Database {
+	t.Helper()
+
+	cfg.AutoMigrate = false
+	db, err := database.NewDatabase(cfg)
+	require.NoError(t, err)
+	require.NoError(t, db.Migrate(t.Context(), "up", io.Discard))
+
+	t.Cleanup(func() {
+		sqlDB, err := db.DB.DB()
+		if err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestRepositories_CreateAndFindQuestion(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?", CreatedAt: time.Now()}
+			require.NoError(t, b.question.Create(question))
+			assert.NotZero(t, question.ID)
+
+			found, err := b.question.FindByID(uint(question.ID))
+			require.NoError(t, err)
+			assert.Equal(t, question.Text, found.Text)
+			assert.Empty(t, found.Answers)
+		})
+	}
+}
+
+func TestRepositories_FindAllOrderedByID(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			first := &models.Question{Text: "First question"}
+			second := &models.Question{Text: "Second question"}
+			require.NoError(t, b.question.Create(first))
+			require.NoError(t, b.question.Create(second))
+
+			questions, err := b.question.FindAll()
+			require.NoError(t, err)
+			require.Len(t, questions, 2)
+			assert.Equal(t, first.ID, questions[0].ID)
+			assert.Equal(t, second.ID, questions[1].ID)
+		})
+	}
+}
+
+func TestRepositories_FindByIDsAndPages(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			for _, text := range []string{"First question", "Hidden question", "Third question"} {
+				question := &models.Question{Text: text}
+				require.NoError(t, b.question.Create(question))
+				require.NoError(t, b.answer.Create(&models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "An answer"}))
+			}
+			require.NoError(t, b.question.SetHidden(2, true))
+
+			found, err := b.question.FindByIDs([]uint{3, 2, 42})
+			require.NoError(t, err)
+			require.Len(t, found, 2)
+			assert.Equal(t, []int{2, 3}, []int{found[0].ID, found[1].ID})
+			assert.Empty(t, found[0].Answers, "answers are not preloaded")
+
+			page, err := b.question.FindPage(repositories.Page{After: 1, Limit: 1})
+			require.NoError(t, err)
+			require.Len(t, page, 1)
+			assert.Equal(t, 3, page[0].ID, "hidden questions are skipped")
+
+			count, err := b.question.CountVisible()
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), count)
+		})
+	}
+}
+
+func TestRepositories_FindFiltersAndSorts(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			author := b.newUser(t)
+
+			// old has two answers, mid one recent answer, fresh none.
+			old := &models.Question{Text: "Old question", UserID: &author, CreatedAt: base}
+			mid := &models.Question{Text: "Mid question", CreatedAt: base.Add(time.Hour)}
+			fresh := &models.Question{Text: "Fresh question", UserID: &author, CreatedAt: base.Add(2 * time.Hour)}
+			for _, question := range []*models.Question{old, mid, fresh} {
+				require.NoError(t, b.question.Create(question))
+			}
+			for _, answer := range []*models.Answer{
+				{QuestionID: uint(old.ID), UserID: b.newUser(t), Text: "First answer", CreatedAt: base.Add(time.Minute)},
+				{QuestionID: uint(old.ID), UserID: b.newUser(t), Text: "Second answer", CreatedAt: base.Add(2 * time.Minute)},
+				{QuestionID: uint(mid.ID), UserID: b.newUser(t), Text: "Late answer", CreatedAt: base.Add(3 * time.Hour)},
+			} {
+				require.NoError(t, b.answer.Create(answer))
+				require.NoError(t, b.question.UpdateAnswerStats(answer.QuestionID, 1))
+			}
+
+			ids := func(specs ...repositories.QuestionSpec) []int {
+				questions, err := b.question.Find(repositories.NewQuestionQuery(specs...))
+				require.NoError(t, err)
+				result := []int{}
+				for _, question := range questions {
+					result = append(result, question.ID)
+				}
+				return result
+			}
+
+			assert.Equal(t, []int{old.ID, mid.ID, fresh.ID}, ids())
+			assert.Equal(t, []int{mid.ID}, ids(repositories.CreatedAfter(base), repositories.CreatedBefore(fresh.CreatedAt)))
+			assert.Equal(t, []int{fresh.ID}, ids(repositories.Unanswered()))
+			assert.Equal(t, []int{old.ID}, ids(repositories.MinAnswers(2)))
+			assert.Equal(t, []int{fresh.ID, old.ID}, ids(repositories.ByAuthor(author), repositories.SortedBy(repositories.SortNewest)))
+			assert.Equal(t, []int{old.ID, mid.ID, fresh.ID}, ids(repositories.SortedBy(repositories.SortOldest)))
+			assert.Equal(t, []int{old.ID, mid.ID, fresh.ID}, ids(repositories.SortedBy(repositories.SortMostAnswers)))
+			assert.Equal(t, []int{mid.ID, fresh.ID, old.ID}, ids(repositories.SortedBy(repositories.SortRecentActivity)))
+
+			found, err := b.question.FindByID(uint(old.ID))
+			require.NoError(t, err)
+			require.NotNil(t, found.UserID)
+			assert.Equal(t, author, *found.UserID)
+		})
+	}
+}
+
+func TestRepositories_FindByIDPreloadsAnswers(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+
+			userID := b.newUser(t)
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: userID, Text: "A language"}
+			require.NoError(t, b.answer.Create(answer))
+			assert.NotZero(t, answer.ID)
+
+			found, err := b.question.FindByID(uint(question.ID))
+			require.NoError(t, err)
+			require.Len(t, found.Answers, 1)
+			assert.Equal(t, answer.ID, found.Answers[0].ID)
+			assert.Equal(t, userID, found.Answers[0].UserID)
+		})
+	}
+}
+
+func TestRepositories_FindByIDWithAnswersPages(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "Question"}
+			require.NoError(t, b.question.Create(question))
+
+			var answerIDs []int
+			for range 5 {
+				answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "Answer"}
+				require.NoError(t, b.answer.Create(answer))
+				answerIDs = append(answerIDs, answer.ID)
+			}
+
+			ids := func(page repositories.Page) []int {
+				found, err := b.question.FindByIDWithAnswers(uint(question.ID), page)
+				require.NoError(t, err)
+				result := []int{}
+				for _, answer := range found.Answers {
+					result = append(result, answer.ID)
+				}
+				return result
+			}
+
+			assert.Equal(t, answerIDs, ids(repositories.Page{}))
+			assert.Equal(t, answerIDs[:2], ids(repositories.Page{Limit: 2}))
+			assert.Equal(t, answerIDs[2:4], ids(repositories.Page{After: uint(answerIDs[1]), Limit: 2}))
+			assert.Equal(t, []int{answerIDs[4], answerIDs[3]}, ids(repositories.Page{Limit: 2, Sort: repositories.NewestFirst}))
+			assert.Equal(t, []int{answerIDs[1], answerIDs[0]}, ids(repositories.Page{After: uint(answerIDs[2]), Sort: repositories.NewestFirst}))
+
+			_, err := b.question.FindByIDWithAnswers(999, repositories.Page{})
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_AnswerStats(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			question := &models.Question{Text: "Question", CreatedAt: base}
+			require.NoError(t, b.question.Create(question))
+
+			stats := func() (int, time.Time) {
+				found, err := b.question.FindByID(uint(question.ID))
+				require.NoError(t, err)
+				return found.AnswerCount, found.LastActivityAt
+			}
+
+			count, lastActivity := stats()
+			assert.Zero(t, count)
+			assert.True(t, base.Equal(lastActivity))
+
+			first := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "First", CreatedAt: base.Add(time.Hour)}
+			second := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "Second", CreatedAt: base.Add(2 * time.Hour)}
+			for _, answer := range []*models.Answer{first, second} {
+				require.NoError(t, b.answer.Create(answer))
+				require.NoError(t, b.question.UpdateAnswerStats(uint(question.ID), 1))
+			}
+
+			count, lastActivity = stats()
+			assert.Equal(t, 2, count)
+			assert.True(t, second.CreatedAt.Equal(lastActivity))
+
+			require.NoError(t, b.answer.DeleteByID(uint(second.ID)))
+			require.NoError(t, b.question.UpdateAnswerStats(uint(question.ID), -1))
+
+			count, lastActivity = stats()
+			assert.Equal(t, 1, count)
+			assert.True(t, first.CreatedAt.Equal(lastActivity))
+
+			repaired, err := b.question.RepairAnswerStats()
+			require.NoError(t, err)
+			assert.Zero(t, repaired)
+
+			// An answer written without updating the stats is picked up by
+			// the repair.
+			require.NoError(t, b.answer.Create(&models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "Third", CreatedAt: base.Add(3 * time.Hour)}))
+
+			repaired, err = b.question.RepairAnswerStats()
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), repaired)
+
+			count, lastActivity = stats()
+			assert.Equal(t, 2, count)
+			assert.True(t, base.Add(3*time.Hour).Equal(lastActivity))
+
+			assert.ErrorIs(t, b.answer.DeleteByID(999), gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_UserActivity(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			user, other := b.newUser(t), b.newUser(t)
+
+			var questionIDs []int
+			for i, asker := range []uuid.UUID{user, other, user} {
+				question := &models.Question{Text: "Question", UserID: &asker, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+				require.NoError(t, b.question.Create(question))
+				questionIDs = append(questionIDs, question.ID)
+			}
+
+			var answerIDs []int
+			for i, author := range []uuid.UUID{user, user, other, user} {
+				answer := &models.Answer{QuestionID: uint(questionIDs[1]), UserID: author, Text: "Answer", CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+				require.NoError(t, b.answer.Create(answer))
+				if author == user {
+					answerIDs = append(answerIDs, answer.ID)
+				}
+			}
+
+			questions, err := b.question.FindByUser(user, repositories.Page{Sort: repositories.NewestFirst})
+			require.NoError(t, err)
+			require.Len(t, questions, 2)
+			assert.Equal(t, questionIDs[2], questions[0].ID)
+			assert.Equal(t, questionIDs[0], questions[1].ID)
+
+			answers, err := b.answer.FindByUser(user, repositories.Page{After: uint(answerIDs[0]), Limit: 1})
+			require.NoError(t, err)
+			require.Len(t, answers, 1)
+			assert.Equal(t, answerIDs[1], answers[0].ID)
+
+			activity, err := b.answer.ActivityOf(user)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), activity.Count)
+			require.NotNil(t, activity.FirstAt)
+			require.NotNil(t, activity.LastAt)
+			assert.True(t, base.Equal(*activity.FirstAt))
+			assert.True(t, base.Add(3*time.Minute).Equal(*activity.LastAt))
+
+			activity, err = b.question.ActivityOf(user)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), activity.Count)
+			assert.True(t, base.Add(2*time.Hour).Equal(*activity.LastAt))
+
+			activity, err = b.question.ActivityOf(uuid.New())
+			require.NoError(t, err)
+			assert.Equal(t, repositories.Activity{}, activity)
+		})
+	}
+}
+
+func TestRepositories_NotFound(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			_, err := b.question.FindByID(999)
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+			_, err = b.answer.FindByID(999)
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_AnswerRequiresQuestion(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			err := b.answer.Create(&models.Answer{QuestionID: 999, UserID: b.newUser(t), Text: "Orphan answer"})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRepositories_AnswerRequiresUser(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+
+			err := b.answer.Create(&models.Answer{QuestionID: uint(question.ID), UserID: uuid.New(), Text: "Anonymous answer"})
+			assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+		})
+	}
+}
+
+func TestRepositories_QuestionRequiresUser(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			unknown := uuid.New()
+			err := b.question.Create(&models.Question{Text: "What is Go?", UserID: &unknown})
+			assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+		})
+	}
+}
+
+func TestRepositories_Users(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			email := "ada@example.com"
+			user := &models.User{ID: uuid.New(), DisplayName: "Ada", Email: &email, Status: models.UserActive, CreatedAt: time.Now()}
+			require.NoError(t, b.user.Create(user))
+
+			found, err := b.user.FindByID(user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Ada", found.DisplayName)
+			require.NotNil(t, found.Email)
+			assert.Equal(t, email, *found.Email)
+
+			duplicate := &models.User{ID: uuid.New(), DisplayName: "Other Ada", Email: &email, Status: models.UserActive}
+			assert.ErrorIs(t, b.user.Create(duplicate), gorm.ErrDuplicatedKey)
+
+			require.NoError(t, b.user.UpdateStatus(user.ID, models.UserSuspended))
+			found, err = b.user.FindByID(user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.UserSuspended, found.Status)
+
+			_, err = b.user.FindByID(uuid.New())
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+			assert.ErrorIs(t, b.user.UpdateStatus(uuid.New(), models.UserActive), gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_Reputation(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+			userID := b.newUser(t)
+			answers := make([]*models.Answer, 2)
+			for i := range answers {
+				answers[i] = &models.Answer{QuestionID: uint(question.ID), UserID: userID, Text: "A language"}
+				require.NoError(t, b.answer.Create(answers[i]))
+			}
+
+			first := uint(answers[0].ID)
+			require.NoError(t, b.reputation.Award(&models.ReputationEvent{UserID: userID, Reason: models.ReputationAnswerPosted, Points: 2, QuestionID: uint(question.ID), AnswerID: first}))
+			require.NoError(t, b.reputation.Award(&models.ReputationEvent{UserID: userID, Reason: "bonus", Points: 10, QuestionID: uint(question.ID), AnswerID: first}))
+			assert.ErrorIs(t, b.reputation.Award(&models.ReputationEvent{UserID: uuid.New(), Reason: models.ReputationAnswerPosted, Points: 2}), gorm.ErrForeignKeyViolated)
+
+			points, err := b.reputation.AnswerPoints(userID, first)
+			require.NoError(t, err)
+			assert.Equal(t, 12, points)
+
+			hidden := &models.Answer{QuestionID: uint(question.ID), UserID: userID, Text: "Held", Hidden: true}
+			require.NoError(t, b.answer.Create(hidden))
+
+			// Only the second answer has no answer_posted entry yet; the
+			// hidden one earns nothing until it is approved.
+			awarded, err := b.reputation.AwardMissingAnswers(2)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), awarded)
+			awarded, err = b.reputation.AwardMissingAnswers(2)
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), awarded)
+
+			user, err := b.user.FindByID(userID)
+			require.NoError(t, err)
+			assert.Equal(t, 14, user.Reputation)
+
+			count, err := b.reputation.CountByUser(userID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), count)
+			entries, err := b.reputation.FindByUser(userID, repositories.Page{Limit: 2, Sort: repositories.NewestFirst})
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.Equal(t, uint(answers[1].ID), entries[0].AnswerID)
+			assert.Equal(t, "bonus", entries[1].Reason)
+
+			sums, err := b.reputation.QuestionAnswerPoints(uint(question.ID))
+			require.NoError(t, err)
+			require.Len(t, sums, 2)
+			assert.Equal(t, []uint{first, uint(answers[1].ID)}, []uint{sums[0].AnswerID, sums[1].AnswerID})
+			assert.Equal(t, []int{12, 2}, []int{sums[0].Points, sums[1].Points})
+			assert.Equal(t, userID, sums[0].UserID)
+
+			rebuilt, err := b.reputation.Rebuild()
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), rebuilt)
+		})
+	}
+}
+
+func TestRepositories_ReputationConcurrentAwards(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			userID := b.newUser(t)
+
+			var wg sync.WaitGroup
+			for range 20 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, b.reputation.Award(&models.ReputationEvent{UserID: userID, Reason: models.ReputationAnswerPosted, Points: 10}))
+				}()
+			}
+			wg.Wait()
+
+			user, err := b.user.FindByID(userID)
+			require.NoError(t, err)
+			assert.Equal(t, 200, user.Reputation)
+
+			rebuilt, err := b.reputation.Rebuild()
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), rebuilt)
+		})
+	}
+}
+
+func TestRepositories_Moderation(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			moderationCase, err := b.moderation.OpenCase(models.ContentQuestion, 7)
+			require.NoError(t, err)
+			again, err := b.moderation.OpenCase(models.ContentQuestion, 7)
+			require.NoError(t, err)
+			assert.Equal(t, moderationCase.ID, again.ID)
+
+			first, second := b.newUser(t), b.newUser(t)
+			count, err := b.moderation.AddFlag(&models.Flag{CaseID: moderationCase.ID, UserID: first, Reason: models.FlagSpam})
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+			_, err = b.moderation.AddFlag(&models.Flag{CaseID: moderationCase.ID, UserID: first, Reason: models.FlagOther})
+			assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+			count, err = b.moderation.AddFlag(&models.Flag{CaseID: moderationCase.ID, UserID: second, Reason: models.FlagAbusive})
+			require.NoError(t, err)
+			assert.Equal(t, 2, count)
+
+			_, err = b.moderation.OpenCase(models.ContentAnswer, 7)
+			require.NoError(t, err)
+			open, err := b.moderation.FindOpen(repositories.Page{Limit: 1})
+			require.NoError(t, err)
+			require.Len(t, open, 1)
+			assert.Equal(t, moderationCase.ID, open[0].ID)
+			require.Len(t, open[0].Flags, 2)
+			assert.Equal(t, models.FlagSpam, open[0].Flags[0].Reason)
+			total, err := b.moderation.CountOpen()
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), total)
+
+			moderator := b.newUser(t)
+			require.NoError(t, b.moderation.Resolve(uint(moderationCase.ID), models.CaseApproved, moderator, time.Now()))
+			assert.ErrorIs(t, b.moderation.Resolve(uint(moderationCase.ID), models.CaseRemoved, moderator, time.Now()), gorm.ErrRecordNotFound)
+
+			resolved, err := b.moderation.FindCase(uint(moderationCase.ID))
+			require.NoError(t, err)
+			assert.Equal(t, models.CaseApproved, resolved.Status)
+			assert.Equal(t, &moderator, resolved.ModeratorID)
+			assert.NotNil(t, resolved.ResolvedAt)
+			approved, err := b.moderation.WasApproved(models.ContentQuestion, 7)
+			require.NoError(t, err)
+			assert.True(t, approved)
+
+			// Flags after a resolution open a new case.
+			next, err := b.moderation.OpenCase(models.ContentQuestion, 7)
+			require.NoError(t, err)
+			assert.NotEqual(t, moderationCase.ID, next.ID)
+		})
+	}
+}
+
+func TestRepositories_RenderText(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+			rendered := &models.Question{Text: "Why Go?", TextHTML: "<p>Why Go?</p>"}
+			require.NoError(t, b.question.Create(rendered))
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "A language"}
+			require.NoError(t, b.answer.Create(answer))
+
+			render := func(text string) string { return "<p>" + text + "</p>" }
+			updated, err := b.question.RenderText(render)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), updated)
+			updated, err = b.answer.RenderText(render)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), updated)
+
+			found, err := b.question.FindByID(uint(question.ID))
+			require.NoError(t, err)
+			assert.Equal(t, "<p>What is Go?</p>", found.TextHTML)
+			require.Len(t, found.Answers, 1)
+			assert.Equal(t, "<p>A language</p>", found.Answers[0].TextHTML)
+
+			updated, err = b.question.RenderText(render)
+			require.NoError(t, err)
+			assert.Zero(t, updated)
+		})
+	}
+}
+
+func TestRepositories_HoldForReview(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			held, err := b.moderation.HoldForReview(models.ContentAnswer, 3, "contains more than 3 links")
+			require.NoError(t, err)
+			assert.NotZero(t, held.ID)
+
+			found, err := b.moderation.FindCase(uint(held.ID))
+			require.NoError(t, err)
+			assert.Equal(t, models.CaseOpen, found.Status)
+			assert.Equal(t, "contains more than 3 links", found.ReviewReason)
+			assert.Empty(t, found.Flags)
+
+			// Flags on held content join its case.
+			flagged, err := b.moderation.OpenCase(models.ContentAnswer, 3)
+			require.NoError(t, err)
+			assert.Equal(t, held.ID, flagged.ID)
+		})
+	}
+}
+
+func TestRepositories_AttachmentsDeletedWithContent(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is in the picture?"}
+			require.NoError(t, b.question.Create(question))
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "A cat"}
+			require.NoError(t, b.answer.Create(answer))
+
+			questionID, answerID := uint(question.ID), uint(answer.ID)
+			newAttachment := func(answerID *uint) *models.Attachment {
+				attachment := &models.Attachment{QuestionID: questionID, AnswerID: answerID, Filename: "cat.png",
+					ContentType: "image/png", Size: 3, SHA256: "hash", BlobKey: uuid.NewString()}
+				require.NoError(t, b.attachment.Create(attachment))
+				return attachment
+			}
+			onQuestion, onAnswer := newAttachment(nil), newAttachment(&answerID)
+
+			found, err := b.attachment.FindByID(uint(onAnswer.ID))
+			require.NoError(t, err)
+			assert.Equal(t, answerID, *found.AnswerID)
+			assert.Equal(t, onAnswer.BlobKey, found.BlobKey)
+
+			all, err := b.attachment.FindByQuestion(questionID)
+			require.NoError(t, err)
+			require.Len(t, all, 2)
+			assert.Equal(t, onQuestion.ID, all[0].ID)
+			byAnswer, err := b.attachment.FindByAnswer(answerID)
+			require.NoError(t, err)
+			require.Len(t, byAnswer, 1)
+			assert.Equal(t, onAnswer.ID, byAnswer[0].ID)
+
+			missing := uint(999)
+			err = b.attachment.Create(&models.Attachment{QuestionID: questionID, AnswerID: &missing, BlobKey: uuid.NewString()})
+			assert.ErrorIs(t, err, gorm.ErrForeignKeyViolated)
+
+			require.NoError(t, b.answer.DeleteByID(answerID))
+			all, err = b.attachment.FindByQuestion(questionID)
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+
+			require.NoError(t, b.question.Delete(questionID))
+			_, err = b.attachment.FindByID(uint(onQuestion.ID))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_HiddenAnswersLeftOutOfLists(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+			userID := b.newUser(t)
+			shown := &models.Answer{QuestionID: uint(question.ID), UserID: userID, Text: "A language"}
+			require.NoError(t, b.answer.Create(shown))
+			hidden := &models.Answer{QuestionID: uint(question.ID), UserID: userID, Text: "Buy cheap watches"}
+			require.NoError(t, b.answer.Create(hidden))
+
+			require.NoError(t, b.answer.SetHidden(uint(hidden.ID), true))
+			require.NoError(t, b.question.SetHidden(uint(question.ID), true))
+
+			found, err := b.question.FindByID(uint(question.ID))
+			require.NoError(t, err)
+			assert.True(t, found.Hidden)
+			require.Len(t, found.Answers, 1)
+			assert.Equal(t, shown.ID, found.Answers[0].ID)
+
+			found, err = b.question.FindByIDWithAnswers(uint(question.ID), repositories.Page{Limit: 5})
+			require.NoError(t, err)
+			assert.Len(t, found.Answers, 1)
+
+			answers, err := b.answer.FindByQuestionIDs([]uint{uint(question.ID)})
+			require.NoError(t, err)
+			assert.Len(t, answers, 1)
+
+			answer, err := b.answer.FindByID(uint(hidden.ID))
+			require.NoError(t, err)
+			assert.True(t, answer.Hidden)
+		})
+	}
+}
+
+func TestRepositories_HiddenContentLeftOutOfUserActivity(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			userID := b.newUser(t)
+			base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			shownQuestion := &models.Question{Text: "What is Go?", UserID: &userID, CreatedAt: base}
+			hiddenQuestion := &models.Question{Text: "Buy cheap watches?", UserID: &userID, CreatedAt: base.Add(time.Hour)}
+			for _, question := range []*models.Question{shownQuestion, hiddenQuestion} {
+				require.NoError(t, b.question.Create(question))
+			}
+			require.NoError(t, b.question.SetHidden(uint(hiddenQuestion.ID), true))
+
+			shown := &models.Answer{QuestionID: uint(shownQuestion.ID), UserID: userID, Text: "A language", CreatedAt: base.Add(2 * time.Hour)}
+			hidden := &models.Answer{QuestionID: uint(shownQuestion.ID), UserID: userID, Text: "Buy cheap watches", CreatedAt: base.Add(3 * time.Hour)}
+			for _, answer := range []*models.Answer{shown, hidden} {
+				require.NoError(t, b.answer.Create(answer))
+			}
+			require.NoError(t, b.answer.SetHidden(uint(hidden.ID), true))
+
+			questions, err := b.question.FindByUser(userID, repositories.Page{})
+			require.NoError(t, err)
+			require.Len(t, questions, 1)
+			assert.Equal(t, shownQuestion.ID, questions[0].ID)
+
+			activity, err := b.question.ActivityOf(userID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), activity.Count)
+			assert.True(t, base.Equal(*activity.LastAt))
+
+			answers, err := b.answer.FindByUser(userID, repositories.Page{})
+			require.NoError(t, err)
+			require.Len(t, answers, 1)
+			assert.Equal(t, shown.ID, answers[0].ID)
+
+			activity, err = b.answer.ActivityOf(userID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), activity.Count)
+			assert.True(t, shown.CreatedAt.Equal(*activity.LastAt))
+
+			// The stats follow the visible answers only.
+			repaired, err := b.question.RepairAnswerStats()
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), repaired)
+			found, err := b.question.FindByID(uint(shownQuestion.ID))
+			require.NoError(t, err)
+			assert.Equal(t, 1, found.AnswerCount)
+			assert.True(t, shown.CreatedAt.Equal(found.LastActivityAt))
+
+			require.NoError(t, b.question.UpdateAnswerStats(uint(shownQuestion.ID), 0))
+			found, err = b.question.FindByID(uint(shownQuestion.ID))
+			require.NoError(t, err)
+			assert.True(t, shown.CreatedAt.Equal(found.LastActivityAt), "a hidden answer is not activity")
+		})
+	}
+}
+
+func TestRepositories_DeleteQuestionCascadesAnswers(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "Question to delete"}
+			require.NoError(t, b.question.Create(question))
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "Some answer"}
+			require.NoError(t, b.answer.Create(answer))
+
+			require.NoError(t, b.question.Delete(uint(question.ID)))
+
+			_, err := b.question.FindByID(uint(question.ID))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+			_, err = b.answer.FindByID(uint(answer.ID))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_DeleteAnswer(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "A language"}
+			require.NoError(t, b.answer.Create(answer))
+
+			require.NoError(t, b.answer.DeleteByID(uint(answer.ID)))
+
+			_, err := b.answer.FindByID(uint(answer.ID))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		})
+	}
+}
+
+func TestRepositories_OutboxProcessPending(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			for _, eventType := range []string{"question.created", "answer.created", "answer.created"} {
+				event := &models.OutboxEvent{Type: eventType, QuestionID: 1, Payload: "{}", Status: models.OutboxPending, DeliveredTo: []string{}}
+				require.NoError(t, b.outbox.Add(event))
+			}
+
+			var handled []int
+			n, err := b.outbox.ProcessPending(0, 2, time.Now().Add(time.Minute), claimNothing, func(event *models.OutboxEvent) bool {
+				handled = append(handled, event.ID)
+				event.Status = models.OutboxDone
+				event.DeliveredTo = append(event.DeliveredTo, "bus")
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+
+			n, err = b.outbox.ProcessPending(0, 2, time.Now().Add(time.Minute), claimNothing, func(event *models.OutboxEvent) bool {
+				handled = append(handled, event.ID)
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, []int{1, 2, 3}, handled)
+
+			done, err := b.outbox.FindByID(1)
+			require.NoError(t, err)
+			assert.Equal(t, models.OutboxDone, done.Status)
+			assert.Equal(t, []string{"bus"}, done.DeliveredTo)
+		})
+	}
+}
+
+// claimNothing leaves claimed outbox events as they are.
+func claimNothing(repositories.Repositories, *models.OutboxEvent) error {
+	return nil
+}
+
+// handleAll reports every outbox event as handled.
+func handleAll(*models.OutboxEvent) bool {
+	return true
+}
+
+func TestRepositories_OutboxReleasesUnhandledEvents(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			for range 2 {
+				event := &models.OutboxEvent{Type: "answer.created", QuestionID: 1, Payload: "{}", Status: models.OutboxPending, DeliveredTo: []string{}}
+				require.NoError(t, b.outbox.Add(event))
+			}
+
+			n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, func(event *models.OutboxEvent) bool {
+				event.Status = models.OutboxDone
+				return event.ID == 1
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, n)
+
+			done, err := b.outbox.FindByID(1)
+			require.NoError(t, err)
+			assert.Equal(t, models.OutboxDone, done.Status)
+
+			released, err := b.outbox.FindByID(2)
+			require.NoError(t, err)
+			assert.Equal(t, models.OutboxPending, released.Status, "an unhandled event is not saved")
+			assert.Nil(t, released.ClaimedUntil)
+
+			var handled []int
+			_, err = b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, func(event *models.OutboxEvent) bool {
+				handled = append(handled, event.ID)
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []int{2}, handled, "a released event is claimed again")
+		})
+	}
+}
+
+func TestRepositories_OutboxClaimWritesInTransaction(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			webhook := &models.Webhook{URL: "https://example.com/hook", Events: []string{"answer.created"}, Active: true}
+			require.NoError(t, b.webhook.Create(webhook))
+			event := &models.OutboxEvent{Type: "answer.created", QuestionID: 1, Payload: "{}", Status: models.OutboxPending, DeliveredTo: []string{}}
+			require.NoError(t, b.outbox.Add(event))
+
+			var delivered []string
+			n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), func(tx repositories.Repositories, event *models.OutboxEvent) error {
+				event.DeliveredTo = append(event.DeliveredTo, "webhooks")
+				return tx.Webhooks.CreateDelivery(&models.WebhookDelivery{
+					WebhookID: uint(webhook.ID), Event: event.Type, Payload: event.Payload,
+					Status: models.DeliveryPending, NextAttemptAt: time.Now(), CreatedAt: time.Now(),
+				})
+			}, func(event *models.OutboxEvent) bool {
+				delivered = event.DeliveredTo
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, []string{"webhooks"}, delivered, "handle sees what claimed wrote")
+
+			deliveries, err := b.webhook.FindDeliveries(uint(webhook.ID))
+			require.NoError(t, err)
+			assert.Len(t, deliveries, 1)
+		})
+	}
+}
+
+func TestRepositories_ClaimDueDeliveries(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			webhook := &models.Webhook{URL: "https://example.com/hook", Events: []string{"answer.created"}, Active: true}
+			require.NoError(t, b.webhook.Create(webhook))
+
+			now := time.Now().UTC()
+			for _, due := range []time.Time{now.Add(-time.Minute), now.Add(-time.Second), now.Add(time.Hour)} {
+				require.NoError(t, b.webhook.CreateDelivery(&models.WebhookDelivery{
+					WebhookID: uint(webhook.ID), Event: "answer.created", Payload: "{}",
+					Status: models.DeliveryPending, NextAttemptAt: due, CreatedAt: now,
+				}))
+			}
+
+			leaseUntil := now.Add(10 * time.Minute)
+			claimed, err := b.webhook.ClaimDueDeliveries(now, leaseUntil, 10)
+			require.NoError(t, err)
+			require.Len(t, claimed, 2)
+			assert.Equal(t, []int{1, 2}, []int{claimed[0].ID, claimed[1].ID})
+
+			again, err := b.webhook.ClaimDueDeliveries(now, leaseUntil, 10)
+			require.NoError(t, err)
+			assert.Empty(t, again, "claimed deliveries are leased")
+
+			stored, err := b.webhook.FindDeliveryByID(1)
+			require.NoError(t, err)
+			assert.True(t, leaseUntil.Equal(stored.NextAttemptAt))
+
+			expired, err := b.webhook.ClaimDueDeliveries(leaseUntil, leaseUntil.Add(time.Minute), 1)
+			require.NoError(t, err)
+			require.Len(t, expired, 1, "an expired lease is claimed again, up to limit")
+		})
+	}
+}
+
+func TestRepositories_OutboxClaimsBatch(t *testing.T) {
+	for _, b := range backends(t) {
+		// The memory store serialises relays with a mutex instead.
+		if b.name == "memory" {
+			continue
+		}
+		t.Run(b.name, func(t *testing.T) {
+			event := &models.OutboxEvent{Type: "question.created", QuestionID: 1, Payload: "{}", Status: models.OutboxPending, DeliveredTo: []string{}}
+			require.NoError(t, b.outbox.Add(event))
+
+			n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, func(*models.OutboxEvent) bool {
+				// Another relay polls while this one dispatches.
+				n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, handleAll)
+				require.NoError(t, err)
+				assert.Zero(t, n, "a claimed event is skipped")
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			stored, err := b.outbox.FindByID(uint(event.ID))
+			require.NoError(t, err)
+			assert.Nil(t, stored.ClaimedUntil, "saving releases the claim")
+
+			// A relay that died mid-batch left an expired lease behind.
+			n, err = b.outbox.ProcessPending(0, 10, time.Now().Add(-time.Minute), claimNothing, func(*models.OutboxEvent) bool {
+				n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, handleAll)
+				require.NoError(t, err)
+				assert.Equal(t, 1, n, "an expired claim is taken over")
+				return true
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+
+			// A claim whose writes fail is rolled back.
+			recordErr := errors.New("record failed")
+			_, err = b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), func(repositories.Repositories, *models.OutboxEvent) error {
+				return recordErr
+			}, handleAll)
+			assert.ErrorIs(t, err, recordErr)
+
+			stored, err = b.outbox.FindByID(uint(event.ID))
+			require.NoError(t, err)
+			assert.Nil(t, stored.ClaimedUntil)
+		})
+	}
+}
+
+func TestRepositories_TransactionRollsBack(t *testing.T) {
+	for _, b := range backends(t) {
+		// The memory store has no rollback.
+		if b.name == "memory" {
+			continue
+		}
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "Rolled back question"}
+			err := b.transactor.Transaction(func(tx repositories.Repositories) error {
+				require.NoError(t, tx.Questions.Create(question))
+				require.NoError(t, tx.Outbox.Add(&models.OutboxEvent{Type: "question.created", Payload: "{}", Status: models.OutboxPending, DeliveredTo: []string{}}))
+				return errors.New("abort")
+			})
+			require.Error(t, err)
+
+			_, err = b.question.FindByID(uint(question.ID))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+			n, err := b.outbox.ProcessPending(0, 10, time.Now().Add(time.Minute), claimNothing, handleAll)
+			require.NoError(t, err)
+			assert.Zero(t, n)
+		})
+	}
+}
+
+func TestRepositories_FollowsAndNotifications(t *testing.T) {
+	for _, b := range backends(t) {
+		t.Run(b.name, func(t *testing.T) {
+			question := &models.Question{Text: "What is Go?"}
+			require.NoError(t, b.question.Create(question))
+			answer := &models.Answer{QuestionID: uint(question.ID), UserID: b.newUser(t), Text: "A language"}
+			require.NoError(t, b.answer.Create(answer))
+
+			userID := uuid.New()
+			follow := &models.Follow{QuestionID: uint(question.ID), UserID: userID, CreatedAt: time.Now()}
+			require.NoError(t, b.follow.Follow(follow))
+			require.NoError(t, b.follow.Follow(follow))
+
+			followers, err := b.follow.FindFollowers(uint(question.ID))
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{userID}, followers)
+
+			for range 2 {
+				notification := &models.Notification{UserID: userID, Type: models.NotificationNewAnswer, QuestionID: uint(question.ID), AnswerID: uint(answer.ID)}
+				require.NoError(t, b.notification.Create(notification))
+			}
+			unread, err := b.notification.CountUnread(userID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), unread, "duplicates are ignored")
+
+			require.NoError(t, b.notification.MarkAllRead(userID, time.Now()))
+			unread, err = b.notification.CountUnread(userID)
+			require.NoError(t, err)
+			assert.Zero(t, unread)
+
+			require.NoError(t, b.question.Delete(uint(question.ID)))
+			followers, err = b.follow.FindFollowers(uint(question.ID))
+			require.NoError(t, err)
+			assert.Empty(t, followers)
+			notifications, err := b.notification.FindByUser(userID, false, 10)
+			require.NoError(t, err)
+			assert.Empty(t, notifications)
+		})
+	}
+}
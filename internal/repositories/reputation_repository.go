@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReputationRepository interface {
+	// Award appends the entry to the ledger and adds its points to the
+	// user's reputation atomically.
+	Award(entry *models.ReputationEvent) error
+	// AnswerPoints sums the points the user has from the answer.
+	AnswerPoints(userID uuid.UUID, answerID uint) (int, error)
+	// QuestionAnswerPoints sums, per user and answer, the points the answers
+	// to the question earned. Each returned entry carries one sum in Points
+	// and leaves out the sums that are zero.
+	QuestionAnswerPoints(questionID uint) ([]*models.ReputationEvent, error)
+	// FindByUser returns one page of the user's ledger.
+	FindByUser(userID uuid.UUID, page Page) ([]*models.ReputationEvent, error)
+	CountByUser(userID uuid.UUID) (int64, error)
+	// AwardMissingAnswers records answer_posted entries worth points for
+	// the visible answers that have none, such as those written before the
+	// ledger existed, updates the totals and returns how many it recorded.
+	AwardMissingAnswers(points int) (int64, error)
+	// Rebuild replays the ledger into the reputation of every user and
+	// returns how many users had a wrong total.
+	Rebuild() (int64, error)
+}
+
+const reputationSQL = "COALESCE((SELECT SUM(points) FROM reputation_events WHERE reputation_events.user_id = users.id), 0)"
+
+type reputationRepository struct {
+	database *gorm.DB
+}
+
+func NewReputationRepository(database *gorm.DB) ReputationRepository {
+	return &reputationRepository{
+		database,
+	}
+}
+
+func (r reputationRepository) Award(entry *models.ReputationEvent) error {
+	// Inside a transaction this becomes a savepoint.
+	return r.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(entry).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.User{}).Where("id = ?", entry.UserID).
+			Update("reputation", gorm.Expr("reputation + ?", entry.Points))
+		if result.Error == nil && result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return result.Error
+	})
+}
+
+func (r reputationRepository) AnswerPoints(userID uuid.UUID, answerID uint) (int, error) {
+	var points int
+	err := r.database.Model(&models.ReputationEvent{}).
+		Where("user_id = ? AND answer_id = ?", userID, answerID).
+		Select("COALESCE(SUM(points), 0)").Scan(&points).Error
+	return points, err
+}
+
+func (r reputationRepository) QuestionAnswerPoints(questionID uint) ([]*models.ReputationEvent, error) {
+	var sums []*models.ReputationEvent
+	err := r.database.Model(&models.ReputationEvent{}).
+		Select("user_id, question_id, answer_id, SUM(points) AS points").
+		Where("question_id = ? AND answer_id <> 0", questionID).
+		Group("user_id, question_id, answer_id").
+		Having("SUM(points) <> 0").
+		Order("answer_id").
+		Scan(&sums).Error
+	if err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+func (r reputationRepository) FindByUser(userID uuid.UUID, page Page) ([]*models.ReputationEvent, error) {
+	var entries []*models.ReputationEvent
+	err := r.database.Where("user_id = ?", userID).Scopes(page.scope).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r reputationRepository) CountByUser(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.database.Model(&models.ReputationEvent{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r reputationRepository) AwardMissingAnswers(points int) (int64, error) {
+	var entries []*models.ReputationEvent
+	err := r.database.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Answer{}).
+			Select("user_id, ? AS reason, ? AS points, question_id, id AS answer_id, created_at",
+				models.ReputationAnswerPosted, points).
+			Where("hidden = ?", false).
+			Where("NOT EXISTS (SELECT 1 FROM reputation_events WHERE reputation_events.answer_id = answers.id AND reputation_events.reason = ?)",
+				models.ReputationAnswerPosted).
+			Order("id").Scan(&entries).Error
+		if err != nil || len(entries) == 0 {
+			return err
+		}
+
+		err = tx.CreateInBatches(entries, 500).Error
+		if err != nil {
+			return err
+		}
+
+		totals := map[uuid.UUID]int{}
+		for _, entry := range entries {
+			totals[entry.UserID] += entry.Points
+		}
+		for userID, total := range totals {
+			err := tx.Model(&models.User{}).Where("id = ?", userID).
+				Update("reputation", gorm.Expr("reputation + ?", total)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(entries)), nil
+}
+
+func (r reputationRepository) Rebuild() (int64, error) {
+	var rebuilt int64
+	err := r.database.Transaction(func(tx *gorm.DB) (err error) {
+		rebuilt, err = rebuild(tx)
+		return err
+	})
+	return rebuilt, err
+}
+
+// rebuild sets every wrong total to the sum of the user's ledger. It must
+// run inside a transaction.
+func rebuild(tx *gorm.DB) (int64, error) {
+	// An award committed between reading the ledger and writing the totals
+	// would be lost; SQLite already serialises writers.
+	if tx.Dialector.Name() == "postgres" {
+		err := tx.Exec("LOCK TABLE reputation_events IN SHARE MODE").Error
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	result := tx.Model(&models.User{}).
+		Where("reputation <> "+reputationSQL).
+		Update("reputation", gorm.Expr(reputationSQL))
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Repositories groups the repositories that can take part in a transaction.
+type Repositories struct {
+	Questions  QuestionRepository
+	Answers    AnswerRepository
+	Outbox     OutboxRepository
+	Reputation ReputationRepository
+	Moderation ModerationRepository
+	Webhooks   WebhookRepository
+}
+
+// Transactor runs fn with repositories bound to a single transaction. The
+// transaction commits when fn returns nil and rolls back otherwise.
+type Transactor interface {
+	Transaction(fn func(tx Repositories) error) error
+}
+
+type transactor struct {
+	database *gorm.DB
+	onCommit func()
+}
+
+// NewTransactor calls onCommit after every successful commit; the outbox
+// relay uses it to pick up new events without waiting for its next poll.
+func NewTransactor(database *gorm.DB, onCommit func()) Transactor {
+	return &transactor{
+		database,
+		onCommit,
+	}
+}
+
+func (t transactor) Transaction(fn func(tx Repositories) error) error {
+	err := t.database.Transaction(func(tx *gorm.DB) error {
+		return fn(bind(tx))
+	})
+	if err != nil {
+		return err
+	}
+
+	t.onCommit()
+	return nil
+}
+
+// bind builds the repositories of the transaction tx.
+func bind(tx *gorm.DB) Repositories {
+	return Repositories{
+		Questions:  NewQuestionRepository(tx),
+		Answers:    NewAnswerRepository(tx),
+		Outbox:     NewOutboxRepository(tx),
+		Moderation: NewModerationRepository(tx),
+		Reputation: NewReputationRepository(tx),
+		Webhooks:   NewWebhookRepository(tx),
+	}
+}
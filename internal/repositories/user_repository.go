@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	// Create returns gorm.ErrDuplicatedKey when the email is taken.
+	Create(user *models.User) error
+	FindByID(id uuid.UUID) (*models.User, error)
+	UpdateStatus(id uuid.UUID, status string) error
+}
+
+type userRepository struct {
+	database *gorm.DB
+}
+
+func NewUserRepository(database *gorm.DB) UserRepository {
+	return &userRepository{
+		database,
+	}
+}
+
+func (u userRepository) Create(user *models.User) error {
+	return u.database.Create(user).Error
+}
+
+func (u userRepository) FindByID(id uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := u.database.Where("id = ?", id).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (u userRepository) UpdateStatus(id uuid.UUID, status string) error {
+	result := u.database.Model(&models.User{}).Where("id = ?", id).Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) error
+	FindAll() ([]*models.Webhook, error)
+	FindByID(id uint) (*models.Webhook, error)
+	Delete(id uint) error
+
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	FindDeliveryByID(id uint) (*models.WebhookDelivery, error)
+	FindDeliveries(webhookID uint) ([]*models.WebhookDelivery, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now
+	// and moves their next attempt to leaseUntil, so no other worker picks
+	// them up while they are being sent.
+	ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	database *gorm.DB
+}
+
+func NewWebhookRepository(database *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		database,
+	}
+}
+
+func (w webhookRepository) Create(webhook *models.Webhook) error {
+	return w.database.Create(webhook).Error
+}
+
+func (w webhookRepository) FindAll() ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := w.database.Order("id").Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (w webhookRepository) FindByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := w.database.First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (w webhookRepository) Delete(id uint) error {
+	return w.database.Delete(&models.Webhook{}, id).Error
+}
+
+func (w webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return w.database.Create(delivery).Error
+}
+
+func (w webhookRepository) FindDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := w.database.First(&delivery, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (w webhookRepository) FindDeliveries(webhookID uint) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := w.database.Where("webhook_id = ?", webhookID).Order("id DESC").Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries locks the batch with FOR UPDATE SKIP LOCKED on postgres,
+// so several workers can run side by side without sending a delivery twice.
+// The transaction ends before anything is sent.
+func (w webhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := w.database.Transaction(func(tx *gorm.DB) error {
+		query := tx
+		if tx.Dialector.Name() == "postgres" {
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+		}
+		err := query.
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+			delivery.NextAttemptAt = leaseUntil
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (w webhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	return w.database.Save(delivery).Error
+}
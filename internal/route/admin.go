@@ -0,0 +1,29 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/i18n"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// RequireAdmin lets through requests carrying "Authorization: Bearer <token>".
+// With an empty token the admin API is disabled.
+func RequireAdmin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				http.Error(w, i18n.Message(r.Context(), i18n.AdminDisabled), http.StatusForbidden)
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				http.Error(w, i18n.Message(r.Context(), i18n.Unauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
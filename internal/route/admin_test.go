@@ -0,0 +1,44 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		locale        string
+		wantStatus    int
+		wantBody      string
+	}{
+		{"disabled", "", "Bearer secret", i18n.LocaleEnglish, http.StatusForbidden, "Admin API is disabled\n"},
+		{"missing token", "secret", "", i18n.LocaleEnglish, http.StatusUnauthorized, "Unauthorized\n"},
+		{"wrong token", "secret", "Bearer guess", i18n.LocaleRussian, http.StatusUnauthorized, "требуется авторизация\n"},
+		{"valid token", "secret", "Bearer secret", i18n.LocaleEnglish, http.StatusNoContent, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/admin/webhooks", nil)
+			request.Header.Set("Authorization", tt.authorization)
+			request.Header.Set("Accept-Language", tt.locale)
+			rr := httptest.NewRecorder()
+			handler := RequireAdmin(tt.token)(next)
+			Locale(config.I18nConfig{DefaultLocale: i18n.LocaleEnglish})(handler).ServeHTTP(rr, request)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
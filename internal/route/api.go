@@ -1,6 +1,8 @@
 package route
 
 import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/graph"
 	"api_service_questions_and_answers/internal/handlers"
 	"encoding/json"
 	"log"
@@ -9,19 +11,63 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func SetupQuestionRoutes(questionHandler *handlers.QuestionHandler, answerHandler *handlers.AnswerHandler) http.Handler {
+type Handlers struct {
+	Question     *handlers.QuestionHandler
+	Answer       *handlers.AnswerHandler
+	Event        *handlers.EventHandler
+	WebSocket    *handlers.WebSocketHandler
+	Webhook      *handlers.WebhookHandler
+	Notification *handlers.NotificationHandler
+	User         *handlers.UserHandler
+	Reputation   *handlers.ReputationHandler
+	Moderation   *handlers.ModerationHandler
+	Activity     *handlers.ActivityHandler
+	Attachment   *handlers.AttachmentHandler
+	GraphQL      *graph.Handler
+	QuestionV2   *handlers.QuestionV2Handler
+	AnswerV2     *handlers.AnswerV2Handler
+}
+
+// SetupQuestionRoutes serves v1 under both /api and /api/v1 with the
+// deprecation headers from v1Deprecation, v2 under /api/v2 and the admin
+// and moderation APIs, which are not versioned, under /api/admin and
+// /api/moderation.
+func SetupQuestionRoutes(h Handlers, adminToken string, v1Deprecation config.DeprecationConfig) http.Handler {
 	r := chi.NewRouter()
 
+	v2 := v2Routes(h)
+	deprecations := newDeprecations(v1Deprecation, v2, "/api/v2")
+
 	r.Route("/api", func(r chi.Router) {
-		r.Get("/questions", questionHandler.GetQuestions)
-		r.Post("/questions", questionHandler.CreateQuestion)
-		r.Get("/questions/{id}", questionHandler.GetQuestion)
-		r.Delete("/questions/{id}", questionHandler.DeleteQuestion)
-
-		r.Get("/answers/{id}", answerHandler.GetAnswer)
-		r.Post("/questions/{id}/answers", answerHandler.CreateAnswer)
-		r.Delete("/answers/{id}", answerHandler.DeleteAnswer)
+		v1Routes(r, h, deprecations)
+		r.Route("/v1", func(r chi.Router) {
+			v1Routes(r, h, deprecations)
+		})
+		r.Mount("/v2", v2)
+
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(RequireAdmin(adminToken))
+
+			r.Get("/webhooks", h.Webhook.GetWebhooks)
+			r.Post("/webhooks", h.Webhook.CreateWebhook)
+			r.Get("/webhooks/{id}", h.Webhook.GetWebhook)
+			r.Delete("/webhooks/{id}", h.Webhook.DeleteWebhook)
+			r.Get("/webhooks/{id}/deliveries", h.Webhook.GetDeliveries)
+			r.Post("/deliveries/{id}/redeliver", h.Webhook.Redeliver)
+
+			r.Put("/users/{uuid}/status", h.User.SetUserStatus)
+		})
+
+		r.Route("/moderation", func(r chi.Router) {
+			r.Use(RequireAdmin(adminToken))
+
+			r.Get("/queue", h.Moderation.GetQueue)
+			r.Post("/cases/{id}", h.Moderation.ResolveCase)
+		})
 	})
+	deprecations.warnUnused()
+
+	r.Post("/graphql", h.GraphQL.ServeHTTP)
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Health check from %s", r.RemoteAddr)
@@ -35,3 +81,63 @@ func SetupQuestionRoutes(questionHandler *handlers.QuestionHandler, answerHandle
 
 	return r
 }
+
+// v1Routes registers the v1 API, which returns bare objects and arrays.
+func v1Routes(r chi.Router, h Handlers, d *deprecations) {
+	route := func(method, pattern string, handler http.HandlerFunc) {
+		r.With(d.route(method, pattern)).Method(method, pattern, handler)
+	}
+
+	route(http.MethodGet, "/questions", h.Question.GetQuestions)
+	route(http.MethodPost, "/questions", h.Question.CreateQuestion)
+	route(http.MethodGet, "/questions/{id}", h.Question.GetQuestion)
+	route(http.MethodDelete, "/questions/{id}", h.Question.DeleteQuestion)
+	route(http.MethodGet, "/questions/{id}/events", h.Event.StreamQuestionEvents)
+
+	route(http.MethodGet, "/answers/{id}", h.Answer.GetAnswer)
+	route(http.MethodGet, "/questions/{id}/answers", h.Answer.GetQuestionAnswers)
+	route(http.MethodPost, "/questions/{id}/answers", h.Answer.CreateAnswer)
+	route(http.MethodDelete, "/answers/{id}", h.Answer.DeleteAnswer)
+
+	route(http.MethodPost, "/questions/{id}/attachments", h.Attachment.UploadQuestionAttachment)
+	route(http.MethodPost, "/answers/{id}/attachments", h.Attachment.UploadAnswerAttachment)
+	route(http.MethodGet, "/attachments/{id}", h.Attachment.GetAttachment)
+
+	route(http.MethodPost, "/questions/{id}/flags", h.Moderation.FlagQuestion)
+	route(http.MethodPost, "/answers/{id}/flags", h.Moderation.FlagAnswer)
+
+	route(http.MethodPost, "/questions/{id}/follow", h.Notification.FollowQuestion)
+	route(http.MethodDelete, "/questions/{id}/follow", h.Notification.UnfollowQuestion)
+	route(http.MethodGet, "/users/{uuid}/notifications", h.Notification.GetNotifications)
+	route(http.MethodPost, "/users/{uuid}/notifications/read", h.Notification.MarkAllNotificationsRead)
+	route(http.MethodPost, "/users/{uuid}/notifications/{id}/read", h.Notification.MarkNotificationRead)
+
+	route(http.MethodPost, "/users", h.User.RegisterUser)
+	route(http.MethodGet, "/users/{uuid}", h.User.GetUser)
+	route(http.MethodGet, "/users/{uuid}/questions", h.Activity.GetUserQuestions)
+	route(http.MethodGet, "/users/{uuid}/answers", h.Activity.GetUserAnswers)
+	route(http.MethodGet, "/users/{uuid}/stats", h.Activity.GetUserStats)
+	route(http.MethodGet, "/users/{uuid}/reputation", h.Reputation.GetUserReputation)
+
+	route(http.MethodGet, "/ws", h.WebSocket.Connect)
+}
+
+// v2Routes returns the v2 API: envelope responses, string ids and
+// V2ErrorBody errors, including for unmatched routes.
+func v2Routes(h Handlers) chi.Router {
+	r := chi.NewRouter()
+	r.NotFound(handlers.V2NotFound)
+	r.MethodNotAllowed(handlers.V2MethodNotAllowed)
+
+	r.Get("/questions", h.QuestionV2.GetQuestions)
+	r.Post("/questions", h.QuestionV2.CreateQuestion)
+	r.Get("/questions/{id}", h.QuestionV2.GetQuestion)
+	r.Delete("/questions/{id}", h.QuestionV2.DeleteQuestion)
+
+	r.Get("/answers/{id}", h.AnswerV2.GetAnswer)
+	r.Get("/questions/{id}/answers", h.AnswerV2.GetQuestionAnswers)
+	r.Post("/questions/{id}/answers", h.AnswerV2.CreateAnswer)
+	r.Delete("/answers/{id}", h.AnswerV2.DeleteAnswer)
+
+	return r
+}
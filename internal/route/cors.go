@@ -0,0 +1,30 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"net/http"
+
+	"github.com/go-chi/cors"
+)
+
+// CORS allows cross-origin requests only from the configured origins.
+// An empty list rejects every cross-origin request.
+func CORS(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	options := cors.Options{
+		AllowedOrigins: cfg.AllowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders: []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "If-None-Match", "Last-Event-ID"},
+		// Paging, deprecation and caching headers the API sets on responses.
+		ExposedHeaders: []string{"Link", "X-Total-Count", "Deprecation", "Sunset", "ETag", "Location"},
+		MaxAge:         300,
+	}
+
+	// go-chi/cors treats an empty list as "allow all", so deny explicitly.
+	if len(cfg.AllowedOrigins) == 0 {
+		options.AllowOriginFunc = func(r *http.Request, origin string) bool {
+			return false
+		}
+	}
+
+	return cors.Handler(options)
+}
@@ -0,0 +1,63 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := CORS(config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})(http.NotFoundHandler())
+
+	tests := []struct {
+		name    string
+		origin  string
+		method  string
+		headers string
+		allowed bool
+	}{
+		{"admin status update", "https://app.example.com", http.MethodPut, "Authorization, Content-Type", true},
+		{"delete", "https://app.example.com", http.MethodDelete, "Authorization", true},
+		{"unknown origin", "https://evil.example.com", http.MethodPut, "Authorization", false},
+		{"unsupported method", "https://app.example.com", http.MethodPatch, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodOptions, "/api/admin/users/1/status", nil)
+			request.Header.Set("Origin", tt.origin)
+			request.Header.Set("Access-Control-Request-Method", tt.method)
+			if tt.headers != "" {
+				request.Header.Set("Access-Control-Request-Headers", tt.headers)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, request)
+
+			if !tt.allowed {
+				assert.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+			assert.Equal(t, tt.origin, rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.method, rr.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.headers, rr.Header().Get("Access-Control-Allow-Headers"))
+		})
+	}
+}
+
+func TestCORS_ExposesResponseHeaders(t *testing.T) {
+	handler := CORS(config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Total-Count", "3")
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/questions", nil)
+	request.Header.Set("Origin", "https://app.example.com")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, request)
+
+	exposed := rr.Header().Get("Access-Control-Expose-Headers")
+	for _, header := range []string{"Link", "X-Total-Count", "Deprecation", "Sunset"} {
+		assert.Contains(t, exposed, header)
+	}
+}
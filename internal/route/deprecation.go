@@ -0,0 +1,90 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// deprecations sets the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers on the routes of a deprecated API version, and a
+// successor-version Link when the successor has the same route.
+type deprecations struct {
+	cfg             config.DeprecationConfig
+	successor       chi.Routes
+	successorPrefix string
+	used            map[string]bool
+}
+
+func newDeprecations(cfg config.DeprecationConfig, successor chi.Routes, successorPrefix string) *deprecations {
+	return &deprecations{
+		cfg:             cfg,
+		successor:       successor,
+		successorPrefix: successorPrefix,
+		used:            make(map[string]bool),
+	}
+}
+
+// route returns the middleware of the route registered as method and
+// pattern; the per-route config overrides the version-wide dates.
+func (d *deprecations) route(method, pattern string) func(http.Handler) http.Handler {
+	key := method + " " + pattern
+	d.used[key] = true
+
+	deprecated, sunset := d.cfg.Deprecated, d.cfg.Sunset
+	if route, ok := d.cfg.Routes[key]; ok {
+		if route.Disabled {
+			return passThrough
+		}
+		if route.Deprecated != "" {
+			deprecated = route.Deprecated
+		}
+		if route.Sunset != "" {
+			sunset = route.Sunset
+		}
+	}
+	if deprecated == "" {
+		return passThrough
+	}
+
+	// The dates were checked by config.Validate.
+	deprecatedAt, _ := time.Parse(config.DateLayout, deprecated)
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	var sunsetHeader string
+	if sunset != "" {
+		sunsetAt, _ := time.Parse(config.DateLayout, sunset)
+		sunsetHeader = sunsetAt.UTC().Format(http.TimeFormat)
+	}
+	hasSuccessor := d.successor != nil && d.successor.Match(chi.NewRouteContext(), method, pattern)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", deprecation)
+			if sunsetHeader != "" {
+				w.Header().Set("Sunset", sunsetHeader)
+			}
+			if hasSuccessor {
+				w.Header().Add("Link", "<"+d.successorPrefix+chi.RouteContext(r.Context()).RoutePath+`>; rel="successor-version"`)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// warnUnused logs per-route settings that matched no route, which are
+// most likely typos.
+func (d *deprecations) warnUnused() {
+	for key := range d.cfg.Routes {
+		if !d.used[key] {
+			log.Printf("Deprecation config for %q matches no route", key)
+		}
+	}
+}
+
+func passThrough(next http.Handler) http.Handler {
+	return next
+}
@@ -0,0 +1,66 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecations_HeadersAndOverrides(t *testing.T) {
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	successor := chi.NewRouter()
+	successor.Get("/questions/{id}", ok)
+
+	d := newDeprecations(config.DeprecationConfig{
+		Deprecated: "2026-10-19",
+		Sunset:     "2027-10-19",
+		Routes: map[string]config.RouteDeprecation{
+			"GET /answers/{id}": {Sunset: "2027-01-01"},
+			"GET /ws":           {Disabled: true},
+		},
+	}, successor, "/api/v2")
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		for _, pattern := range []string{"/questions/{id}", "/answers/{id}", "/ws"} {
+			r.With(d.route(http.MethodGet, pattern)).Get(pattern, ok)
+		}
+	})
+
+	get := func(path string) http.Header {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Header()
+	}
+
+	question := get("/api/questions/3")
+	assert.Equal(t, "@1792368000", question.Get("Deprecation"))
+	assert.Equal(t, "Tue, 19 Oct 2027 00:00:00 GMT", question.Get("Sunset"))
+	assert.Equal(t, `</api/v2/questions/3>; rel="successor-version"`, question.Get("Link"))
+
+	answer := get("/api/answers/3")
+	assert.Equal(t, "@1792368000", answer.Get("Deprecation"))
+	assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", answer.Get("Sunset"))
+	assert.Empty(t, answer.Get("Link"))
+
+	ws := get("/api/ws")
+	assert.Empty(t, ws.Get("Deprecation"))
+	assert.Empty(t, ws.Get("Sunset"))
+}
+
+func TestDeprecations_NotDeprecated(t *testing.T) {
+	d := newDeprecations(config.DeprecationConfig{}, nil, "/api/v2")
+
+	r := chi.NewRouter()
+	r.With(d.route(http.MethodGet, "/questions")).Get("/questions", func(w http.ResponseWriter, _ *http.Request) {})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/questions", nil))
+
+	assert.Empty(t, rr.Header().Get("Deprecation"))
+}
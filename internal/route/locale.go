@@ -0,0 +1,22 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// Locale puts the locale best matching the Accept-Language header of each
+// request in its context, for handlers to translate messages with. Requests
+// matching no supported locale get the configured default.
+func Locale(cfg config.I18nConfig) func(http.Handler) http.Handler {
+	fallback := language.Make(cfg.DefaultLocale)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := i18n.Match(r.Header.Get("Accept-Language"), fallback)
+			next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+		})
+	}
+}
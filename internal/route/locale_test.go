@@ -0,0 +1,35 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/i18n"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocale(t *testing.T) {
+	handler := Locale(config.I18nConfig{DefaultLocale: i18n.LocaleRussian})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(i18n.FromContext(r.Context()).String()))
+	}))
+
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", "ru"},
+		{"en-US,en;q=0.9", "en"},
+		{"fr-FR", "ru"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/questions", nil)
+			request.Header.Set("Accept-Language", tt.acceptLanguage)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, request)
+			assert.Equal(t, tt.want, rr.Body.String())
+		})
+	}
+}
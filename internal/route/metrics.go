@@ -0,0 +1,20 @@
+package route
+
+import (
+	"api_service_questions_and_answers/internal/cache"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// CacheStats reports the hit and miss counters of the repository cache.
+func CacheStats(c cache.Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(c.Stats())
+		if err != nil {
+			log.Printf("Error encoding response: %v", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ActivityService interface {
+	GetUserQuestions(userID uuid.UUID, page repositories.Page) (*QuestionList, error)
+	GetUserAnswers(userID uuid.UUID, page repositories.Page) (*AnswerList, error)
+	GetUserStats(userID uuid.UUID) (*UserStats, error)
+}
+
+// QuestionList is one page of questions.
+type QuestionList struct {
+	Questions []*models.Question
+	Total     int
+	HasMore   bool
+}
+
+// UserStats summarises what a user has asked and answered. The activity
+// times are nil for a user who has done neither.
+type UserStats struct {
+	UserID          uuid.UUID  `json:"user_id"`
+	Questions       int64      `json:"questions"`
+	Answers         int64      `json:"answers"`
+	FirstActivityAt *time.Time `json:"first_activity_at"`
+	LastActivityAt  *time.Time `json:"last_activity_at"`
+}
+
+type activityService struct {
+	questionRepository repositories.QuestionRepository
+	answerRepository   repositories.AnswerRepository
+}
+
+// NewActivityService reports what users have asked and answered, keyed by
+// the user ids stored on questions and answers.
+func NewActivityService(
+	questionRepository repositories.QuestionRepository,
+	answerRepository repositories.AnswerRepository,
+) ActivityService {
+	return &activityService{
+		questionRepository,
+		answerRepository,
+	}
+}
+
+func (a activityService) GetUserQuestions(userID uuid.UUID, page repositories.Page) (*QuestionList, error) {
+	questions, hasMore, err := findPage(page, func(page repositories.Page) ([]*models.Question, error) {
+		return a.questionRepository.FindByUser(userID, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := a.questionRepository.ActivityOf(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuestionList{Questions: questions, Total: int(activity.Count), HasMore: hasMore}, nil
+}
+
+func (a activityService) GetUserAnswers(userID uuid.UUID, page repositories.Page) (*AnswerList, error) {
+	answers, hasMore, err := findPage(page, func(page repositories.Page) ([]*models.Answer, error) {
+		return a.answerRepository.FindByUser(userID, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := a.answerRepository.ActivityOf(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnswerList{Answers: answers, Total: int(activity.Count), HasMore: hasMore}, nil
+}
+
+func (a activityService) GetUserStats(userID uuid.UUID) (*UserStats, error) {
+	questions, err := a.questionRepository.ActivityOf(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := a.answerRepository.ActivityOf(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserStats{
+		UserID:          userID,
+		Questions:       questions.Count,
+		Answers:         answers.Count,
+		FirstActivityAt: earliest(questions.FirstAt, answers.FirstAt),
+		LastActivityAt:  latest(questions.LastAt, answers.LastAt),
+	}, nil
+}
+
+// findPage reads one record past the page to tell whether another page
+// follows.
+func findPage[T any](page repositories.Page, find func(repositories.Page) ([]T, error)) ([]T, bool, error) {
+	limit := page.Limit
+	if limit > 0 {
+		page.Limit++
+	}
+
+	items, err := find(page)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if limit > 0 && len(items) > limit {
+		return items[:limit], true, nil
+	}
+	return items, false, nil
+}
+
+func earliest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+	return a
+}
+
+func latest(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityService_UserStats(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := registerTestUser(t, s.users)
+	asked := &models.Question{Text: "What is Go?", UserID: &user, CreatedAt: base.Add(time.Hour)}
+	require.NoError(t, s.questionRepo.Create(asked))
+	other := &models.Question{Text: "What is Rust?", CreatedAt: base}
+	require.NoError(t, s.questionRepo.Create(other))
+	for _, at := range []time.Time{base, base.Add(2 * time.Hour)} {
+		require.NoError(t, s.answerRepo.Create(&models.Answer{QuestionID: uint(other.ID), UserID: user, Text: "Answer", CreatedAt: at}))
+	}
+
+	stats, err := s.activity.GetUserStats(user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Questions)
+	assert.Equal(t, int64(2), stats.Answers)
+	assert.Equal(t, base, *stats.FirstActivityAt)
+	assert.Equal(t, base.Add(2*time.Hour), *stats.LastActivityAt)
+
+	answers, err := s.activity.GetUserAnswers(user, repositories.Page{Limit: 1, Sort: repositories.NewestFirst})
+	require.NoError(t, err)
+	require.Len(t, answers.Answers, 1)
+	assert.Equal(t, base.Add(2*time.Hour), answers.Answers[0].CreatedAt)
+	assert.Equal(t, 2, answers.Total)
+	assert.True(t, answers.HasMore)
+
+	stats, err = s.activity.GetUserStats(uuid.New())
+	require.NoError(t, err)
+	assert.Zero(t, stats.Questions+stats.Answers)
+	assert.Nil(t, stats.FirstActivityAt)
+	assert.Nil(t, stats.LastActivityAt)
+}
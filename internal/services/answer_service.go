@@ -1,51 +1,132 @@
 package services
 
 import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/markdown"
 	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
 	"api_service_questions_and_answers/internal/repositories"
 	"errors"
 
 	"gorm.io/gorm"
 )
 
+// ErrQuestionNotFound is returned when answering a question that does not
+// exist.
+var ErrQuestionNotFound = errors.New("question not found")
+
+// AnswerService treats hidden answers as missing and hidden questions as
+// unknown.
 type AnswerService interface {
 	CreateAnswer(questionId uint, request *models.Answer) (*models.Answer, error)
 	GetAnswer(id uint) (*models.Answer, error)
+	GetAnswersByQuestionIDs(questionIDs []uint) (map[uint][]*models.Answer, error)
+	ListAnswers(questionID uint, page repositories.Page) (*AnswerList, error)
 	DeleteAnswer(id uint) error
 }
 
+// AnswerList is one page of answers.
+type AnswerList struct {
+	Answers []*models.Answer
+	Total   int
+	HasMore bool
+}
+
 type answerService struct {
 	questionRepository repositories.QuestionRepository
 	answerRepository   repositories.AnswerRepository
+	userRepository     repositories.UserRepository
+	transactor         repositories.Transactor
+	rules              ReputationRules
+	filters            filter.Chain
 }
 
+// NewAnswerService records answer.created and answer.deleted in the outbox
+// together with the change. Creating and deleting answers updates the
+// answer stats of their question in the same transaction, and so does the
+// reputation they earn their author under rules. Only active users can
+// answer. New answers go through filters first: rejected ones are not saved
+// and flagged ones are saved hidden and held for moderation, without
+// answer.created or reputation until a moderator approves them.
 func NewAnswerService(
 	questionRepository repositories.QuestionRepository,
 	answerRepository repositories.AnswerRepository,
+	userRepository repositories.UserRepository,
+	transactor repositories.Transactor,
+	rules ReputationRules,
+	filters filter.Chain,
 ) AnswerService {
 	return &answerService{
 		questionRepository,
 		answerRepository,
+		userRepository,
+		transactor,
+		rules,
+		filters,
 	}
 }
 
+// CreateAnswer renders the Markdown of the answer to HTML. It returns
+// ErrQuestionNotFound for an unknown question, ErrUserNotFound or
+// ErrUserSuspended when the author cannot answer and ErrContentRejected
+// when a filter rejects the answer.
 func (a answerService) CreateAnswer(questionId uint, request *models.Answer) (*models.Answer, error) {
-	_, err := a.questionRepository.FindByID(questionId)
+	_, err := found(a.questionRepository.FindByID(questionId))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("question not found")
+			return nil, ErrQuestionNotFound
 		}
 		return nil, err
 	}
 
+	err = activeUser(a.userRepository, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := screen(a.filters, request.Text)
+	if err != nil {
+		return nil, err
+	}
+
 	answer := &models.Answer{
 		QuestionID: questionId,
 		UserID:     request.UserID,
 		Text:       request.Text,
+		TextHTML:   markdown.Render(request.Text),
 		CreatedAt:  request.CreatedAt,
+		Hidden:     result.Verdict == filter.Flag,
 	}
 
-	err = a.answerRepository.Create(answer)
+	err = a.transactor.Transaction(func(tx repositories.Repositories) error {
+		err := tx.Answers.Create(answer)
+		if err != nil {
+			return err
+		}
+
+		if answer.Hidden {
+			_, err := tx.Moderation.HoldForReview(models.ContentAnswer, uint(answer.ID), result.Reason.Error())
+			return err
+		}
+
+		err = tx.Questions.UpdateAnswerStats(questionId, 1)
+		if err != nil {
+			return err
+		}
+
+		err = awardAnswer(tx.Reputation, answer, models.ReputationAnswerPosted, a.rules[models.ReputationAnswerPosted])
+		if err != nil {
+			return err
+		}
+
+		return outbox.Record(tx, events.Event{
+			Type:       events.AnswerCreated,
+			QuestionID: questionId,
+			UserID:     answer.UserID,
+			Data:       answer,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -54,9 +135,104 @@ func (a answerService) CreateAnswer(questionId uint, request *models.Answer) (*m
 }
 
 func (a answerService) GetAnswer(id uint) (*models.Answer, error) {
-	return a.answerRepository.FindByID(id)
+	answer, err := a.answerRepository.FindByID(id)
+	if err == nil && answer.Hidden {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return answer, err
+}
+
+// GetAnswersByQuestionIDs loads the answers of several questions at once,
+// keyed by question id. Questions without answers are missing from the map.
+func (a answerService) GetAnswersByQuestionIDs(questionIDs []uint) (map[uint][]*models.Answer, error) {
+	answers, err := a.answerRepository.FindByQuestionIDs(questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byQuestion := make(map[uint][]*models.Answer, len(questionIDs))
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = append(byQuestion[answer.QuestionID], answer)
+	}
+	return byQuestion, nil
+}
+
+// ListAnswers returns ErrQuestionNotFound for an unknown question.
+func (a answerService) ListAnswers(questionID uint, page repositories.Page) (*AnswerList, error) {
+	var question *models.Question
+	answers, hasMore, err := findPage(page, func(page repositories.Page) ([]*models.Answer, error) {
+		var err error
+		question, err = found(a.questionRepository.FindByIDWithAnswers(questionID, page))
+		if err != nil {
+			return nil, err
+		}
+
+		answers := make([]*models.Answer, len(question.Answers))
+		for i := range question.Answers {
+			answers[i] = &question.Answers[i]
+		}
+		return answers, nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, err
+	}
+
+	return &AnswerList{Answers: answers, Total: question.AnswerCount, HasMore: hasMore}, nil
 }
 
 func (a answerService) DeleteAnswer(id uint) error {
-	return a.answerRepository.DeleteByID(id)
+	answer, err := a.answerRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	err = a.transactor.Transaction(func(tx repositories.Repositories) error {
+		// Moderation may have hidden or shown the answer since it was read.
+		current, err := tx.Answers.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Answers.DeleteByID(id)
+		if err != nil {
+			return err
+		}
+
+		if !current.Hidden {
+			err = tx.Questions.UpdateAnswerStats(answer.QuestionID, -1)
+			if err != nil {
+				return err
+			}
+		}
+
+		points, err := tx.Reputation.AnswerPoints(answer.UserID, id)
+		if err != nil {
+			return err
+		}
+		err = awardAnswer(tx.Reputation, answer, models.ReputationAnswerDeleted, -points)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Record(tx, events.Event{
+			Type:       events.AnswerDeleted,
+			QuestionID: answer.QuestionID,
+			UserID:     answer.UserID,
+			Data:       answer,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAnswerService_CreateAnswer_Success(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	userID := registerTestUser(t, s.users)
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: userID, Text: "A language"})
+	require.NoError(t, err)
+	assert.NotZero(t, answer.ID)
+	assert.Equal(t, uint(question.ID), answer.QuestionID)
+
+	found, err := s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	require.Len(t, found.Answers, 1)
+	assert.Equal(t, userID, found.Answers[0].UserID)
+}
+
+func TestAnswerService_CreateAnswer_QuestionNotFound(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	_, err := s.answers.CreateAnswer(999, &models.Answer{UserID: uuid.New(), Text: "A language"})
+	require.Error(t, err)
+	assert.Equal(t, "question not found", err.Error())
+}
+
+func TestAnswerService_DeleteAnswer(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.answers.DeleteAnswer(uint(answer.ID)))
+
+	_, err = s.answers.GetAnswer(uint(answer.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestAnswerService_PublishesEvents(t *testing.T) {
+	broadcaster := events.NewBroadcaster(10)
+	s := newTestServices(t, testConfig{publisher: broadcaster})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	sub := broadcaster.Subscribe(uint(question.ID), 0)
+	defer sub.Close()
+
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language"})
+	require.NoError(t, err)
+	require.NoError(t, s.answers.DeleteAnswer(uint(answer.ID)))
+
+	created := <-sub.C
+	assert.Equal(t, events.AnswerCreated, created.Type)
+	assert.Equal(t, uint(question.ID), created.QuestionID)
+
+	deleted := <-sub.C
+	assert.Equal(t, events.AnswerDeleted, deleted.Type)
+	assert.Greater(t, deleted.ID, created.ID)
+}
+
+func TestAnswerService_ListAnswersPages(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	for range 3 {
+		_, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language"})
+		require.NoError(t, err)
+	}
+
+	first, err := s.answers.ListAnswers(uint(question.ID), repositories.Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, first.Answers, 2)
+	assert.Equal(t, 3, first.Total)
+	assert.True(t, first.HasMore)
+
+	last, err := s.answers.ListAnswers(uint(question.ID), repositories.Page{After: uint(first.Answers[1].ID), Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, last.Answers, 1)
+	assert.False(t, last.HasMore)
+
+	_, err = s.answers.ListAnswers(999, repositories.Page{Limit: 2})
+	assert.ErrorIs(t, err, ErrQuestionNotFound)
+}
+
+func TestAnswerService_MaintainsAnswerStats(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?", CreatedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, question.CreatedAt, question.LastActivityAt)
+
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language", CreatedAt: question.CreatedAt.Add(time.Minute)})
+	require.NoError(t, err)
+
+	found, err := s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Equal(t, 1, found.AnswerCount)
+	assert.Equal(t, answer.CreatedAt, found.LastActivityAt)
+
+	require.NoError(t, s.answers.DeleteAnswer(uint(answer.ID)))
+	require.NoError(t, s.answers.DeleteAnswer(uint(answer.ID)))
+
+	found, err = s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Zero(t, found.AnswerCount)
+	assert.Equal(t, question.CreatedAt, found.LastActivityAt)
+}
+
+func TestAnswerService_CreateAnswer_RequiresActiveUser(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: uuid.New(), Text: "A language"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	userID := registerTestUser(t, s.users)
+	require.NoError(t, s.users.UpdateStatus(userID, models.UserSuspended))
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: userID, Text: "A language"})
+	assert.ErrorIs(t, err, ErrUserSuspended)
+
+	found, err := s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Empty(t, found.Answers)
+	assert.Zero(t, found.AnswerCount)
+}
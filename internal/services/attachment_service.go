@@ -0,0 +1,285 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/blob"
+	"api_service_questions_and_answers/internal/i18n"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrAttachmentType is wrapped in an *i18n.Error with the sniffed type
+	// of the file.
+	ErrAttachmentType     = errors.New("attachment type not allowed")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+// maxFilename is the length, in bytes, to which uploaded file names are cut.
+const maxFilename = 255
+
+type AttachmentService interface {
+	// Attach stores body as a file of the question or answer. It returns
+	// ErrContentNotFound for unknown or hidden content, ErrAttachmentType
+	// when the sniffed type is not allowed and ErrAttachmentTooLarge when
+	// body is longer than the size limit.
+	Attach(contentType string, contentID uint, filename string, body io.Reader) (*models.Attachment, error)
+	// OpenAttachment returns the attachment with its file, which the caller
+	// must close, or ErrAttachmentNotFound when it is missing or belongs to
+	// hidden content.
+	OpenAttachment(id uint) (*models.Attachment, io.ReadSeekCloser, error)
+}
+
+type attachmentService struct {
+	questionRepository   repositories.QuestionRepository
+	answerRepository     repositories.AnswerRepository
+	attachmentRepository repositories.AttachmentRepository
+	blobs                blob.Store
+	maxSize              int64
+	allowedTypes         []string
+}
+
+// NewAttachmentService accepts files of up to maxSize bytes whose sniffed
+// media type is one of allowedTypes.
+func NewAttachmentService(
+	questionRepository repositories.QuestionRepository,
+	answerRepository repositories.AnswerRepository,
+	attachmentRepository repositories.AttachmentRepository,
+	blobs blob.Store,
+	maxSize int64,
+	allowedTypes []string,
+) AttachmentService {
+	return &attachmentService{
+		questionRepository,
+		answerRepository,
+		attachmentRepository,
+		blobs,
+		maxSize,
+		allowedTypes,
+	}
+}
+
+func (a attachmentService) Attach(contentType string, contentID uint, filename string, body io.Reader) (*models.Attachment, error) {
+	attachment := &models.Attachment{Filename: attachmentFilename(filename)}
+	switch contentType {
+	case models.ContentQuestion:
+		_, err := found(a.questionRepository.FindByID(contentID))
+		if err != nil {
+			return nil, contentError(err)
+		}
+		attachment.QuestionID = contentID
+	case models.ContentAnswer:
+		answer, err := a.answerRepository.FindByID(contentID)
+		if err == nil && answer.Hidden {
+			err = gorm.ErrRecordNotFound
+		}
+		if err != nil {
+			return nil, contentError(err)
+		}
+		attachment.QuestionID = answer.QuestionID
+		attachment.AnswerID = &contentID
+	default:
+		return nil, fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	// http.DetectContentType looks at no more than the first 512 bytes.
+	reader := bufio.NewReaderSize(body, 512)
+	head, err := reader.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	attachment.ContentType = http.DetectContentType(head)
+	mediaType, _, _ := mime.ParseMediaType(attachment.ContentType)
+	if !slices.Contains(a.allowedTypes, mediaType) {
+		return nil, i18n.Wrap(ErrAttachmentType, i18n.AttachmentTypeNotAllowed, mediaType)
+	}
+
+	attachment.BlobKey = uuid.NewString()
+	hash := sha256.New()
+	// Reading one byte past the limit tells a file of exactly maxSize bytes
+	// from a larger one.
+	attachment.Size, err = a.blobs.Put(attachment.BlobKey, io.TeeReader(io.LimitReader(reader, a.maxSize+1), hash))
+	if err != nil {
+		return nil, err
+	}
+	if attachment.Size > a.maxSize {
+		a.deleteBlob(attachment.BlobKey)
+		return nil, ErrAttachmentTooLarge
+	}
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	err = a.attachmentRepository.Create(attachment)
+	if err != nil {
+		a.deleteBlob(attachment.BlobKey)
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			// The content was deleted during the upload.
+			return nil, ErrContentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (a attachmentService) OpenAttachment(id uint) (*models.Attachment, io.ReadSeekCloser, error) {
+	attachment, err := a.attachmentRepository.FindByID(id)
+	if err != nil {
+		return nil, nil, attachmentError(err)
+	}
+
+	_, err = found(a.questionRepository.FindByID(attachment.QuestionID))
+	if err == nil && attachment.AnswerID != nil {
+		var answer *models.Answer
+		answer, err = a.answerRepository.FindByID(*attachment.AnswerID)
+		if err == nil && answer.Hidden {
+			err = gorm.ErrRecordNotFound
+		}
+	}
+	if err != nil {
+		return nil, nil, attachmentError(err)
+	}
+
+	file, err := a.blobs.Open(attachment.BlobKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, ErrAttachmentNotFound
+		}
+		return nil, nil, err
+	}
+	return attachment, file, nil
+}
+
+func (a attachmentService) deleteBlob(key string) {
+	deleteBlobs(a.blobs, []*models.Attachment{{BlobKey: key}})
+}
+
+// deleteBlobs removes the files of attachments. Failures are logged: the
+// rows are gone by then and a leftover file only takes space.
+func deleteBlobs(blobs blob.Store, attachments []*models.Attachment) {
+	for _, attachment := range attachments {
+		err := blobs.Delete(attachment.BlobKey)
+		if err != nil {
+			log.Printf("Failed to delete attachment file %s: %v", attachment.BlobKey, err)
+		}
+	}
+}
+
+func contentError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrContentNotFound
+	}
+	return err
+}
+
+func attachmentError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAttachmentNotFound
+	}
+	return err
+}
+
+// attachmentFilename keeps the base name of an uploaded file without
+// control characters, cut to maxFilename bytes.
+func attachmentFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(name, ""))
+	if len(name) > maxFilename {
+		// Cutting may split a character, whose remains are dropped.
+		name = strings.ToValidUTF8(name[:maxFilename], "")
+	}
+	if name == "." || name == ".." || name == "/" || strings.TrimSpace(name) == "" {
+		return "attachment"
+	}
+	return name
+}
+
+type questionAttachments struct {
+	QuestionService
+	attachmentRepository repositories.AttachmentRepository
+	blobs                blob.Store
+}
+
+// DeleteQuestionAttachments wraps questions so that deleting a question
+// also deletes the files attached to it and to its answers. Their rows go
+// with the question.
+func DeleteQuestionAttachments(
+	questions QuestionService,
+	attachmentRepository repositories.AttachmentRepository,
+	blobs blob.Store,
+) QuestionService {
+	return &questionAttachments{
+		questions,
+		attachmentRepository,
+		blobs,
+	}
+}
+
+func (q questionAttachments) DeleteQuestion(id uint) error {
+	attachments, err := q.attachmentRepository.FindByQuestion(id)
+	if err != nil {
+		return err
+	}
+
+	err = q.QuestionService.DeleteQuestion(id)
+	if err != nil {
+		return err
+	}
+
+	deleteBlobs(q.blobs, attachments)
+	return nil
+}
+
+type answerAttachments struct {
+	AnswerService
+	attachmentRepository repositories.AttachmentRepository
+	blobs                blob.Store
+}
+
+// DeleteAnswerAttachments wraps answers so that deleting an answer also
+// deletes the files attached to it.
+func DeleteAnswerAttachments(
+	answers AnswerService,
+	attachmentRepository repositories.AttachmentRepository,
+	blobs blob.Store,
+) AnswerService {
+	return &answerAttachments{
+		answers,
+		attachmentRepository,
+		blobs,
+	}
+}
+
+func (a answerAttachments) DeleteAnswer(id uint) error {
+	attachments, err := a.attachmentRepository.FindByAnswer(id)
+	if err != nil {
+		return err
+	}
+
+	err = a.AnswerService.DeleteAnswer(id)
+	if err != nil {
+		return err
+	}
+
+	deleteBlobs(a.blobs, attachments)
+	return nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/blob"
+	"api_service_questions_and_answers/internal/models"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func TestAttachmentService_AttachAndOpen(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is in the picture?"})
+	require.NoError(t, err)
+
+	content := append(pngHeader, "pixels"...)
+	attachment, err := s.attachments.Attach(models.ContentQuestion, uint(question.ID), `C:\photos\cat.png`, bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, "cat.png", attachment.Filename)
+	assert.Equal(t, "image/png", attachment.ContentType)
+	assert.Equal(t, int64(len(content)), attachment.Size)
+	assert.Len(t, attachment.SHA256, 64)
+
+	opened, file, err := s.attachments.OpenAttachment(uint(attachment.ID))
+	require.NoError(t, err)
+	defer file.Close()
+	assert.Equal(t, attachment.BlobKey, opened.BlobKey)
+	stored, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, content, stored)
+
+	_, _, err = s.attachments.OpenAttachment(999)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+}
+
+func TestAttachmentService_AttachRejects(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is in the archive?"})
+	require.NoError(t, err)
+	id := uint(question.ID)
+
+	_, err = s.attachments.Attach(models.ContentQuestion, id, "archive.zip", strings.NewReader("PK\x03\x04"))
+	assert.ErrorIs(t, err, ErrAttachmentType)
+	assert.ErrorContains(t, err, "application/zip")
+
+	_, err = s.attachments.Attach(models.ContentQuestion, id, "long.txt", strings.NewReader(strings.Repeat("a", 65)))
+	assert.ErrorIs(t, err, ErrAttachmentTooLarge)
+	_, err = s.attachments.Attach(models.ContentQuestion, id, "limit.txt", strings.NewReader(strings.Repeat("a", 64)))
+	assert.NoError(t, err)
+
+	_, err = s.attachments.Attach(models.ContentQuestion, 999, "cat.png", bytes.NewReader(pngHeader))
+	assert.ErrorIs(t, err, ErrContentNotFound)
+	_, err = s.attachments.Attach(models.ContentAnswer, 999, "cat.png", bytes.NewReader(pngHeader))
+	assert.ErrorIs(t, err, ErrContentNotFound)
+}
+
+func TestAttachmentService_DeleteRemovesFiles(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is in the picture?"})
+	require.NoError(t, err)
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A cat"})
+	require.NoError(t, err)
+
+	onQuestion, err := s.attachments.Attach(models.ContentQuestion, uint(question.ID), "cat.png", bytes.NewReader(pngHeader))
+	require.NoError(t, err)
+	onAnswer, err := s.attachments.Attach(models.ContentAnswer, uint(answer.ID), "cat.txt", strings.NewReader("a cat"))
+	require.NoError(t, err)
+	require.Equal(t, uint(question.ID), onAnswer.QuestionID)
+
+	require.NoError(t, s.questions.DeleteQuestion(uint(question.ID)))
+
+	for _, attachment := range []*models.Attachment{onQuestion, onAnswer} {
+		_, _, err = s.attachments.OpenAttachment(uint(attachment.ID))
+		assert.ErrorIs(t, err, ErrAttachmentNotFound)
+		_, err = s.blobs.Open(attachment.BlobKey)
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	}
+}
+
+func TestAttachmentFilename(t *testing.T) {
+	assert.Equal(t, "report.pdf", attachmentFilename("../../etc/report.pdf"))
+	assert.Equal(t, "ab.txt", attachmentFilename("a\x00b.txt"))
+	assert.Equal(t, "attachment", attachmentFilename(""))
+	assert.Equal(t, "attachment", attachmentFilename("a/.."))
+	assert.Len(t, attachmentFilename(strings.Repeat("я", 200)), 254)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/i18n"
+	"errors"
+)
+
+// ErrContentRejected is returned when a content filter rejects a new
+// question or answer. The *i18n.Error wrapping it says why.
+var ErrContentRejected = errors.New("content rejected")
+
+// screen runs chain over text. It returns ErrContentRejected when a filter
+// rejects the text and otherwise the result, which says whether to hold
+// the content for review.
+func screen(chain filter.Chain, text string) (filter.Result, error) {
+	result := chain.Check(text)
+	if result.Verdict == filter.Reject {
+		return result, i18n.Wrap(ErrContentRejected, i18n.ContentRejected, result.Reason)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestContentFilters_RejectAndHoldForReview(t *testing.T) {
+	chain := filter.Chain{filter.BannedWords(filter.NewLexicon([]string{"казино"}), filter.Reject), filter.Links(0, filter.Flag)}
+	s := newTestServices(t, testConfig{
+		rules:         ReputationRules{models.ReputationAnswerPosted: 2},
+		filters:       chain,
+		flagThreshold: 3,
+	})
+
+	_, err := s.questions.CreateQuestion(&models.Question{Text: "Лучшие казино?"})
+	assert.ErrorIs(t, err, ErrContentRejected)
+	assert.ErrorContains(t, err, `"казино"`)
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "Is https://go.dev any good?"})
+	require.NoError(t, err)
+	assert.True(t, question.Hidden)
+	_, err = s.questions.GetQuestion(uint(question.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	queue, err := s.moderation.GetQueue(repositories.Page{})
+	require.NoError(t, err)
+	require.Len(t, queue.Cases, 1)
+	assert.Equal(t, "contains more than 0 links", queue.Cases[0].ReviewReason)
+	assert.Zero(t, queue.Cases[0].FlagCount)
+	assert.Empty(t, pendingEventTypes(t, s.outbox), "held content has no created event")
+
+	moderator := registerTestUser(t, s.users)
+	_, err = s.moderation.ResolveCase(uint(queue.Cases[0].ID), "approve", moderator)
+	require.NoError(t, err)
+	_, err = s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Equal(t, []string{events.QuestionCreated}, pendingEventTypes(t, s.outbox))
+
+	author := registerTestUser(t, s.users)
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "Try an online казино"})
+	assert.ErrorIs(t, err, ErrContentRejected)
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "See https://go.dev/doc"})
+	require.NoError(t, err)
+	assert.True(t, answer.Hidden)
+
+	found, err := s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Empty(t, found.Answers)
+	assert.Zero(t, found.AnswerCount, "a held answer is not counted")
+	queue, err = s.moderation.GetQueue(repositories.Page{})
+	require.NoError(t, err)
+	require.Len(t, queue.Cases, 1)
+	require.NotNil(t, queue.Cases[0].Answer)
+	assert.Equal(t, answer.ID, queue.Cases[0].Answer.ID)
+	assert.Empty(t, pendingEventTypes(t, s.outbox))
+	reputation, err := s.reputation.GetUserReputation(author, repositories.Page{})
+	require.NoError(t, err)
+	assert.Zero(t, reputation.Reputation, "a held answer earns nothing")
+
+	_, err = s.moderation.ResolveCase(uint(queue.Cases[0].ID), "dismiss", moderator)
+	require.NoError(t, err)
+	found, err = s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Len(t, found.Answers, 1)
+	assert.Equal(t, 1, found.AnswerCount)
+	assert.Equal(t, []string{events.AnswerCreated}, pendingEventTypes(t, s.outbox))
+	reputation, err = s.reputation.GetUserReputation(author, repositories.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, reputation.Reputation)
+}
+
+// pendingEventTypes marks the pending outbox events done and returns their
+// types.
+func pendingEventTypes(t *testing.T, repo repositories.OutboxRepository) []string {
+	t.Helper()
+
+	var types []string
+	_, err := repo.ProcessPending(0, 100, time.Now(), func(repositories.Repositories, *models.OutboxEvent) error {
+		return nil
+	}, func(event *models.OutboxEvent) bool {
+		types = append(types, event.Type)
+		event.Status = models.OutboxDone
+		return true
+	})
+	require.NoError(t, err)
+	return types
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
+	"api_service_questions_and_answers/internal/repositories"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrContentNotFound is returned when flagging a question or an answer
+	// that does not exist or is hidden.
+	ErrContentNotFound = errors.New("content not found")
+	ErrAlreadyFlagged  = errors.New("content already flagged by this user")
+	ErrCaseNotFound    = errors.New("moderation case not found")
+	ErrCaseResolved    = errors.New("moderation case already resolved")
+)
+
+type ModerationService interface {
+	FlagContent(contentType string, contentID uint, request *models.Flag) (*models.Flag, error)
+	GetQueue(page repositories.Page) (*ModerationQueue, error)
+	ResolveCase(id uint, action string, moderatorID uuid.UUID) (*models.ModerationCase, error)
+}
+
+// ModerationQueue is one page of the open moderation cases.
+type ModerationQueue struct {
+	Cases   []*models.ModerationCase
+	Total   int
+	HasMore bool
+}
+
+type moderationService struct {
+	questionRepository   repositories.QuestionRepository
+	answerRepository     repositories.AnswerRepository
+	userRepository       repositories.UserRepository
+	moderationRepository repositories.ModerationRepository
+	transactor           repositories.Transactor
+	questionService      QuestionService
+	answerService        AnswerService
+	rules                ReputationRules
+	flagThreshold        int
+}
+
+// NewModerationService hides content once its open case has flagThreshold
+// flags, unless a moderator approved it before. Removing content deletes
+// it through questionService and answerService, like its author would.
+// Approving a held answer awards its author the points of rules.
+func NewModerationService(
+	questionRepository repositories.QuestionRepository,
+	answerRepository repositories.AnswerRepository,
+	userRepository repositories.UserRepository,
+	moderationRepository repositories.ModerationRepository,
+	transactor repositories.Transactor,
+	questionService QuestionService,
+	answerService AnswerService,
+	rules ReputationRules,
+	flagThreshold int,
+) ModerationService {
+	return &moderationService{
+		questionRepository,
+		answerRepository,
+		userRepository,
+		moderationRepository,
+		transactor,
+		questionService,
+		answerService,
+		rules,
+		flagThreshold,
+	}
+}
+
+// FlagContent returns ErrContentNotFound for unknown or hidden content,
+// ErrUserNotFound or ErrUserSuspended when the reporter cannot flag and
+// ErrAlreadyFlagged when the reporter already flagged the open case.
+func (m moderationService) FlagContent(contentType string, contentID uint, request *models.Flag) (*models.Flag, error) {
+	hidden, err := m.isHidden(contentType, contentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrContentNotFound
+		}
+		return nil, err
+	}
+	if hidden {
+		return nil, ErrContentNotFound
+	}
+
+	err = activeUser(m.userRepository, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	flag := &models.Flag{
+		UserID:    request.UserID,
+		Reason:    request.Reason,
+		CreatedAt: time.Now(),
+	}
+
+	err = m.transactor.Transaction(func(tx repositories.Repositories) error {
+		moderationCase, err := tx.Moderation.OpenCase(contentType, contentID)
+		if err != nil {
+			return err
+		}
+
+		flag.CaseID = moderationCase.ID
+		count, err := tx.Moderation.AddFlag(flag)
+		if err != nil || count < m.flagThreshold {
+			return err
+		}
+
+		approved, err := tx.Moderation.WasApproved(contentType, contentID)
+		if err != nil || approved {
+			return err
+		}
+		return setHidden(tx, contentType, contentID, true)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyFlagged
+		}
+		return nil, err
+	}
+
+	return flag, nil
+}
+
+// GetQueue returns the open cases oldest first by default, each with its
+// flags and content.
+func (m moderationService) GetQueue(page repositories.Page) (*ModerationQueue, error) {
+	cases, hasMore, err := findPage(page, m.moderationRepository.FindOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, moderationCase := range cases {
+		err := m.loadContent(moderationCase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	total, err := m.moderationRepository.CountOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModerationQueue{Cases: cases, Total: int(total), HasMore: hasMore}, nil
+}
+
+// ResolveCase returns ErrCaseNotFound for an unknown case, ErrCaseResolved
+// for a case that is no longer open and ErrUserNotFound or
+// ErrUserSuspended when the moderator is not an active user. Approving
+// and dismissing show the content again; removing deletes it. Content a
+// filter held for review never had its created event nor, for an answer,
+// its reputation, so showing it records both then.
+func (m moderationService) ResolveCase(id uint, action string, moderatorID uuid.UUID) (*models.ModerationCase, error) {
+	status, ok := models.ModerationActions[action]
+	if !ok {
+		return nil, fmt.Errorf("unknown moderation action %q", action)
+	}
+
+	err := activeUser(m.userRepository, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	moderationCase, err := m.moderationRepository.FindCase(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCaseNotFound
+		}
+		return nil, err
+	}
+	if moderationCase.Status != models.CaseOpen {
+		return nil, ErrCaseResolved
+	}
+
+	// Deleting first lets a failed resolution be retried: deleting content
+	// that is already gone succeeds.
+	if status == models.CaseRemoved {
+		err := m.deleteContent(moderationCase.ContentType, moderationCase.ContentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = m.transactor.Transaction(func(tx repositories.Repositories) error {
+		err := tx.Moderation.Resolve(id, status, moderatorID, time.Now())
+		if err != nil || status == models.CaseRemoved {
+			return err
+		}
+
+		err = setHidden(tx, moderationCase.ContentType, moderationCase.ContentID, false)
+		if err != nil || moderationCase.ReviewReason == "" {
+			return err
+		}
+		return m.recordCreated(tx, moderationCase.ContentType, moderationCase.ContentID)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCaseResolved
+		}
+		return nil, err
+	}
+
+	resolved, err := m.moderationRepository.FindCase(id)
+	if err != nil {
+		return nil, err
+	}
+	err = m.loadContent(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (m moderationService) isHidden(contentType string, id uint) (bool, error) {
+	if contentType == models.ContentQuestion {
+		question, err := m.questionRepository.FindByID(id)
+		if err != nil {
+			return false, err
+		}
+		return question.Hidden, nil
+	}
+
+	answer, err := m.answerRepository.FindByID(id)
+	if err != nil {
+		return false, err
+	}
+	return answer.Hidden, nil
+}
+
+// loadContent sets the question or the answer of the case, if it still
+// exists.
+func (m moderationService) loadContent(moderationCase *models.ModerationCase) error {
+	var err error
+	if moderationCase.ContentType == models.ContentQuestion {
+		moderationCase.Question, err = m.questionRepository.FindByID(moderationCase.ContentID)
+		if err == nil {
+			moderationCase.Question.Answers = nil
+		}
+	} else {
+		moderationCase.Answer, err = m.answerRepository.FindByID(moderationCase.ContentID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (m moderationService) deleteContent(contentType string, id uint) error {
+	if contentType == models.ContentQuestion {
+		return m.questionService.DeleteQuestion(id)
+	}
+	return m.answerService.DeleteAnswer(id)
+}
+
+// recordCreated records the created event of the question or the answer, if
+// it still exists, in the outbox and awards the answer its reputation.
+func (m moderationService) recordCreated(tx repositories.Repositories, contentType string, id uint) error {
+	if contentType == models.ContentQuestion {
+		question, err := tx.Questions.FindByID(id)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+
+		question.Answers = nil
+		event := events.Event{
+			Type:       events.QuestionCreated,
+			QuestionID: id,
+			Data:       question,
+		}
+		if question.UserID != nil {
+			event.UserID = *question.UserID
+		}
+		return outbox.Record(tx, event)
+	}
+
+	answer, err := tx.Answers.FindByID(id)
+	if err != nil {
+		return ignoreNotFound(err)
+	}
+
+	err = awardAnswer(tx.Reputation, answer, models.ReputationAnswerPosted, m.rules[models.ReputationAnswerPosted])
+	if err != nil {
+		return err
+	}
+	return outbox.Record(tx, events.Event{
+		Type:       events.AnswerCreated,
+		QuestionID: answer.QuestionID,
+		UserID:     answer.UserID,
+		Data:       answer,
+	})
+}
+
+// setHidden keeps the answer count of the question in step with the
+// visible answers when it hides or shows an answer.
+func setHidden(tx repositories.Repositories, contentType string, id uint, hidden bool) error {
+	if contentType == models.ContentQuestion {
+		return tx.Questions.SetHidden(id, hidden)
+	}
+
+	answer, err := tx.Answers.FindByID(id)
+	if err != nil || answer.Hidden == hidden {
+		return ignoreNotFound(err)
+	}
+
+	err = tx.Answers.SetHidden(id, hidden)
+	if err != nil {
+		return err
+	}
+
+	delta := 1
+	if hidden {
+		delta = -1
+	}
+	return tx.Questions.UpdateAnswerStats(answer.QuestionID, delta)
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestModerationService_FlagsHideQuestionUntilApproved(t *testing.T) {
+	s := newTestServices(t, testConfig{flagThreshold: 2})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "Buy cheap watches?"})
+	require.NoError(t, err)
+	id := uint(question.ID)
+
+	first := registerTestUser(t, s.users)
+	_, err = s.moderation.FlagContent(models.ContentQuestion, id, &models.Flag{UserID: first, Reason: models.FlagSpam})
+	require.NoError(t, err)
+	_, err = s.moderation.FlagContent(models.ContentQuestion, id, &models.Flag{UserID: first, Reason: models.FlagSpam})
+	assert.ErrorIs(t, err, ErrAlreadyFlagged)
+
+	_, err = s.questions.GetQuestion(id)
+	require.NoError(t, err)
+
+	flag, err := s.moderation.FlagContent(models.ContentQuestion, id, &models.Flag{UserID: registerTestUser(t, s.users), Reason: models.FlagSpam})
+	require.NoError(t, err)
+
+	_, err = s.questions.GetQuestion(id)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	questions, err := s.questions.GetAllQuestions()
+	require.NoError(t, err)
+	assert.Empty(t, questions)
+	_, err = s.moderation.FlagContent(models.ContentQuestion, id, &models.Flag{UserID: registerTestUser(t, s.users), Reason: models.FlagSpam})
+	assert.ErrorIs(t, err, ErrContentNotFound)
+
+	queue, err := s.moderation.GetQueue(repositories.Page{})
+	require.NoError(t, err)
+	require.Len(t, queue.Cases, 1)
+	assert.Equal(t, flag.CaseID, queue.Cases[0].ID)
+	assert.Equal(t, 2, queue.Cases[0].FlagCount)
+	require.NotNil(t, queue.Cases[0].Question)
+	assert.True(t, queue.Cases[0].Question.Hidden)
+
+	moderator := registerTestUser(t, s.users)
+	resolved, err := s.moderation.ResolveCase(uint(flag.CaseID), "approve", moderator)
+	require.NoError(t, err)
+	assert.Equal(t, models.CaseApproved, resolved.Status)
+	assert.Equal(t, &moderator, resolved.ModeratorID)
+	_, err = s.moderation.ResolveCase(uint(flag.CaseID), "dismiss", moderator)
+	assert.ErrorIs(t, err, ErrCaseResolved)
+
+	// Approved content is queued again but no longer hidden by flags.
+	for range 2 {
+		_, err = s.moderation.FlagContent(models.ContentQuestion, id, &models.Flag{UserID: registerTestUser(t, s.users), Reason: models.FlagOther})
+		require.NoError(t, err)
+	}
+	_, err = s.questions.GetQuestion(id)
+	require.NoError(t, err)
+	queue, err = s.moderation.GetQueue(repositories.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, queue.Total)
+}
+
+func TestModerationService_RemoveAndDismissAnswers(t *testing.T) {
+	s := newTestServices(t, testConfig{flagThreshold: 2})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	author := registerTestUser(t, s.users)
+	spam, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "Buy cheap watches"})
+	require.NoError(t, err)
+	rude, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "What a silly question"})
+	require.NoError(t, err)
+
+	flagTwice := func(answerID int) int {
+		var flag *models.Flag
+		for range 2 {
+			flag, err = s.moderation.FlagContent(models.ContentAnswer, uint(answerID), &models.Flag{UserID: registerTestUser(t, s.users), Reason: models.FlagAbusive})
+			require.NoError(t, err)
+		}
+		return flag.CaseID
+	}
+	spamCase, rudeCase := flagTwice(spam.ID), flagTwice(rude.ID)
+
+	found, err := s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	assert.Empty(t, found.Answers)
+	assert.Zero(t, found.AnswerCount, "hidden answers are not counted")
+	list, err := s.answers.ListAnswers(uint(question.ID), repositories.Page{})
+	require.NoError(t, err)
+	assert.Zero(t, list.Total)
+	unanswered, err := s.questions.FindQuestions(repositories.NewQuestionQuery(repositories.Unanswered()))
+	require.NoError(t, err)
+	assert.Len(t, unanswered, 1)
+	_, err = s.answers.GetAnswer(uint(spam.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	moderator := registerTestUser(t, s.users)
+	_, err = s.moderation.ResolveCase(uint(spamCase), "remove", moderator)
+	require.NoError(t, err)
+	_, err = s.moderation.ResolveCase(uint(rudeCase), "dismiss", moderator)
+	require.NoError(t, err)
+
+	found, err = s.questions.GetQuestion(uint(question.ID))
+	require.NoError(t, err)
+	require.Len(t, found.Answers, 1)
+	assert.Equal(t, rude.ID, found.Answers[0].ID)
+	assert.Equal(t, 1, found.AnswerCount)
+
+	queue, err := s.moderation.GetQueue(repositories.Page{})
+	require.NoError(t, err)
+	assert.Empty(t, queue.Cases)
+
+	_, err = s.moderation.ResolveCase(99, "approve", moderator)
+	assert.ErrorIs(t, err, ErrCaseNotFound)
+	_, err = s.moderation.ResolveCase(uint(rudeCase), "approve", uuid.New())
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	_, err = s.moderation.FlagContent(models.ContentAnswer, uint(spam.ID), &models.Flag{UserID: moderator, Reason: models.FlagSpam})
+	assert.ErrorIs(t, err, ErrContentNotFound)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	events.Publisher
+	Follow(questionID uint, userID uuid.UUID) error
+	Unfollow(questionID uint, userID uuid.UUID) error
+	GetInbox(userID uuid.UUID, unreadOnly bool, limit int) (*Inbox, error)
+	MarkRead(userID uuid.UUID, id uint) error
+	MarkAllRead(userID uuid.UUID) error
+}
+
+// Inbox is a page of a user's notifications with the total unread count.
+type Inbox struct {
+	Unread        int64                  `json:"unread"`
+	Notifications []*models.Notification `json:"notifications"`
+}
+
+type notificationService struct {
+	questionRepository     repositories.QuestionRepository
+	followRepository       repositories.FollowRepository
+	notificationRepository repositories.NotificationRepository
+}
+
+// NewNotificationService turns answer.created events into inbox entries for
+// the followers of the question. Askers follow their own questions.
+func NewNotificationService(
+	questionRepository repositories.QuestionRepository,
+	followRepository repositories.FollowRepository,
+	notificationRepository repositories.NotificationRepository,
+) NotificationService {
+	return &notificationService{
+		questionRepository,
+		followRepository,
+		notificationRepository,
+	}
+}
+
+func (n notificationService) Follow(questionID uint, userID uuid.UUID) error {
+	_, err := n.questionRepository.FindByID(questionID)
+	if err != nil {
+		return err
+	}
+
+	return n.followRepository.Follow(&models.Follow{
+		QuestionID: questionID,
+		UserID:     userID,
+		CreatedAt:  time.Now(),
+	})
+}
+
+func (n notificationService) Unfollow(questionID uint, userID uuid.UUID) error {
+	return n.followRepository.Unfollow(questionID, userID)
+}
+
+func (n notificationService) GetInbox(userID uuid.UUID, unreadOnly bool, limit int) (*Inbox, error) {
+	notifications, err := n.notificationRepository.FindByUser(userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := n.notificationRepository.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Inbox{Unread: unread, Notifications: notifications}, nil
+}
+
+func (n notificationService) MarkRead(userID uuid.UUID, id uint) error {
+	return n.notificationRepository.MarkRead(userID, id, time.Now())
+}
+
+func (n notificationService) MarkAllRead(userID uuid.UUID) error {
+	return n.notificationRepository.MarkAllRead(userID, time.Now())
+}
+
+func (n notificationService) Publish(event events.Event) {
+	switch event.Type {
+	case events.QuestionCreated:
+		n.followAsker(event)
+	case events.AnswerCreated:
+		n.notifyFollowers(event)
+	}
+}
+
+// followAsker subscribes the author of a new question to its answers.
+func (n notificationService) followAsker(event events.Event) {
+	if event.UserID == uuid.Nil {
+		return
+	}
+
+	err := n.followRepository.Follow(&models.Follow{
+		QuestionID: event.QuestionID,
+		UserID:     event.UserID,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Printf("Failed to follow question %d for its asker %s: %v", event.QuestionID, event.UserID, err)
+	}
+}
+
+// notifyFollowers notifies every follower of the question except the
+// author of the new answer.
+func (n notificationService) notifyFollowers(event events.Event) {
+	answerID, err := answerIDOf(event)
+	if err != nil {
+		log.Printf("Failed to read answer of %s event: %v", event.Type, err)
+		return
+	}
+
+	followers, err := n.followRepository.FindFollowers(event.QuestionID)
+	if err != nil {
+		log.Printf("Failed to load followers of question %d: %v", event.QuestionID, err)
+		return
+	}
+
+	for _, follower := range followers {
+		if follower == event.UserID {
+			continue
+		}
+
+		err := n.notificationRepository.Create(&models.Notification{
+			UserID:     follower,
+			Type:       models.NotificationNewAnswer,
+			QuestionID: event.QuestionID,
+			AnswerID:   answerID,
+			CreatedAt:  time.Now(),
+		})
+		if err != nil {
+			log.Printf("Failed to notify %s about answer %d: %v", follower, answerID, err)
+		}
+	}
+}
+
+// answerIDOf reads the answer id from the event data, which is either the
+// answer itself or its JSON when the event came through the outbox.
+func answerIDOf(event events.Event) (uint, error) {
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	var answer struct {
+		ID uint `json:"id"`
+	}
+	err = json.Unmarshal(raw, &answer)
+	return answer.ID, err
+}
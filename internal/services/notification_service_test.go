@@ -0,0 +1,85 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNotificationService_NotifiesFollowersExceptAuthor(t *testing.T) {
+	s := newTestServices(t, testConfig{notify: true})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	follower, author := uuid.New(), registerTestUser(t, s.users)
+	require.NoError(t, s.notifications.Follow(uint(question.ID), follower))
+	require.NoError(t, s.notifications.Follow(uint(question.ID), follower))
+	require.NoError(t, s.notifications.Follow(uint(question.ID), author))
+
+	answer, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: author, Text: "A language"})
+	require.NoError(t, err)
+
+	inbox, err := s.notifications.GetInbox(follower, false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inbox.Unread)
+	require.Len(t, inbox.Notifications, 1)
+	assert.Equal(t, uint(answer.ID), inbox.Notifications[0].AnswerID)
+	assert.Equal(t, models.NotificationNewAnswer, inbox.Notifications[0].Type)
+
+	authorInbox, err := s.notifications.GetInbox(author, false, 10)
+	require.NoError(t, err)
+	assert.Empty(t, authorInbox.Notifications)
+
+	require.NoError(t, s.notifications.MarkRead(follower, uint(inbox.Notifications[0].ID)))
+	inbox, err = s.notifications.GetInbox(follower, true, 10)
+	require.NoError(t, err)
+	assert.Zero(t, inbox.Unread)
+	assert.Empty(t, inbox.Notifications)
+
+	assert.ErrorIs(t, s.notifications.MarkRead(author, 1), gorm.ErrRecordNotFound)
+}
+
+func TestNotificationService_UnfollowStopsNotifications(t *testing.T) {
+	s := newTestServices(t, testConfig{notify: true})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+
+	follower := uuid.New()
+	require.NoError(t, s.notifications.Follow(uint(question.ID), follower))
+	require.NoError(t, s.notifications.Unfollow(uint(question.ID), follower))
+
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language"})
+	require.NoError(t, err)
+
+	inbox, err := s.notifications.GetInbox(follower, false, 10)
+	require.NoError(t, err)
+	assert.Empty(t, inbox.Notifications)
+}
+
+func TestNotificationService_FollowUnknownQuestion(t *testing.T) {
+	s := newTestServices(t, testConfig{notify: true})
+
+	err := s.notifications.Follow(999, uuid.New())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestNotificationService_AskerFollowsOwnQuestion(t *testing.T) {
+	s := newTestServices(t, testConfig{notify: true})
+
+	asker := registerTestUser(t, s.users)
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?", UserID: &asker})
+	require.NoError(t, err)
+
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: registerTestUser(t, s.users), Text: "A language"})
+	require.NoError(t, err)
+
+	inbox, err := s.notifications.GetInbox(asker, false, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), inbox.Unread)
+}
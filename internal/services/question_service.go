@@ -1,35 +1,121 @@
 package services
 
 import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/markdown"
 	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
 	"api_service_questions_and_answers/internal/repositories"
+	"errors"
+
+	"gorm.io/gorm"
 )
 
+// QuestionService leaves hidden questions out of its reads: lists skip
+// them and lookups return gorm.ErrRecordNotFound.
 type QuestionService interface {
 	GetAllQuestions() ([]*models.Question, error)
+	FindQuestions(query repositories.QuestionQuery) ([]*models.Question, error)
+	// GetQuestionsPage returns one page of the questions, without answers.
+	GetQuestionsPage(page repositories.Page) ([]*models.Question, error)
+	CountQuestions() (int64, error)
+	// GetQuestionsByIDs returns the questions with the given ids, without
+	// answers; missing and hidden ones are skipped.
+	GetQuestionsByIDs(ids []uint) ([]*models.Question, error)
 	CreateQuestion(request *models.Question) (*models.Question, error)
 	GetQuestion(id uint) (*models.Question, error)
+	// GetQuestionPreview returns the question with only its first
+	// answersLimit answers.
+	GetQuestionPreview(id uint, answersLimit int) (*models.Question, error)
 	DeleteQuestion(id uint) error
 }
 
 type questionService struct {
 	questionRepository repositories.QuestionRepository
+	userRepository     repositories.UserRepository
+	transactor         repositories.Transactor
+	filters            filter.Chain
 }
 
+// NewQuestionService records question.created and question.deleted in the
+// outbox together with the change. Only active users can ask, though a
+// question may have no author. New questions go through filters first:
+// rejected ones are not saved and flagged ones are saved hidden and held for
+// moderation, without question.created.
 func NewQuestionService(
 	questionRepository repositories.QuestionRepository,
+	userRepository repositories.UserRepository,
+	transactor repositories.Transactor,
+	filters filter.Chain,
 ) QuestionService {
 	return &questionService{
 		questionRepository,
+		userRepository,
+		transactor,
+		filters,
 	}
 }
 
 func (q questionService) GetAllQuestions() ([]*models.Question, error) {
-	return q.questionRepository.FindAll()
+	return visible(q.questionRepository.FindAll())
+}
+
+func (q questionService) FindQuestions(query repositories.QuestionQuery) ([]*models.Question, error) {
+	return visible(q.questionRepository.Find(query))
+}
+
+func (q questionService) GetQuestionsPage(page repositories.Page) ([]*models.Question, error) {
+	return q.questionRepository.FindPage(page)
+}
+
+func (q questionService) CountQuestions() (int64, error) {
+	return q.questionRepository.CountVisible()
+}
+
+func (q questionService) GetQuestionsByIDs(ids []uint) ([]*models.Question, error) {
+	return visible(q.questionRepository.FindByIDs(ids))
 }
 
+// CreateQuestion renders the Markdown of the question to HTML. It returns
+// ErrUserNotFound or ErrUserSuspended when the author cannot ask and
+// ErrContentRejected when a filter rejects the question.
 func (q questionService) CreateQuestion(question *models.Question) (*models.Question, error) {
-	err := q.questionRepository.Create(question)
+	if question.UserID != nil {
+		err := activeUser(q.userRepository, *question.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := screen(q.filters, question.Text)
+	if err != nil {
+		return nil, err
+	}
+	question.TextHTML = markdown.Render(question.Text)
+	question.Hidden = result.Verdict == filter.Flag
+
+	err = q.transactor.Transaction(func(tx repositories.Repositories) error {
+		err := tx.Questions.Create(question)
+		if err != nil {
+			return err
+		}
+
+		if question.Hidden {
+			_, err := tx.Moderation.HoldForReview(models.ContentQuestion, uint(question.ID), result.Reason.Error())
+			return err
+		}
+
+		event := events.Event{
+			Type:       events.QuestionCreated,
+			QuestionID: uint(question.ID),
+			Data:       question,
+		}
+		if question.UserID != nil {
+			event.UserID = *question.UserID
+		}
+		return outbox.Record(tx, event)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -38,9 +124,70 @@ func (q questionService) CreateQuestion(question *models.Question) (*models.Ques
 }
 
 func (q questionService) GetQuestion(id uint) (*models.Question, error) {
-	return q.questionRepository.FindByID(id)
+	return found(q.questionRepository.FindByID(id))
 }
 
+func (q questionService) GetQuestionPreview(id uint, answersLimit int) (*models.Question, error) {
+	return found(q.questionRepository.FindByIDWithAnswers(id, repositories.Page{Limit: answersLimit}))
+}
+
+// DeleteQuestion takes back the reputation the answers to the question
+// earned, like DeleteAnswer does for a single answer.
 func (q questionService) DeleteQuestion(id uint) error {
-	return q.questionRepository.Delete(id)
+	question, err := q.questionRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return q.transactor.Transaction(func(tx repositories.Repositories) error {
+		earned, err := tx.Reputation.QuestionAnswerPoints(id)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range earned {
+			answer := &models.Answer{ID: int(entry.AnswerID), QuestionID: id, UserID: entry.UserID}
+			err := awardAnswer(tx.Reputation, answer, models.ReputationAnswerDeleted, -entry.Points)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Questions.Delete(id)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Record(tx, events.Event{
+			Type:       events.QuestionDeleted,
+			QuestionID: id,
+			Data:       question,
+		})
+	})
+}
+
+// visible drops the hidden questions from a repository result.
+func visible(questions []*models.Question, err error) ([]*models.Question, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	shown := make([]*models.Question, 0, len(questions))
+	for _, question := range questions {
+		if !question.Hidden {
+			shown = append(shown, question)
+		}
+	}
+	return shown, nil
+}
+
+// found turns a hidden question into gorm.ErrRecordNotFound.
+func found(question *models.Question, err error) (*models.Question, error) {
+	if err == nil && question.Hidden {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return question, err
 }
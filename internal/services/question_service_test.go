@@ -0,0 +1,82 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestQuestionService_CreateAndGetQuestion(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	created, err := s.questions.CreateQuestion(&models.Question{Text: "What is *Go*?"})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	found, err := s.questions.GetQuestion(uint(created.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "What is *Go*?", found.Text)
+	assert.Equal(t, "<p>What is <em>Go</em>?</p>\n", found.TextHTML)
+	assert.Empty(t, found.Answers)
+}
+
+func TestQuestionService_CreateQuestion_RequiresActiveUser(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	unknown := uuid.New()
+	_, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?", UserID: &unknown})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	suspended := registerTestUser(t, s.users)
+	require.NoError(t, s.users.UpdateStatus(suspended, models.UserSuspended))
+	_, err = s.questions.CreateQuestion(&models.Question{Text: "What is Go?", UserID: &suspended})
+	assert.ErrorIs(t, err, ErrUserSuspended)
+
+	questions, err := s.questions.GetAllQuestions()
+	require.NoError(t, err)
+	assert.Empty(t, questions)
+}
+
+func TestQuestionService_GetAllQuestions(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	_, err := s.questions.CreateQuestion(&models.Question{Text: "First question"})
+	require.NoError(t, err)
+	_, err = s.questions.CreateQuestion(&models.Question{Text: "Second question"})
+	require.NoError(t, err)
+
+	questions, err := s.questions.GetAllQuestions()
+	require.NoError(t, err)
+	require.Len(t, questions, 2)
+	assert.Equal(t, "First question", questions[0].Text)
+	assert.Equal(t, "Second question", questions[1].Text)
+}
+
+func TestQuestionService_GetQuestion_NotFound(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	_, err := s.questions.GetQuestion(999)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestQuestionService_DeleteQuestion_CascadesAnswers(t *testing.T) {
+	s := newTestServices(t, testConfig{})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "Question to delete"})
+	require.NoError(t, err)
+
+	answer := &models.Answer{QuestionID: uint(question.ID), UserID: registerTestUser(t, s.users), Text: "Some answer"}
+	require.NoError(t, s.answerRepo.Create(answer))
+
+	require.NoError(t, s.questions.DeleteQuestion(uint(question.ID)))
+
+	_, err = s.questions.GetQuestion(uint(question.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	_, err = s.answerRepo.FindByID(uint(answer.ID))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReputationRules maps a reputation reason to the points it awards; see
+// config.ReputationConfig. Reasons without a rule award nothing.
+type ReputationRules map[string]int
+
+type ReputationService interface {
+	GetUserReputation(userID uuid.UUID, page repositories.Page) (*UserReputation, error)
+}
+
+// UserReputation is a user's reputation with one page of its history.
+type UserReputation struct {
+	UserID     uuid.UUID                 `json:"user_id"`
+	Reputation int                       `json:"reputation"`
+	History    []*models.ReputationEvent `json:"history"`
+	Total      int                       `json:"-"`
+	HasMore    bool                      `json:"-"`
+}
+
+type reputationService struct {
+	userRepository       repositories.UserRepository
+	reputationRepository repositories.ReputationRepository
+}
+
+func NewReputationService(
+	userRepository repositories.UserRepository,
+	reputationRepository repositories.ReputationRepository,
+) ReputationService {
+	return &reputationService{
+		userRepository,
+		reputationRepository,
+	}
+}
+
+// GetUserReputation returns ErrUserNotFound for an unknown user.
+func (r reputationService) GetUserReputation(userID uuid.UUID, page repositories.Page) (*UserReputation, error) {
+	user, err := r.userRepository.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	history, hasMore, err := findPage(page, func(page repositories.Page) ([]*models.ReputationEvent, error) {
+		return r.reputationRepository.FindByUser(userID, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := r.reputationRepository.CountByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserReputation{
+		UserID:     userID,
+		Reputation: user.Reputation,
+		History:    history,
+		Total:      int(total),
+		HasMore:    hasMore,
+	}, nil
+}
+
+// awardAnswer records points for the author of answer. Zero points, a rule
+// that is turned off or an answer that earned nothing, record nothing.
+func awardAnswer(reputation repositories.ReputationRepository, answer *models.Answer, reason string, points int) error {
+	if points == 0 {
+		return nil
+	}
+	return reputation.Award(&models.ReputationEvent{
+		UserID:     answer.UserID,
+		Reason:     reason,
+		Points:     points,
+		QuestionID: answer.QuestionID,
+		AnswerID:   uint(answer.ID),
+	})
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputationService_AnswersAwardAndTakeBack(t *testing.T) {
+	s := newTestServices(t, testConfig{rules: ReputationRules{models.ReputationAnswerPosted: 2}})
+
+	question, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	userID := registerTestUser(t, s.users)
+	first, err := s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: userID, Text: "A language"})
+	require.NoError(t, err)
+	_, err = s.answers.CreateAnswer(uint(question.ID), &models.Answer{UserID: userID, Text: "A gopher"})
+	require.NoError(t, err)
+
+	reputation, err := s.reputation.GetUserReputation(userID, repositories.Page{Sort: repositories.NewestFirst})
+	require.NoError(t, err)
+	assert.Equal(t, 4, reputation.Reputation)
+	assert.Equal(t, 2, reputation.Total)
+
+	require.NoError(t, s.answers.DeleteAnswer(uint(first.ID)))
+
+	reputation, err = s.reputation.GetUserReputation(userID, repositories.Page{Sort: repositories.NewestFirst})
+	require.NoError(t, err)
+	assert.Equal(t, 2, reputation.Reputation)
+	require.Len(t, reputation.History, 3)
+	assert.Equal(t, models.ReputationAnswerDeleted, reputation.History[0].Reason)
+	assert.Equal(t, -2, reputation.History[0].Points)
+	assert.Equal(t, uint(first.ID), reputation.History[0].AnswerID)
+
+	_, err = s.reputation.GetUserReputation(uuid.New(), repositories.Page{})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestReputationService_DeleteQuestionTakesBackAnswers(t *testing.T) {
+	s := newTestServices(t, testConfig{rules: ReputationRules{models.ReputationAnswerPosted: 2}})
+
+	deleted, err := s.questions.CreateQuestion(&models.Question{Text: "What is Go?"})
+	require.NoError(t, err)
+	kept, err := s.questions.CreateQuestion(&models.Question{Text: "What is Rust?"})
+	require.NoError(t, err)
+
+	author, other := registerTestUser(t, s.users), registerTestUser(t, s.users)
+	for _, answer := range []struct {
+		questionID int
+		userID     uuid.UUID
+	}{{deleted.ID, author}, {deleted.ID, author}, {deleted.ID, other}, {kept.ID, author}} {
+		_, err := s.answers.CreateAnswer(uint(answer.questionID), &models.Answer{UserID: answer.userID, Text: "An answer"})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, s.questions.DeleteQuestion(uint(deleted.ID)))
+
+	reputation, err := s.reputation.GetUserReputation(author, repositories.Page{Sort: repositories.NewestFirst})
+	require.NoError(t, err)
+	assert.Equal(t, 2, reputation.Reputation, "only the answer to the kept question counts")
+	require.Len(t, reputation.History, 5)
+	assert.Equal(t, models.ReputationAnswerDeleted, reputation.History[0].Reason)
+	assert.Equal(t, -2, reputation.History[0].Points)
+
+	reputation, err = s.reputation.GetUserReputation(other, repositories.Page{})
+	require.NoError(t, err)
+	assert.Zero(t, reputation.Reputation)
+}
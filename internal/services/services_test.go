@@ -0,0 +1,111 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/blob"
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/filter"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig tunes the services built by newTestServices.
+type testConfig struct {
+	filters       filter.Chain
+	rules         ReputationRules
+	flagThreshold int
+	// publisher receives every event: the outbox is relayed to it on each
+	// commit. Without a publisher or notify, recorded events stay pending in
+	// the outbox.
+	publisher events.Publisher
+	// notify delivers the events to the notification service as well.
+	notify bool
+}
+
+// testServices wires the services over one memory store the way the server
+// does. Attachments accept PNG and text files of up to 64 bytes.
+type testServices struct {
+	questions     QuestionService
+	answers       AnswerService
+	moderation    ModerationService
+	reputation    ReputationService
+	notifications NotificationService
+	attachments   AttachmentService
+	activity      ActivityService
+
+	questionRepo repositories.QuestionRepository
+	answerRepo   repositories.AnswerRepository
+	users        repositories.UserRepository
+	outbox       repositories.OutboxRepository
+	blobs        blob.Store
+}
+
+func newTestServices(t *testing.T, cfg testConfig) testServices {
+	t.Helper()
+
+	blobs, err := blob.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	store := memory.NewStore()
+	questionRepo := memory.NewQuestionRepository(store)
+	answerRepo := memory.NewAnswerRepository(store)
+	attachmentRepo := memory.NewAttachmentRepository(store)
+	userRepo := memory.NewUserRepository(store)
+	outboxRepo := memory.NewOutboxRepository(store)
+	notifications := NewNotificationService(questionRepo, memory.NewFollowRepository(store), memory.NewNotificationRepository(store))
+
+	var subscribers []events.Publisher
+	if cfg.notify {
+		subscribers = append(subscribers, notifications)
+	}
+	if cfg.publisher != nil {
+		subscribers = append(subscribers, cfg.publisher)
+	}
+
+	wake := func() {}
+	if len(subscribers) > 0 {
+		publisher := events.Fanout(subscribers...)
+		relay := outbox.NewRelay(outboxRepo, []outbox.Sink{outbox.BusSink(publisher)}, nil, config.OutboxConfig{BatchSize: 10, MaxAttempts: 1})
+		wake = func() {
+			require.NoError(t, relay.ProcessPending(context.Background()))
+		}
+	}
+	transactor := memory.NewTransactor(store, wake)
+
+	questions := NewQuestionService(questionRepo, userRepo, transactor, cfg.filters)
+	questions = DeleteQuestionAttachments(questions, attachmentRepo, blobs)
+	answers := NewAnswerService(questionRepo, answerRepo, userRepo, transactor, cfg.rules, cfg.filters)
+	answers = DeleteAnswerAttachments(answers, attachmentRepo, blobs)
+
+	return testServices{
+		questions: questions,
+		answers:   answers,
+		moderation: NewModerationService(questionRepo, answerRepo, userRepo, memory.NewModerationRepository(store),
+			transactor, questions, answers, cfg.rules, cfg.flagThreshold),
+		reputation:    NewReputationService(userRepo, memory.NewReputationRepository(store)),
+		notifications: notifications,
+		attachments:   NewAttachmentService(questionRepo, answerRepo, attachmentRepo, blobs, 64, []string{"image/png", "text/plain"}),
+		activity:      NewActivityService(questionRepo, answerRepo),
+		questionRepo:  questionRepo,
+		answerRepo:    answerRepo,
+		users:         userRepo,
+		outbox:        outboxRepo,
+		blobs:         blobs,
+	}
+}
+
+// registerTestUser adds an active user to users and returns its id.
+func registerTestUser(t *testing.T, users repositories.UserRepository) uuid.UUID {
+	t.Helper()
+
+	user := &models.User{ID: uuid.New(), DisplayName: "Tester", Status: models.UserActive}
+	require.NoError(t, users.Create(user))
+	return user.ID
+}
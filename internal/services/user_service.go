@@ -0,0 +1,95 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrUserSuspended = errors.New("user is suspended")
+	ErrEmailTaken    = errors.New("email is already registered")
+)
+
+type UserService interface {
+	RegisterUser(request *models.User) (*models.User, error)
+	GetUser(id uuid.UUID) (*models.User, error)
+	SetUserStatus(id uuid.UUID, status string) (*models.User, error)
+}
+
+type userService struct {
+	userRepository repositories.UserRepository
+}
+
+func NewUserService(userRepository repositories.UserRepository) UserService {
+	return &userService{
+		userRepository,
+	}
+}
+
+// RegisterUser creates an active user with a new id. The email is
+// lower-cased and ErrEmailTaken is returned when it is already registered.
+func (u userService) RegisterUser(request *models.User) (*models.User, error) {
+	email := strings.ToLower(strings.TrimSpace(*request.Email))
+	user := &models.User{
+		ID:          uuid.New(),
+		DisplayName: strings.TrimSpace(request.DisplayName),
+		Email:       &email,
+		Status:      models.UserActive,
+		CreatedAt:   time.Now(),
+	}
+
+	err := u.userRepository.Create(user)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEmailTaken
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUser returns ErrUserNotFound for an unknown user.
+func (u userService) GetUser(id uuid.UUID) (*models.User, error) {
+	user, err := u.userRepository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u userService) SetUserStatus(id uuid.UUID, status string) (*models.User, error) {
+	err := u.userRepository.UpdateStatus(id, status)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return u.GetUser(id)
+}
+
+// activeUser returns ErrUserNotFound or ErrUserSuspended unless id is a
+// user that may write content.
+func activeUser(users repositories.UserRepository, id uuid.UUID) error {
+	user, err := users.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.Status == models.UserSuspended {
+		return ErrUserSuspended
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_RegisterAndSuspend(t *testing.T) {
+	userService := NewUserService(memory.NewUserRepository(memory.NewStore()))
+
+	email := " Ada@Example.com "
+	user, err := userService.RegisterUser(&models.User{DisplayName: " Ada ", Email: &email})
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, user.ID)
+	assert.Equal(t, "Ada", user.DisplayName)
+	assert.Equal(t, "ada@example.com", *user.Email)
+	assert.Equal(t, models.UserActive, user.Status)
+
+	again := "ADA@example.com"
+	_, err = userService.RegisterUser(&models.User{DisplayName: "Ada again", Email: &again})
+	assert.ErrorIs(t, err, ErrEmailTaken)
+
+	suspended, err := userService.SetUserStatus(user.ID, models.UserSuspended)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserSuspended, suspended.Status)
+
+	_, err = userService.GetUser(uuid.New())
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	_, err = userService.SetUserStatus(uuid.New(), models.UserActive)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
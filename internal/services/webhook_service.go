@@ -0,0 +1,113 @@
+package services
+
+import (
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+type WebhookService interface {
+	CreateWebhook(request *models.Webhook) (*models.Webhook, error)
+	GetAllWebhooks() ([]*models.Webhook, error)
+	GetWebhook(id uint) (*models.Webhook, error)
+	DeleteWebhook(id uint) error
+	GetDeliveries(webhookID uint) ([]*models.WebhookDelivery, error)
+	Redeliver(deliveryID uint) (*models.WebhookDelivery, error)
+}
+
+type webhookService struct {
+	webhookRepository repositories.WebhookRepository
+	wake              func()
+}
+
+// NewWebhookService calls wake after queueing a redelivery so the delivery
+// worker picks it up.
+func NewWebhookService(
+	webhookRepository repositories.WebhookRepository,
+	wake func(),
+) WebhookService {
+	return &webhookService{
+		webhookRepository,
+		wake,
+	}
+}
+
+func (s webhookService) CreateWebhook(request *models.Webhook) (*models.Webhook, error) {
+	webhook := &models.Webhook{
+		URL:       request.URL,
+		Secret:    request.Secret,
+		Events:    request.Events,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+
+	if webhook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhook.Secret = secret
+	}
+
+	err := s.webhookRepository.Create(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s webhookService) GetAllWebhooks() ([]*models.Webhook, error) {
+	return s.webhookRepository.FindAll()
+}
+
+func (s webhookService) GetWebhook(id uint) (*models.Webhook, error) {
+	return s.webhookRepository.FindByID(id)
+}
+
+func (s webhookService) DeleteWebhook(id uint) error {
+	return s.webhookRepository.Delete(id)
+}
+
+func (s webhookService) GetDeliveries(webhookID uint) ([]*models.WebhookDelivery, error) {
+	_, err := s.webhookRepository.FindByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookRepository.FindDeliveries(webhookID)
+}
+
+// Redeliver queues a new delivery with the payload of an earlier one.
+func (s webhookService) Redeliver(deliveryID uint) (*models.WebhookDelivery, error) {
+	original, err := s.webhookRepository.FindDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now(),
+		CreatedAt:     time.Now(),
+	}
+
+	err = s.webhookRepository.CreateDelivery(delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	s.wake()
+	return delivery, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
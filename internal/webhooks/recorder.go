@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/outbox"
+	"api_service_questions_and_answers/internal/repositories"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// payload is the JSON body posted to webhook endpoints.
+type payload struct {
+	Event      string    `json:"event"`
+	QuestionID uint      `json:"question_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Data       any       `json:"data"`
+}
+
+type recorder struct {
+	wake func()
+}
+
+// NewRecorder records a pending delivery for every active webhook subscribed
+// to an outbox event, in the transaction that claims the event, and calls
+// wake once they are committed so the worker sends them right away.
+func NewRecorder(wake func()) outbox.Recorder {
+	return &recorder{
+		wake,
+	}
+}
+
+func (recorder) Name() string {
+	return "webhooks"
+}
+
+func (r recorder) Record(tx repositories.Repositories, _ int, event events.Event) error {
+	webhooks, err := tx.Webhooks.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks: %w", err)
+	}
+
+	now := time.Now()
+	var body []byte
+	for _, webhook := range webhooks {
+		if !webhook.Active || !webhook.Accepts(event.Type) {
+			continue
+		}
+
+		if body == nil {
+			body, err = json.Marshal(payload{
+				Event:      event.Type,
+				QuestionID: event.QuestionID,
+				CreatedAt:  event.CreatedAt,
+				Data:       event.Data,
+			})
+			if err != nil {
+				log.Printf("Failed to encode webhook payload for %s: %v", event.Type, err)
+				return nil
+			}
+		}
+
+		delivery := &models.WebhookDelivery{
+			WebhookID:     uint(webhook.ID),
+			Event:         event.Type,
+			Payload:       string(body),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := tx.Webhooks.CreateDelivery(delivery); err != nil {
+			return fmt.Errorf("failed to record delivery for webhook %d: %w", webhook.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r recorder) Recorded() {
+	r.wake()
+}
@@ -0,0 +1,180 @@
+package webhooks
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const batchSize = 50
+
+// Worker sends the pending deliveries recorded by NewRecorder and
+// reschedules failed ones with exponential backoff until max_attempts is
+// reached.
+type Worker struct {
+	repository   repositories.WebhookRepository
+	client       *http.Client
+	pollInterval time.Duration
+	maxAttempts  int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	// lease is how long a claimed batch stays hidden from other workers;
+	// every delivery in it may take up to the client timeout.
+	lease time.Duration
+	wake  chan struct{}
+	now   func() time.Time
+}
+
+func NewWorker(repository repositories.WebhookRepository, cfg config.WebhooksConfig) *Worker {
+	return &Worker{
+		repository:   repository,
+		client:       &http.Client{Timeout: cfg.Timeout},
+		pollInterval: cfg.PollInterval,
+		maxAttempts:  cfg.MaxAttempts,
+		backoff:      cfg.Backoff,
+		maxBackoff:   cfg.MaxBackoff,
+		lease:        batchSize * cfg.Timeout,
+		wake:         make(chan struct{}, 1),
+		now:          time.Now,
+	}
+}
+
+// Wake makes Run check for due deliveries without waiting for the next poll.
+func (w *Worker) Wake() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run processes deliveries until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.ProcessDue(ctx); err != nil {
+			log.Printf("Webhook worker error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// ProcessDue attempts every delivery whose next attempt time has passed.
+func (w *Worker) ProcessDue(ctx context.Context) error {
+	for {
+		now := w.now()
+		deliveries, err := w.repository.ClaimDueDeliveries(now, now.Add(w.lease), batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load due deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				return nil
+			}
+			w.attempt(ctx, delivery)
+			if err := w.repository.UpdateDelivery(delivery); err != nil {
+				return fmt.Errorf("failed to update delivery %d: %w", delivery.ID, err)
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return nil
+		}
+	}
+}
+
+func (w *Worker) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	webhook, err := w.repository.FindByID(delivery.WebhookID)
+	if err != nil || !webhook.Active {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			w.fail(delivery, 0, err)
+			return
+		}
+		delivery.Status = models.DeliveryFailed
+		delivery.LastError = "webhook is removed or inactive"
+		return
+	}
+
+	statusCode, err := w.send(ctx, webhook, delivery)
+	if err != nil {
+		w.fail(delivery, statusCode, err)
+		return
+	}
+
+	now := w.now()
+	delivery.Attempts++
+	delivery.Status = models.DeliveryDelivered
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	delivery.DeliveredAt = &now
+}
+
+func (w *Worker) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := w.now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+func (w *Worker) fail(delivery *models.WebhookDelivery, statusCode int, err error) {
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = err.Error()
+
+	if delivery.Attempts >= w.maxAttempts {
+		delivery.Status = models.DeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = w.now().Add(w.backoffFor(delivery.Attempts))
+}
+
+// backoffFor doubles the base delay with every attempt, up to maxBackoff.
+func (w *Worker) backoffFor(attempts int) time.Duration {
+	delay := w.backoff
+	for i := 1; i < attempts && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.maxBackoff {
+		delay = w.maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"api_service_questions_and_answers/internal/config"
+	"api_service_questions_and_answers/internal/events"
+	"api_service_questions_and_answers/internal/models"
+	"api_service_questions_and_answers/internal/repositories"
+	"api_service_questions_and_answers/internal/repositories/memory"
+	"api_service_questions_and_answers/internal/services"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver records requests and answers with the next queued status code,
+// then 200 once the queue is empty.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, received{r.Header.Clone(), body})
+
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func setup(t *testing.T, statuses ...int) (*Worker, services.WebhookService, repositories.WebhookRepository, *receiver, *models.Webhook) {
+	t.Helper()
+
+	rc := &receiver{statuses: statuses}
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	repo := memory.NewWebhookRepository(memory.NewStore())
+	worker := NewWorker(repo, config.WebhooksConfig{
+		PollInterval: time.Second,
+		Timeout:      time.Second,
+		MaxAttempts:  3,
+		Backoff:      10 * time.Second,
+		MaxBackoff:   15 * time.Second,
+	})
+	service := services.NewWebhookService(repo, func() {})
+
+	webhook, err := service.CreateWebhook(&models.Webhook{URL: server.URL, Events: []string{events.AnswerCreated}})
+	require.NoError(t, err)
+
+	return worker, service, repo, rc, webhook
+}
+
+func TestWorker_DeliversSignedPayload(t *testing.T) {
+	worker, _, repo, rc, webhook := setup(t)
+
+	record(t, repo, events.Event{Type: events.AnswerCreated, QuestionID: 1, Data: map[string]string{"text": "hi"}})
+	record(t, repo, events.Event{Type: events.QuestionCreated, QuestionID: 1})
+	require.NoError(t, worker.ProcessDue(context.Background()))
+
+	require.Len(t, rc.requests, 1)
+	req := rc.requests[0]
+	assert.Equal(t, events.AnswerCreated, req.header.Get(HeaderEvent))
+	assert.JSONEq(t, `{"text":"hi"}`, extractData(t, req.body))
+
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, Verify(webhook.Secret, timestamp, req.body, req.header.Get(HeaderSignature)))
+
+	deliveries, err := repo.FindDeliveries(uint(webhook.ID))
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, models.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+}
+
+func TestRecorder_RecordsPendingDeliveries(t *testing.T) {
+	worker, _, repo, rc, webhook := setup(t)
+
+	woken := false
+	recorder := NewRecorder(func() { woken = true })
+	require.NoError(t, recorder.Record(repositories.Repositories{Webhooks: repo}, 1, events.Event{Type: events.AnswerCreated, QuestionID: 1}))
+	assert.Equal(t, models.DeliveryPending, onlyDelivery(t, repo, webhook).Status)
+	assert.Empty(t, rc.requests, "the worker sends deliveries")
+
+	recorder.Recorded()
+	assert.True(t, woken)
+
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	assert.Equal(t, models.DeliveryDelivered, onlyDelivery(t, repo, webhook).Status)
+	assert.Len(t, rc.requests, 1)
+}
+
+func TestWorker_SkipsDeliveriesClaimedByAnotherWorker(t *testing.T) {
+	worker, _, repo, rc, webhook := setup(t)
+
+	record(t, repo, events.Event{Type: events.AnswerCreated, QuestionID: 1})
+
+	now := time.Now()
+	_, err := repo.ClaimDueDeliveries(now, now.Add(time.Minute), batchSize)
+	require.NoError(t, err)
+
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	assert.Empty(t, rc.requests)
+	assert.Equal(t, models.DeliveryPending, onlyDelivery(t, repo, webhook).Status)
+}
+
+func TestWorker_RetriesWithBackoffUntilMaxAttempts(t *testing.T) {
+	worker, _, repo, rc, webhook := setup(t, 500, 500, 500)
+
+	record(t, repo, events.Event{Type: events.AnswerCreated, QuestionID: 1})
+
+	now := time.Now()
+	worker.now = func() time.Time { return now }
+
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	delivery := onlyDelivery(t, repo, webhook)
+	assert.Equal(t, models.DeliveryPending, delivery.Status)
+	assert.Equal(t, 500, delivery.LastStatusCode)
+	assert.Equal(t, now.Add(10*time.Second), delivery.NextAttemptAt)
+
+	// Not due yet.
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	assert.Len(t, rc.requests, 1)
+
+	now = now.Add(10 * time.Second)
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	delivery = onlyDelivery(t, repo, webhook)
+	assert.Equal(t, now.Add(15*time.Second), delivery.NextAttemptAt, "backoff is capped")
+
+	now = now.Add(15 * time.Second)
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	delivery = onlyDelivery(t, repo, webhook)
+	assert.Equal(t, models.DeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Len(t, rc.requests, 3)
+}
+
+func TestWorker_RedeliverSendsAgain(t *testing.T) {
+	worker, service, repo, rc, webhook := setup(t, 410, 410, 410)
+
+	worker.maxAttempts = 1
+	record(t, repo, events.Event{Type: events.AnswerCreated, QuestionID: 1})
+	require.NoError(t, worker.ProcessDue(context.Background()))
+	failed := onlyDelivery(t, repo, webhook)
+	require.Equal(t, models.DeliveryFailed, failed.Status)
+
+	rc.statuses = nil
+	redelivery, err := service.Redeliver(uint(failed.ID))
+	require.NoError(t, err)
+	require.NoError(t, worker.ProcessDue(context.Background()))
+
+	stored, err := repo.FindDeliveryByID(uint(redelivery.ID))
+	require.NoError(t, err)
+	assert.Equal(t, models.DeliveryDelivered, stored.Status)
+	assert.Equal(t, string(rc.requests[0].body), string(rc.requests[1].body))
+}
+
+// record runs the recorder the way the outbox relay does.
+func record(t *testing.T, repo repositories.WebhookRepository, event events.Event) {
+	t.Helper()
+
+	event.CreatedAt = time.Now()
+	require.NoError(t, NewRecorder(func() {}).Record(repositories.Repositories{Webhooks: repo}, 1, event))
+}
+
+func onlyDelivery(t *testing.T, repo repositories.WebhookRepository, webhook *models.Webhook) *models.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := repo.FindDeliveries(uint(webhook.ID))
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	return deliveries[0]
+}
+
+func extractData(t *testing.T, body []byte) string {
+	t.Helper()
+
+	var payload struct {
+		Data json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	return string(payload.Data)
+}
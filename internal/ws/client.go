@@ -0,0 +1,192 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	maxMessageSize = 4096
+)
+
+// request is a message sent by the client.
+type request struct {
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+// reply acknowledges a request or reports an error to the client.
+type reply struct {
+	Type   string   `json:"type"`
+	Topics []string `json:"topics,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// Client is one WebSocket connection. Outgoing messages wait in a bounded
+// queue; when the client cannot keep up, the oldest messages are dropped.
+type Client struct {
+	hub    *Hub
+	mu     sync.Mutex
+	topics map[string]struct{}
+	queue  [][]byte
+	notify chan struct{}
+}
+
+func newClient(hub *Hub) *Client {
+	return &Client{
+		hub:    hub,
+		topics: make(map[string]struct{}),
+		notify: make(chan struct{}, 1),
+	}
+}
+
+func (c *Client) subscribedToAny(topics []string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, topic := range topics {
+		if _, ok := c.topics[topic]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) enqueue(message []byte) {
+	c.mu.Lock()
+	if len(c.queue) >= c.hub.sendBuffer {
+		c.queue = c.queue[1:]
+	}
+	c.queue = append(c.queue, message)
+	c.mu.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) dequeueAll() [][]byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queue := c.queue
+	c.queue = nil
+	return queue
+}
+
+// Close releases the connection slot without serving a connection.
+func (c *Client) Close() {
+	c.hub.unregister(c)
+}
+
+// Serve runs the connection until the client goes away.
+func (c *Client) Serve(conn *websocket.Conn) {
+	defer c.Close()
+	defer conn.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.readLoop(conn)
+	}()
+
+	c.writeLoop(conn, done)
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	pongWait := 2 * c.hub.pingInterval
+
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("Websocket read error: %v", err)
+			}
+			return
+		}
+		c.handle(data)
+	}
+}
+
+func (c *Client) handle(data []byte) {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		c.reply(reply{Type: "error", Error: "invalid message"})
+		return
+	}
+
+	topics := make([]string, 0, len(req.Topics))
+	for _, topic := range req.Topics {
+		normalized, err := NormalizeTopic(topic)
+		if err != nil {
+			c.reply(reply{Type: "error", Error: err.Error()})
+			return
+		}
+		topics = append(topics, normalized)
+	}
+
+	c.mu.Lock()
+	switch req.Action {
+	case "subscribe":
+		for _, topic := range topics {
+			c.topics[topic] = struct{}{}
+		}
+	case "unsubscribe":
+		for _, topic := range topics {
+			delete(c.topics, topic)
+		}
+	default:
+		c.mu.Unlock()
+		c.reply(reply{Type: "error", Error: "unknown action " + strings.TrimSpace(req.Action)})
+		return
+	}
+	c.mu.Unlock()
+
+	c.reply(reply{Type: req.Action + "d", Topics: topics})
+}
+
+func (c *Client) reply(r reply) {
+	message, err := json.Marshal(r)
+	if err != nil {
+		log.Printf("Error encoding websocket reply: %v", err)
+		return
+	}
+	c.enqueue(message)
+}
+
+func (c *Client) writeLoop(conn *websocket.Conn, done <-chan struct{}) {
+	ping := time.NewTicker(c.hub.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-c.notify:
+			for _, message := range c.dequeueAll() {
+				_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+					return
+				}
+			}
+		case <-ping.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
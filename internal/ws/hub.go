@@ -0,0 +1,129 @@
+// Package ws delivers domain events to WebSocket clients subscribed to
+// topics: "questions", "question:{id}" and "user:{uuid}".
+package ws
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const TopicQuestions = "questions"
+
+var ErrTooManyConnections = errors.New("too many websocket connections")
+
+// Hub tracks connected clients and routes published events to them.
+type Hub struct {
+	mu             sync.Mutex
+	clients        map[*Client]struct{}
+	lastID         uint64
+	maxConnections int
+	sendBuffer     int
+	pingInterval   time.Duration
+}
+
+func NewHub(maxConnections, sendBuffer int, pingInterval time.Duration) *Hub {
+	return &Hub{
+		clients:        make(map[*Client]struct{}),
+		maxConnections: maxConnections,
+		sendBuffer:     sendBuffer,
+		pingInterval:   pingInterval,
+	}
+}
+
+// Register reserves a connection slot. It fails once the configured
+// connection limit is reached.
+func (h *Hub) Register() (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxConnections > 0 && len(h.clients) >= h.maxConnections {
+		return nil, ErrTooManyConnections
+	}
+
+	client := newClient(h)
+	h.clients[client] = struct{}{}
+	return client, nil
+}
+
+func (h *Hub) unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, client)
+}
+
+// Connections reports how many clients are registered.
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.clients)
+}
+
+func (h *Hub) Publish(event events.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event.ID = h.lastID
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding websocket event: %v", err)
+		return
+	}
+
+	topics := Topics(event)
+	for client := range h.clients {
+		if client.subscribedToAny(topics) {
+			client.enqueue(message)
+		}
+	}
+}
+
+// Topics returns every topic an event is delivered to.
+func Topics(event events.Event) []string {
+	topics := []string{fmt.Sprintf("question:%d", event.QuestionID)}
+	if strings.HasPrefix(event.Type, "question.") {
+		topics = append(topics, TopicQuestions)
+	}
+	if event.UserID != uuid.Nil {
+		topics = append(topics, "user:"+event.UserID.String())
+	}
+	return topics
+}
+
+// NormalizeTopic checks a topic requested by a client and returns it in
+// the form events are routed with.
+func NormalizeTopic(topic string) (string, error) {
+	switch {
+	case topic == TopicQuestions:
+		return topic, nil
+	case strings.HasPrefix(topic, "question:"):
+		id, err := strconv.ParseUint(strings.TrimPrefix(topic, "question:"), 10, 64)
+		if err != nil || id == 0 {
+			return "", fmt.Errorf("invalid question topic %q", topic)
+		}
+		return fmt.Sprintf("question:%d", id), nil
+	case strings.HasPrefix(topic, "user:"):
+		id, err := uuid.Parse(strings.TrimPrefix(topic, "user:"))
+		if err != nil {
+			return "", fmt.Errorf("invalid user topic %q", topic)
+		}
+		return "user:" + id.String(), nil
+	default:
+		return "", fmt.Errorf("unknown topic %q", topic)
+	}
+}
@@ -0,0 +1,61 @@
+package ws
+
+import (
+	"api_service_questions_and_answers/internal/events"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopics(t *testing.T) {
+	userID := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+
+	assert.ElementsMatch(t, []string{"question:1", "questions"},
+		Topics(events.Event{Type: events.QuestionCreated, QuestionID: 1}))
+	assert.ElementsMatch(t, []string{"question:1", "user:" + userID.String()},
+		Topics(events.Event{Type: events.AnswerCreated, QuestionID: 1, UserID: userID}))
+}
+
+func TestNormalizeTopic(t *testing.T) {
+	topic, err := NormalizeTopic("user:123E4567-E89B-12D3-A456-426614174000")
+	require.NoError(t, err)
+	assert.Equal(t, "user:123e4567-e89b-12d3-a456-426614174000", topic)
+
+	for _, invalid := range []string{"question:0", "question:abc", "user:nope", "answers"} {
+		_, err := NormalizeTopic(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestHub_ConnectionLimit(t *testing.T) {
+	hub := NewHub(1, 10, time.Minute)
+
+	client, err := hub.Register()
+	require.NoError(t, err)
+
+	_, err = hub.Register()
+	assert.ErrorIs(t, err, ErrTooManyConnections)
+
+	client.Close()
+	_, err = hub.Register()
+	assert.NoError(t, err)
+}
+
+func TestHub_DropsOldestWhenQueueIsFull(t *testing.T) {
+	hub := NewHub(10, 2, time.Minute)
+	client, err := hub.Register()
+	require.NoError(t, err)
+	client.topics["question:1"] = struct{}{}
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(events.Event{Type: events.AnswerCreated, QuestionID: 1})
+	}
+
+	queue := client.dequeueAll()
+	require.Len(t, queue, 2)
+	assert.Contains(t, string(queue[0]), `"id":2`)
+	assert.Contains(t, string(queue[1]), `"id":3`)
+}